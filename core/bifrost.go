@@ -5668,6 +5668,9 @@ func (bifrost *Bifrost) requestWorker(provider schemas.Provider, config *schemas
 		}
 
 		originalModelRequested := model
+		// Providers only see the model after the key's aliases resolve it, so they read the
+		// requested one from here (e.g. to log what an alias resolved from)
+		req.Context.SetValue(schemas.BifrostContextKeyOriginalModelRequested, originalModelRequested)
 		// resolvedModel is set inside the handler closures below on every attempt so that each
 		// key's own alias mapping is applied. The outer var holds the LAST attempt's value and is
 		// read single-threaded by the worker after retries finish (e.g. the error-fallback at
@@ -0,0 +1,129 @@
+package keyselectors
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/maximhq/bifrost/core/schemas"
+)
+
+// maxRoundRobinRotations bounds how many provider/model rotations are tracked; the least recently
+// used is dropped first and starts over if its model is requested again.
+const maxRoundRobinRotations = 4096
+
+// roundRobinSlot identifies a key in a rotation. Keys are told apart by a fingerprint of their ID,
+// name and value, and keys that share a fingerprint (e.g. several with no ID) by their order among
+// those, so none of them share a turn.
+type roundRobinSlot struct {
+	fingerprint uint64
+	occurrence  int
+}
+
+// roundRobinRotation is the rotation for one provider/model.
+type roundRobinRotation struct {
+	weights  map[roundRobinSlot]float64 // current weight per key
+	lastUsed uint64
+}
+
+// NewWeightedRoundRobin returns a KeySelector that rotates through keys in proportion to their weights
+// using smooth weighted round-robin, so consecutive requests are spread evenly instead of by chance.
+// Rotation state is tracked per provider and model, where model is the one requested, before the
+// keys' aliases resolve it, so each alias rotates on its own. Zero-weight keys are skipped unless
+// every key has zero weight, in which case all keys take equal turns.
+//
+// Set it as the KeySelector in BifrostConfig to use it instead of the default weighted random.
+func NewWeightedRoundRobin() schemas.KeySelector {
+	return newWeightedRoundRobin(maxRoundRobinRotations)
+}
+
+func newWeightedRoundRobin(maxRotations int) schemas.KeySelector {
+	var mu sync.Mutex
+	// provider/model -> rotation
+	state := make(map[string]*roundRobinRotation)
+	var uses uint64
+
+	return func(ctx *schemas.BifrostContext, keys []schemas.Key, providerKey schemas.ModelProvider, model string) (schemas.Key, error) {
+		if len(keys) == 1 {
+			return keys[0], nil
+		}
+
+		uniform := true
+		for _, key := range keys {
+			if key.Weight > 0 {
+				uniform = false
+				break
+			}
+		}
+
+		slots := make([]roundRobinSlot, len(keys))
+		occurrences := make(map[uint64]int, len(keys))
+		for i, key := range keys {
+			fingerprint := keyFingerprint(key)
+			slots[i] = roundRobinSlot{fingerprint: fingerprint, occurrence: occurrences[fingerprint]}
+			occurrences[fingerprint]++
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		rotationKey := string(providerKey) + "/" + model
+		previous := state[rotationKey]
+		if previous == nil {
+			previous = &roundRobinRotation{}
+			if len(state) >= maxRotations {
+				evictLeastRecentlyUsed(state)
+			}
+		}
+		current := make(map[roundRobinSlot]float64, len(keys))
+		totalWeight := 0.0
+		best := -1
+		for i, key := range keys {
+			weight := key.Weight
+			if uniform {
+				weight = 1
+			}
+			if weight <= 0 {
+				continue
+			}
+			current[slots[i]] = previous.weights[slots[i]] + weight
+			totalWeight += weight
+			if best < 0 || current[slots[i]] > current[slots[best]] {
+				best = i
+			}
+		}
+
+		// Fallback to first key if something goes wrong
+		if best < 0 {
+			return keys[0], nil
+		}
+
+		current[slots[best]] -= totalWeight
+		// Keys that are no longer offered drop out of the rotation
+		uses++
+		state[rotationKey] = &roundRobinRotation{weights: current, lastUsed: uses}
+		return keys[best], nil
+	}
+}
+
+// keyFingerprint hashes the key's ID, name and value, so the value itself is never kept.
+func keyFingerprint(key schemas.Key) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key.ID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key.Name))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key.Value.GetValue()))
+	return hash.Sum64()
+}
+
+// evictLeastRecentlyUsed drops the rotation that was used longest ago.
+func evictLeastRecentlyUsed(state map[string]*roundRobinRotation) {
+	var oldestKey string
+	var oldest *roundRobinRotation
+	for rotationKey, rotation := range state {
+		if oldest == nil || rotation.lastUsed < oldest.lastUsed {
+			oldestKey, oldest = rotationKey, rotation
+		}
+	}
+	delete(state, oldestKey)
+}
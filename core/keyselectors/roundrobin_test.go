@@ -0,0 +1,142 @@
+package keyselectors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedRoundRobin(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []schemas.Key
+		expected []string
+	}{
+		{
+			name:     "equal weights alternate",
+			keys:     []schemas.Key{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}},
+			expected: []string{"a", "b", "a", "b"},
+		},
+		{
+			name:     "weights are smoothed",
+			keys:     []schemas.Key{{ID: "a", Weight: 2}, {ID: "b", Weight: 1}},
+			expected: []string{"a", "b", "a", "a", "b", "a"},
+		},
+		{
+			name:     "zero weight keys are skipped",
+			keys:     []schemas.Key{{ID: "a", Weight: 1}, {ID: "b", Weight: 0}},
+			expected: []string{"a", "a", "a"},
+		},
+		{
+			name:     "all zero weights rotate uniformly",
+			keys:     []schemas.Key{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			expected: []string{"a", "b", "c", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := NewWeightedRoundRobin()
+			var got []string
+			for range tt.expected {
+				key, err := selector(nil, tt.keys, schemas.HuggingFace, "model")
+				assert.NoError(t, err)
+				got = append(got, key.ID)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestWeightedRoundRobin_StatePerModel(t *testing.T) {
+	selector := NewWeightedRoundRobin()
+	keys := []schemas.Key{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}}
+
+	first, _ := selector(nil, keys, schemas.HuggingFace, "model-1")
+	other, _ := selector(nil, keys, schemas.HuggingFace, "model-2")
+	second, _ := selector(nil, keys, schemas.HuggingFace, "model-1")
+
+	assert.Equal(t, "a", first.ID)
+	assert.Equal(t, "a", other.ID)
+	assert.Equal(t, "b", second.ID)
+}
+
+func TestWeightedRoundRobin_KeysWithoutDistinctIDs(t *testing.T) {
+	describe := func(key schemas.Key) string { return fmt.Sprintf("%s:%g", key.Name, key.Weight) }
+	tests := []struct {
+		name     string
+		keys     []schemas.Key
+		expected []string
+	}{
+		{
+			name:     "empty IDs",
+			keys:     []schemas.Key{{Name: "first", Weight: 1}, {Name: "second", Weight: 1}},
+			expected: []string{"first:1", "second:1", "first:1", "second:1"},
+		},
+		{
+			name:     "duplicate IDs",
+			keys:     []schemas.Key{{ID: "dup", Name: "first", Weight: 1}, {ID: "dup", Name: "second", Weight: 1}},
+			expected: []string{"first:1", "second:1", "first:1", "second:1"},
+		},
+		{
+			name:     "keys differing only in weight",
+			keys:     []schemas.Key{{ID: "dup", Weight: 2}, {ID: "dup", Weight: 1}},
+			expected: []string{":2", ":1", ":2", ":2", ":1", ":2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := NewWeightedRoundRobin()
+			var got []string
+			for range tt.expected {
+				key, err := selector(nil, tt.keys, schemas.HuggingFace, "model")
+				assert.NoError(t, err)
+				got = append(got, describe(key))
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestWeightedRoundRobin_Aliases(t *testing.T) {
+	selector := NewWeightedRoundRobin()
+	// Core passes the requested model, so an alias and the model it points at rotate separately
+	keys := []schemas.Key{
+		{ID: "a", Weight: 1, Aliases: schemas.KeyAliases{"fast": "groq/meta-llama/Llama-3.1-8B-Instruct"}},
+		{ID: "b", Weight: 1, Aliases: schemas.KeyAliases{"fast": "cerebras/meta-llama/Llama-3.1-8B-Instruct"}},
+	}
+
+	var viaAlias, direct []string
+	for range 2 {
+		key, err := selector(nil, keys, schemas.HuggingFace, "fast")
+		assert.NoError(t, err)
+		viaAlias = append(viaAlias, key.ID)
+		key, err = selector(nil, keys, schemas.HuggingFace, "groq/meta-llama/Llama-3.1-8B-Instruct")
+		assert.NoError(t, err)
+		direct = append(direct, key.ID)
+	}
+
+	assert.Equal(t, []string{"a", "b"}, viaAlias)
+	assert.Equal(t, []string{"a", "b"}, direct)
+}
+
+func TestWeightedRoundRobin_EvictsLeastRecentlyUsedModel(t *testing.T) {
+	selector := newWeightedRoundRobin(2)
+	keys := []schemas.Key{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}}
+	pick := func(model string) string {
+		key, err := selector(nil, keys, schemas.HuggingFace, model)
+		assert.NoError(t, err)
+		return key.ID
+	}
+
+	assert.Equal(t, "a", pick("model-1"))
+	assert.Equal(t, "a", pick("model-2"))
+	assert.Equal(t, "b", pick("model-1"))
+	// Tracking a third model drops model-2, the least recently used, which then starts over
+	assert.Equal(t, "a", pick("model-3"))
+	assert.Equal(t, "a", pick("model-1"))
+	assert.Equal(t, "a", pick("model-2"))
+}
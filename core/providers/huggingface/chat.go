@@ -2,12 +2,17 @@
 package huggingface
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/bytedance/sonic"
 
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
 	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
 )
 
 // sanitizeMessagesForHuggingFace removes unsupported ChatAssistantMessage fields
@@ -20,7 +25,7 @@ func sanitizeMessagesForHuggingFace(messages []schemas.ChatMessage) []schemas.Ch
 		sanitized[i] = schemas.ChatMessage{
 			Name:            msg.Name,
 			Role:            msg.Role,
-			Content:         msg.Content,
+			Content:         sanitizeContentForHuggingFace(msg.Content),
 			ChatToolMessage: msg.ChatToolMessage,
 		}
 		// Only preserve ToolCalls from ChatAssistantMessage
@@ -33,6 +38,33 @@ func sanitizeMessagesForHuggingFace(messages []schemas.ChatMessage) []schemas.Ch
 	return sanitized
 }
 
+// sanitizeContentForHuggingFace drops the Anthropic/Bedrock-only annotations (cache_control,
+// citations, cachePoint) from content blocks. Text, image_url and the other OpenAI parts are
+// kept as is, so vision models receive images exactly as the caller sent them. The caller's
+// blocks are copied rather than modified.
+func sanitizeContentForHuggingFace(content *schemas.ChatMessageContent) *schemas.ChatMessageContent {
+	if content == nil || !slices.ContainsFunc(content.ContentBlocks, func(block schemas.ChatContentBlock) bool {
+		return block.CacheControl != nil || block.Citations != nil || block.CachePoint != nil
+	}) {
+		return content
+	}
+
+	blocks := make([]schemas.ChatContentBlock, 0, len(content.ContentBlocks))
+	for _, block := range content.ContentBlocks {
+		// A bare Bedrock cache point marker carries no content at all
+		if block.CachePoint != nil && block.Text == nil && block.ImageURLStruct == nil && block.InputAudio == nil && block.File == nil {
+			continue
+		}
+		block.CacheControl = nil
+		block.Citations = nil
+		block.CachePoint = nil
+		blocks = append(blocks, block)
+	}
+	sanitized := *content
+	sanitized.ContentBlocks = blocks
+	return &sanitized
+}
+
 func ToHuggingFaceChatCompletionRequest(bifrostReq *schemas.BifrostChatRequest) (*HuggingFaceChatRequest, error) {
 	if bifrostReq == nil || bifrostReq.Input == nil {
 		return nil, nil
@@ -61,6 +93,9 @@ func ToHuggingFaceChatCompletionRequest(bifrostReq *schemas.BifrostChatRequest)
 		if params.PresencePenalty != nil {
 			hfReq.PresencePenalty = params.PresencePenalty
 		}
+		if params.Prediction != nil {
+			hfReq.Prediction = params.Prediction
+		}
 		if params.Seed != nil {
 			hfReq.Seed = params.Seed
 		}
@@ -76,6 +111,13 @@ func ToHuggingFaceChatCompletionRequest(bifrostReq *schemas.BifrostChatRequest)
 		if params.TopP != nil {
 			hfReq.TopP = params.TopP
 		}
+		if params.User != nil {
+			hfReq.User = params.User
+		}
+		// Copied so nothing done to the outbound payload can reach the caller's map
+		if params.Metadata != nil && len(*params.Metadata) > 0 {
+			hfReq.Metadata = maps.Clone(*params.Metadata)
+		}
 
 		// Handle response format (direct type assertion to avoid marshal→unmarshal round-trip)
 		if params.ResponseFormat != nil {
@@ -110,27 +152,21 @@ func ToHuggingFaceChatCompletionRequest(bifrostReq *schemas.BifrostChatRequest)
 		}
 
 		hfReq.Tools = params.Tools
+		hfReq.ParallelToolCalls = params.ParallelToolCalls
 
 		// Handle tool choice
 		if params.ToolChoice != nil {
 			hfToolChoice := &HuggingFaceToolChoice{}
 			if params.ToolChoice.ChatToolChoiceStr != nil {
-				switch *params.ToolChoice.ChatToolChoiceStr {
-				case "auto":
-					auto := EnumStringTypeAuto
-					hfToolChoice.EnumValue = &auto
-				case "none":
-					none := EnumStringTypeNone
-					hfToolChoice.EnumValue = &none
-				case "required":
-					required := EnumStringTypeRequired
-					hfToolChoice.EnumValue = &required
-				}
+				hfToolChoice.EnumValue = toHuggingFaceToolChoiceEnum(*params.ToolChoice.ChatToolChoiceStr)
 			} else if params.ToolChoice.ChatToolChoiceStruct != nil {
 				if params.ToolChoice.ChatToolChoiceStruct.Type == schemas.ChatToolChoiceTypeFunction && params.ToolChoice.ChatToolChoiceStruct.Function != nil {
 					hfToolChoice.Function = &schemas.ChatToolChoiceFunction{
 						Name: params.ToolChoice.ChatToolChoiceStruct.Function.Name,
 					}
+				} else {
+					// {"type": "auto"} and friends carry no more than the plain string form
+					hfToolChoice.EnumValue = toHuggingFaceToolChoiceEnum(string(params.ToolChoice.ChatToolChoiceStruct.Type))
 				}
 			}
 			if hfToolChoice.EnumValue != nil || hfToolChoice.Function != nil {
@@ -142,3 +178,264 @@ func ToHuggingFaceChatCompletionRequest(bifrostReq *schemas.BifrostChatRequest)
 
 	return hfReq, nil
 }
+
+// toHuggingFaceToolChoiceEnum maps a string tool choice onto the values TGI accepts. "any"
+// (Anthropic's spelling of "required") is translated; anything else unsupported yields nil.
+func toHuggingFaceToolChoiceEnum(choice string) *EnumStringType {
+	var value EnumStringType
+	switch schemas.ChatToolChoiceType(choice) {
+	case schemas.ChatToolChoiceTypeAuto:
+		value = EnumStringTypeAuto
+	case schemas.ChatToolChoiceTypeNone:
+		value = EnumStringTypeNone
+	case schemas.ChatToolChoiceTypeRequired, schemas.ChatToolChoiceTypeAny:
+		value = EnumStringTypeRequired
+	default:
+		return nil
+	}
+	return &value
+}
+
+// applyContextUser fills the chat `user` field from the authenticated user ID on the context
+// when enabled and the caller did not set one, so HF can attribute traffic for abuse monitoring.
+func (provider *HuggingFaceProvider) applyContextUser(ctx *schemas.BifrostContext, hfReq *HuggingFaceChatRequest) {
+	if hfReq.User != nil || !provider.huggingFaceConfig.ForwardUserIDFromContext || ctx == nil {
+		return
+	}
+	if userID, ok := ctx.Value(schemas.BifrostContextKeyUserID).(string); ok && userID != "" {
+		hfReq.User = schemas.Ptr(userID)
+	}
+}
+
+// dropUnsupportedPrediction removes the predicted output unless the request is routed to one of
+// the inference providers configured in PredictionInferenceProviders, so backends that don't
+// implement predicted outputs never see the field.
+func (provider *HuggingFaceProvider) dropUnsupportedPrediction(ctx context.Context, hfReq *HuggingFaceChatRequest, inferenceProvider inferenceProvider) {
+	if hfReq.Prediction == nil {
+		return
+	}
+	for _, supported := range provider.huggingFaceConfig.PredictionInferenceProviders {
+		if inferenceProvider != "" && strings.EqualFold(strings.TrimSpace(supported), string(inferenceProvider)) {
+			return
+		}
+	}
+	hfReq.Prediction = nil
+	provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: dropping prediction, inference provider %q is not in prediction_inference_providers", inferenceProvider), nil))
+}
+
+// streamUsageTracker post-processes chat stream chunks to label usage accuracy and, when
+// enabled, to replace the empty usage on the closing chunk with an estimate if HF never
+// reported usage during the stream.
+type streamUsageTracker struct {
+	estimate   bool
+	prompt     string
+	counter    func() tokenCounter // resolved lazily so tokenizers are only loaded when an estimate is needed
+	completion strings.Builder
+	reported   bool
+}
+
+func newStreamUsageTracker(request *schemas.BifrostChatRequest, estimate bool, counter func() tokenCounter) *streamUsageTracker {
+	return &streamUsageTracker{
+		estimate: estimate,
+		prompt:   chatPromptText(request.Input),
+		counter:  counter,
+	}
+}
+
+// convert is used as the postResponseConverter of the OpenAI-compatible stream handler. Choices
+// are only read, so content and tool-call deltas pass through untouched and in order; decoration
+// is confined to Usage and ExtraFields.
+func (t *streamUsageTracker) convert(response *schemas.BifrostChatResponse) *schemas.BifrostChatResponse {
+	t.observe(response.Choices)
+	t.decorate(&response.Usage, &response.ExtraFields)
+	return response
+}
+
+// observe accumulates the generated text (content and tool-call arguments) for completion estimates.
+func (t *streamUsageTracker) observe(choices []schemas.BifrostResponseChoice) {
+	for _, choice := range choices {
+		if choice.ChatStreamResponseChoice == nil || choice.ChatStreamResponseChoice.Delta == nil {
+			continue
+		}
+		delta := choice.ChatStreamResponseChoice.Delta
+		if delta.Content != nil {
+			t.completion.WriteString(*delta.Content)
+		}
+		for _, toolCall := range delta.ToolCalls {
+			if toolCall.Function.Name != nil {
+				t.completion.WriteString(*toolCall.Function.Name)
+			}
+			t.completion.WriteString(toolCall.Function.Arguments)
+		}
+	}
+}
+
+// decorate labels reported usage as exact, or replaces the handler's zero usage on the closing
+// chunk with an estimate when enabled and HF reported none.
+func (t *streamUsageTracker) decorate(usage **schemas.BifrostLLMUsage, extraFields *schemas.BifrostResponseExtraFields) {
+	if *usage == nil {
+		return
+	}
+	if (*usage).PromptTokens > 0 || (*usage).CompletionTokens > 0 || (*usage).TotalTokens > 0 {
+		t.reported = true
+		extraFields.UsageAccuracy = schemas.UsageAccuracyExact
+		return
+	}
+
+	// Zero usage only appears on the handler's closing chunk when HF reported none
+	if t.estimate && !t.reported {
+		counter := t.counter()
+		promptTokens := counter.countTokens(t.prompt)
+		completionTokens := counter.countTokens(t.completion.String())
+		*usage = &schemas.BifrostLLMUsage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+			TotalTokens:      promptTokens + completionTokens,
+		}
+		extraFields.UsageAccuracy = schemas.UsageAccuracyEstimated
+	}
+}
+
+// chatPromptText joins the text content of chat messages for token estimation.
+func chatPromptText(messages []schemas.ChatMessage) string {
+	var builder strings.Builder
+	for _, message := range messages {
+		if message.Content == nil {
+			continue
+		}
+		if message.Content.ContentStr != nil {
+			builder.WriteString(*message.Content.ContentStr)
+			builder.WriteString("\n")
+		}
+		for _, block := range message.Content.ContentBlocks {
+			if block.Text != nil {
+				builder.WriteString(*block.Text)
+				builder.WriteString("\n")
+			}
+		}
+	}
+	return builder.String()
+}
+
+// truncateAtStopSequences cuts each choice's text at the earliest stop sequence.
+// TGI-backed routes echo the matched stop sequence and some backends ignore stop
+// entirely, so the contract is enforced here as well.
+func truncateAtStopSequences(response *schemas.BifrostChatResponse, stop []string) {
+	if response == nil || len(stop) == 0 {
+		return
+	}
+	for i := range response.Choices {
+		choice := &response.Choices[i]
+		if choice.ChatNonStreamResponseChoice == nil || choice.Message == nil || choice.Message.Content == nil || choice.Message.Content.ContentStr == nil {
+			continue
+		}
+		content := *choice.Message.Content.ContentStr
+		cut := -1
+		for _, sequence := range stop {
+			if sequence == "" {
+				continue
+			}
+			if idx := strings.Index(content, sequence); idx >= 0 && (cut < 0 || idx < cut) {
+				cut = idx
+			}
+		}
+		if cut < 0 {
+			continue
+		}
+		choice.Message.Content.ContentStr = schemas.Ptr(content[:cut])
+		choice.FinishReason = schemas.Ptr(string(schemas.BifrostFinishReasonStop))
+	}
+}
+
+// isStreamingUnsupportedError reports whether a failed chat stream was rejected because the
+// model or its backend cannot stream, as opposed to a problem with the request itself.
+func isStreamingUnsupportedError(bifrostErr *schemas.BifrostError) bool {
+	if bifrostErr == nil || bifrostErr.StatusCode == nil || bifrostErr.Error == nil {
+		return false
+	}
+	switch *bifrostErr.StatusCode {
+	case fasthttp.StatusBadRequest, fasthttp.StatusUnprocessableEntity, fasthttp.StatusNotImplemented:
+	default:
+		return false
+	}
+	message := strings.ToLower(bifrostErr.Error.Message)
+	if !strings.Contains(message, "stream") {
+		return false
+	}
+	return strings.Contains(message, "not support") || strings.Contains(message, "unsupported") || strings.Contains(message, "not available")
+}
+
+// toChatStreamChunk repackages a non-streaming chat response as a single stream chunk carrying
+// each choice's whole message as its delta, with the response's usage and extra fields.
+func toChatStreamChunk(response *schemas.BifrostChatResponse) *schemas.BifrostChatResponse {
+	chunk := *response
+	chunk.Object = "chat.completion.chunk"
+	chunk.Choices = make([]schemas.BifrostResponseChoice, 0, len(response.Choices))
+	for _, choice := range response.Choices {
+		delta := &schemas.ChatStreamResponseChoiceDelta{}
+		if choice.ChatNonStreamResponseChoice != nil && choice.Message != nil {
+			message := choice.Message
+			if message.Role != "" {
+				delta.Role = schemas.Ptr(string(message.Role))
+			}
+			if message.Content != nil {
+				if message.Content.ContentStr != nil {
+					delta.Content = message.Content.ContentStr
+				} else {
+					var text strings.Builder
+					for _, block := range message.Content.ContentBlocks {
+						if block.Text != nil {
+							text.WriteString(*block.Text)
+						}
+					}
+					delta.Content = schemas.Ptr(text.String())
+				}
+			}
+			if message.ChatAssistantMessage != nil {
+				delta.Refusal = message.Refusal
+				delta.Audio = message.Audio
+				delta.Reasoning = message.Reasoning
+				delta.ReasoningDetails = message.ReasoningDetails
+				delta.ToolCalls = message.ToolCalls
+			}
+		}
+		chunk.Choices = append(chunk.Choices, schemas.BifrostResponseChoice{
+			Index:                    choice.Index,
+			FinishReason:             choice.FinishReason,
+			LogProbs:                 choice.LogProbs,
+			ChatStreamResponseChoice: &schemas.ChatStreamResponseChoice{Delta: delta},
+		})
+	}
+	return &chunk
+}
+
+// streamNonStreamingResponse sends a non-streaming chat response down a new stream channel as a
+// single chunk, or as the equivalent Responses events when the stream serves the Responses API.
+func streamNonStreamingResponse(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), response *schemas.BifrostChatResponse) chan *schemas.BifrostStreamChunk {
+	responseChan := make(chan *schemas.BifrostStreamChunk, schemas.DefaultStreamBufferSize)
+	chunk := toChatStreamChunk(response)
+
+	go func() {
+		defer providerUtils.EnsureStreamFinalizerCalled(ctx, postHookSpanFinalizer)
+		defer close(responseChan)
+
+		if isResponsesFallback, _ := ctx.Value(schemas.BifrostContextKeyIsResponsesToChatCompletionFallback).(bool); !isResponsesFallback {
+			ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+			providerUtils.ProcessAndSendResponse(ctx, postHookRunner, providerUtils.GetBifrostResponseForStreamResponse(nil, chunk, nil, nil, nil, nil), responseChan, postHookSpanFinalizer)
+			return
+		}
+
+		state := schemas.AcquireChatToResponsesStreamState()
+		defer schemas.ReleaseChatToResponsesStreamState(state)
+		events := chunk.ToBifrostResponsesStreamResponse(state)
+		for i, event := range events {
+			event.ExtraFields.ChunkIndex = event.SequenceNumber
+			if i == len(events)-1 {
+				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+			}
+			providerUtils.ProcessAndSendResponse(ctx, postHookRunner, providerUtils.GetBifrostResponseForStreamResponse(nil, nil, event, nil, nil, nil), responseChan, postHookSpanFinalizer)
+		}
+	}()
+
+	return responseChan
+}
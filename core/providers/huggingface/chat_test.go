@@ -1,14 +1,66 @@
 package huggingface
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	schemas "github.com/maximhq/bifrost/core/schemas"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// noopLogger is a no-op schemas.Logger for use in tests.
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                   {}
+func (noopLogger) Info(string, ...any)                    {}
+func (noopLogger) Warn(string, ...any)                    {}
+func (noopLogger) Error(string, ...any)                   {}
+func (noopLogger) Fatal(string, ...any)                   {}
+func (noopLogger) SetLevel(schemas.LogLevel)              {}
+func (noopLogger) SetOutputType(schemas.LoggerOutputType) {}
+func (noopLogger) LogHTTPRequest(schemas.LogLevel, string) schemas.LogEventBuilder {
+	return schemas.NoopLogEvent
+}
+
+// noopPostHookRunner is a PostHookRunner that passes through results unchanged.
+func noopPostHookRunner(_ *schemas.BifrostContext, result *schemas.BifrostResponse, err *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError) {
+	return result, err
+}
+
+// newTestHuggingFaceProvider returns a provider whose base URL points at the given test server.
+func newTestHuggingFaceProvider(t *testing.T, serverURL string) *HuggingFaceProvider {
+	t.Helper()
+	config := &schemas.ProviderConfig{
+		NetworkConfig: schemas.NetworkConfig{
+			BaseURL:                        serverURL,
+			DefaultRequestTimeoutInSeconds: 5,
+		},
+		// Keep usage estimates deterministic and off the network
+		HuggingFaceConfig: &schemas.HuggingFaceConfig{DisableTokenizerFetch: true},
+	}
+	return NewHuggingFaceProvider(config, noopLogger{})
+}
+
+func testHuggingFaceChatRequest(model string) *schemas.BifrostChatRequest {
+	return &schemas.BifrostChatRequest{
+		Provider: schemas.HuggingFace,
+		Model:    model,
+		Input: []schemas.ChatMessage{
+			{Role: schemas.ChatMessageRoleUser, Content: &schemas.ChatMessageContent{ContentStr: schemas.Ptr("hello")}},
+		},
+	}
+}
+
 func TestToHuggingFaceChatCompletionRequest_ResponseFormat(t *testing.T) {
 	makeReq := func(rf *interface{}) *schemas.BifrostChatRequest {
 		return &schemas.BifrostChatRequest{
@@ -130,3 +182,1567 @@ func TestToHuggingFaceChatCompletionRequest_ResponseFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestChatCompletionStream_MidStreamError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		for _, token := range []string{"Hel", "lo"} {
+			fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":%q}}]}\n\n", token)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		fmt.Fprint(w, "data: {\"error\":\"CUDA out of memory\",\"error_type\":\"generation\"}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"never\"}}]}\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+
+	var contents []string
+	var streamErr *schemas.BifrostError
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case chunk, ok := <-stream:
+			if !ok {
+				done = true
+				break
+			}
+			if chunk.BifrostError != nil {
+				streamErr = chunk.BifrostError
+				continue
+			}
+			if chunk.BifrostChatResponse != nil {
+				for _, choice := range chunk.BifrostChatResponse.Choices {
+					if choice.ChatStreamResponseChoice != nil && choice.ChatStreamResponseChoice.Delta != nil && choice.ChatStreamResponseChoice.Delta.Content != nil {
+						contents = append(contents, *choice.ChatStreamResponseChoice.Delta.Content)
+					}
+				}
+			}
+		case <-timeout:
+			t.Fatal("stream did not close after mid-stream error")
+		}
+	}
+
+	assert.Equal(t, []string{"Hel", "lo"}, contents)
+	require.NotNil(t, streamErr)
+	require.NotNil(t, streamErr.Error)
+	assert.Equal(t, "CUDA out of memory", streamErr.Error.Message)
+	require.NotNil(t, streamErr.Error.Type)
+	assert.Equal(t, "generation", *streamErr.Error.Type)
+}
+
+func TestChatCompletionStream_SkipsMalformedChunk(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":2,\"total_tokens\":5}}\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+
+	var contents []string
+	var last *schemas.BifrostChatResponse
+	for chunk := range stream {
+		require.Nil(t, chunk.BifrostError, "a malformed chunk does not end the stream")
+		require.NotNil(t, chunk.BifrostChatResponse)
+		last = chunk.BifrostChatResponse
+		for _, choice := range chunk.BifrostChatResponse.Choices {
+			if choice.ChatStreamResponseChoice != nil && choice.ChatStreamResponseChoice.Delta != nil && choice.ChatStreamResponseChoice.Delta.Content != nil {
+				contents = append(contents, *choice.ChatStreamResponseChoice.Delta.Content)
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"Hel", "lo"}, contents)
+	require.NotNil(t, last)
+	require.NotNil(t, last.Usage)
+	assert.Equal(t, 5, last.Usage.TotalTokens)
+}
+
+func TestChatCompletion_RateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "17")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+	require.NotNil(t, resp.ExtraFields.RateLimit)
+	assert.Equal(t, int64(1000), *resp.ExtraFields.RateLimit.Limit)
+	assert.Equal(t, int64(42), *resp.ExtraFields.RateLimit.Remaining)
+	assert.Equal(t, int64(17), *resp.ExtraFields.RateLimit.Reset)
+}
+
+func TestChatCompletion_RequestIDHeader(t *testing.T) {
+	t.Parallel()
+
+	var gotRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = r.Header.Get("X-Request-Id")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, "req-123")
+
+	_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, "req-123", gotRequestID)
+}
+
+func TestChatCompletion_UserField(t *testing.T) {
+	t.Parallel()
+
+	var captured []map[string]interface{}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		captured = append(captured, body)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	const model = "groq/meta-llama/Llama-3.3-70B-Instruct"
+
+	t.Run("explicit_user_forwarded", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		req := testHuggingFaceChatRequest(model)
+		req.Params = &schemas.ChatParameters{User: schemas.Ptr("user-123")}
+
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, req)
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, "user-123", captured[len(captured)-1]["user"])
+	})
+
+	t.Run("responses_conversion_preserves_user", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		req := &schemas.BifrostResponsesRequest{
+			Provider: schemas.HuggingFace,
+			Model:    model,
+			Input: []schemas.ResponsesMessage{{
+				Role:    schemas.Ptr(schemas.ResponsesInputMessageRoleUser),
+				Content: &schemas.ResponsesMessageContent{ContentStr: schemas.Ptr("hello")},
+			}},
+			Params: &schemas.ResponsesParameters{User: schemas.Ptr("user-456")},
+		}
+
+		_, bifrostErr := provider.Responses(ctx, schemas.Key{}, req)
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, "user-456", captured[len(captured)-1]["user"])
+	})
+
+	t.Run("context_user_when_enabled", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.huggingFaceConfig.ForwardUserIDFromContext = true
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		ctx.SetValue(schemas.BifrostContextKeyUserID, "tenant-user")
+
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest(model))
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, "tenant-user", captured[len(captured)-1]["user"])
+	})
+
+	t.Run("context_user_ignored_when_disabled", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		ctx.SetValue(schemas.BifrostContextKeyUserID, "tenant-user")
+
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest(model))
+		require.Nil(t, bifrostErr)
+		assert.NotContains(t, captured[len(captured)-1], "user")
+	})
+}
+
+func TestChatCompletion_UsageAccuracy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		usage        string
+		wantAccuracy schemas.UsageAccuracy
+	}{
+		{name: "exact_when_reported", usage: `,"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}`, wantAccuracy: schemas.UsageAccuracyExact},
+		{name: "unlabeled_without_usage", usage: "", wantAccuracy: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]%s}`, tt.usage)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, tt.wantAccuracy, resp.ExtraFields.UsageAccuracy)
+		})
+	}
+}
+
+func TestChatCompletion_ModelHubURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{name: "included_when_enabled", enabled: true, want: "https://huggingface.co/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "omitted_by_default", enabled: false, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.huggingFaceConfig.IncludeModelHubURL = tt.enabled
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, tt.want, resp.ExtraFields.ModelHubURL)
+		})
+	}
+}
+
+// collectChatStream drains a chat stream, returning its chat chunks in order.
+func collectChatStream(t *testing.T, stream chan *schemas.BifrostStreamChunk) []*schemas.BifrostChatResponse {
+	t.Helper()
+	var chunks []*schemas.BifrostChatResponse
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case chunk, ok := <-stream:
+			if !ok {
+				return chunks
+			}
+			require.Nil(t, chunk.BifrostError)
+			if chunk.BifrostChatResponse != nil {
+				chunks = append(chunks, chunk.BifrostChatResponse)
+			}
+		case <-timeout:
+			t.Fatal("stream did not close")
+		}
+	}
+}
+
+func TestChatCompletionStream_EstimatedUsage(t *testing.T) {
+	t.Parallel()
+
+	const model = "groq/meta-llama/Llama-3.3-70B-Instruct"
+
+	tests := []struct {
+		name         string
+		estimate     bool
+		usage        string
+		wantUsage    *schemas.BifrostLLMUsage
+		wantAccuracy schemas.UsageAccuracy
+	}{
+		{
+			name:         "synthetic_when_omitted",
+			estimate:     true,
+			wantUsage:    &schemas.BifrostLLMUsage{PromptTokens: 2, CompletionTokens: 3, TotalTokens: 5}, // "hello\n", "Hello there"
+			wantAccuracy: schemas.UsageAccuracyEstimated,
+		},
+		{
+			name:         "reported_usage_kept",
+			estimate:     true,
+			usage:        `,"usage":{"prompt_tokens":11,"completion_tokens":2,"total_tokens":13}`,
+			wantUsage:    &schemas.BifrostLLMUsage{PromptTokens: 11, CompletionTokens: 2, TotalTokens: 13},
+			wantAccuracy: schemas.UsageAccuracyExact,
+		},
+		{
+			name:         "disabled",
+			estimate:     false,
+			wantUsage:    &schemas.BifrostLLMUsage{},
+			wantAccuracy: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				for _, token := range []string{"Hello", " there"} {
+					fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":%q}}]}\n\n", token)
+				}
+				fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]%s}\n\n", tt.usage)
+				fmt.Fprint(w, "data: [DONE]\n\n")
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.huggingFaceConfig.EstimateStreamUsage = tt.estimate
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+			stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest(model))
+			require.Nil(t, bifrostErr)
+
+			chunks := collectChatStream(t, stream)
+			require.NotEmpty(t, chunks)
+			last := chunks[len(chunks)-1]
+			require.NotNil(t, last.Usage)
+			assert.Equal(t, tt.wantUsage.PromptTokens, last.Usage.PromptTokens)
+			assert.Equal(t, tt.wantUsage.CompletionTokens, last.Usage.CompletionTokens)
+			assert.Equal(t, tt.wantUsage.TotalTokens, last.Usage.TotalTokens)
+			assert.Equal(t, tt.wantAccuracy, last.ExtraFields.UsageAccuracy)
+		})
+	}
+}
+
+func TestChatCompletionStream_JSONContentType(t *testing.T) {
+	t.Parallel()
+
+	var gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.JSONContentType = "application/json; charset=utf-8"
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+	collectChatStream(t, stream)
+
+	assert.Equal(t, "application/json; charset=utf-8", gotContentType)
+}
+
+func TestChatCompletionStream_DecorationLeavesToolCallDeltasUntouched(t *testing.T) {
+	t.Parallel()
+
+	events := []string{
+		`{"index":0,"delta":{"role":"assistant","content":"Checking"}}`,
+		`{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}`,
+		`{"index":0,"delta":{"content":" now"}}`,
+		`{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}`,
+		`{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]}}`,
+		`{"index":0,"delta":{},"finish_reason":"tool_calls"}`,
+	}
+
+	streamChoices := func(t *testing.T, estimate bool) []string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, choice := range events {
+				fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[%s]}\n\n", choice)
+			}
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		}))
+		defer server.Close()
+
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.huggingFaceConfig.EstimateStreamUsage = estimate
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+		require.Nil(t, bifrostErr)
+
+		var choices []string
+		for _, chunk := range collectChatStream(t, stream) {
+			encoded, err := json.Marshal(chunk.Choices)
+			require.NoError(t, err)
+			choices = append(choices, string(encoded))
+		}
+		return choices
+	}
+
+	plain := streamChoices(t, false)
+	decorated := streamChoices(t, true)
+	assert.Equal(t, plain, decorated)
+
+	// Content and tool-call deltas keep their wire order
+	var order []string
+	for _, encoded := range decorated {
+		switch {
+		case strings.Contains(encoded, `"tool_calls":[`):
+			order = append(order, "tool")
+		case strings.Contains(encoded, `"content"`):
+			order = append(order, "content")
+		}
+	}
+	assert.Equal(t, []string{"content", "tool", "content", "tool", "tool"}, order)
+}
+
+func TestChatCompletion_ModelLoading(t *testing.T) {
+	t.Parallel()
+
+	const loadingBody = `{"error":"Model meta-llama/Llama-3.2-1B-Instruct is currently loading","estimated_time":20.1}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, loadingBody)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	request := testHuggingFaceChatRequest("hf-inference/meta-llama/Llama-3.2-1B-Instruct")
+
+	assertModelLoading := func(t *testing.T, bifrostErr *schemas.BifrostError) {
+		t.Helper()
+		require.NotNil(t, bifrostErr)
+		assert.False(t, bifrostErr.IsBifrostError)
+		require.NotNil(t, bifrostErr.StatusCode)
+		assert.Equal(t, http.StatusServiceUnavailable, *bifrostErr.StatusCode, "status must stay retryable")
+		require.NotNil(t, bifrostErr.Type)
+		assert.Equal(t, modelLoadingErrorType, *bifrostErr.Type)
+
+		estimatedTime, loading := ModelLoadingEstimatedTime(bifrostErr)
+		assert.True(t, loading)
+		assert.Equal(t, 20100*time.Millisecond, estimatedTime)
+	}
+
+	t.Run("non_streaming", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, request)
+		assertModelLoading(t, bifrostErr)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, request)
+		assertModelLoading(t, bifrostErr)
+	})
+}
+
+func TestChatCompletionStream_NonLoadingErrorUsesOpenAIFormat(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":{"message":"upstream overloaded","type":"server_error"}}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.NotNil(t, bifrostErr)
+	assert.Equal(t, "upstream overloaded", bifrostErr.Error.Message)
+	_, loading := ModelLoadingEstimatedTime(bifrostErr)
+	assert.False(t, loading)
+}
+
+func TestChatCompletion_TransientErrorRetries(t *testing.T) {
+	t.Parallel()
+
+	const okBody = `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`
+
+	tests := []struct {
+		name       string
+		failures   int
+		status     int
+		retryAfter string
+		timeout    time.Duration
+		wantCalls  int
+		wantStatus int
+	}{
+		{name: "rate_limited_then_ok", failures: 2, status: http.StatusTooManyRequests, wantCalls: 3},
+		{name: "unavailable_then_ok", failures: 1, status: http.StatusServiceUnavailable, wantCalls: 2},
+		{name: "retries_exhausted", failures: 5, status: http.StatusTooManyRequests, wantCalls: 3, wantStatus: http.StatusTooManyRequests},
+		{name: "bad_request_not_retried", failures: 5, status: http.StatusBadRequest, wantCalls: 1, wantStatus: http.StatusBadRequest},
+		{name: "retry_after_past_deadline", failures: 5, status: http.StatusTooManyRequests, retryAfter: "30", timeout: time.Second, wantCalls: 1, wantStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				calls++
+				failing := calls <= tt.failures
+				mu.Unlock()
+				w.Header().Set("Content-Type", "application/json")
+				if failing {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					fmt.Fprint(w, `{"error":{"message":"try again"}}`)
+					return
+				}
+				fmt.Fprint(w, okBody)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.huggingFaceConfig.TransientErrorRetries = 2
+			provider.huggingFaceConfig.TransientRetryBaseDelayInMs = 1
+
+			deadline := schemas.NoDeadline
+			if tt.timeout > 0 {
+				deadline = time.Now().Add(tt.timeout)
+			}
+			ctx := schemas.NewBifrostContext(context.Background(), deadline)
+			start := time.Now()
+			resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+
+			mu.Lock()
+			assert.Equal(t, tt.wantCalls, calls)
+			mu.Unlock()
+			if tt.wantStatus != 0 {
+				require.NotNil(t, bifrostErr)
+				require.NotNil(t, bifrostErr.StatusCode)
+				assert.Equal(t, tt.wantStatus, *bifrostErr.StatusCode)
+				assert.Less(t, time.Since(start), 5*time.Second)
+				return
+			}
+			require.Nil(t, bifrostErr)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestChatCompletion_WhitespacePaddedModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		model   string
+		aliases schemas.KeyAliases
+	}{
+		{model: " groq/meta-llama/Llama-3.3-70B-Instruct "},
+		{model: "groq/meta-llama/Llama-3.3-70B-Instruct\n"},
+		{model: " llama ", aliases: schemas.KeyAliases{"llama": "groq/meta-llama/Llama-3.3-70B-Instruct"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.model), func(t *testing.T) {
+			var sentModel string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				sentModel, _ = body["model"].(string)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{Aliases: tt.aliases}, testHuggingFaceChatRequest(tt.model))
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, "meta-llama/Llama-3.3-70B-Instruct:groq", sentModel)
+		})
+	}
+}
+
+func TestChatCompletion_EffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.IncludeEffectiveConfig = true
+	provider.huggingFaceConfig.ForwardUserIDFromContext = true
+	key := schemas.Key{
+		Aliases:              schemas.KeyAliases{"fast": "meta-llama/Llama-3.1-8B-Instruct"},
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: "groq"},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyUserID, "tenant-user")
+	resp, bifrostErr := provider.ChatCompletion(ctx, key, testHuggingFaceChatRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, &HuggingFaceEffectiveConfig{
+		Model:             "meta-llama/Llama-3.1-8B-Instruct",
+		InferenceProvider: "groq",
+		Task:              "chat-completion",
+		AppliedDefaults:   []string{"inference_provider", "user"},
+	}, resp.ExtraFields.EffectiveConfig)
+}
+
+func TestChatCompletion_RateLimitedErrorCarriesRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "12")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"message":"rate limit reached"}}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	request := testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct")
+
+	assertRateLimit := func(t *testing.T, bifrostErr *schemas.BifrostError) {
+		t.Helper()
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.ExtraFields.RateLimit)
+		require.NotNil(t, bifrostErr.ExtraFields.RateLimit.RetryAfter)
+		assert.Equal(t, int64(12), *bifrostErr.ExtraFields.RateLimit.RetryAfter)
+		require.NotNil(t, bifrostErr.ExtraFields.RateLimit.Remaining)
+		assert.Equal(t, int64(0), *bifrostErr.ExtraFields.RateLimit.Remaining)
+	}
+
+	t.Run("non_streaming", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, request)
+		assertRateLimit(t, bifrostErr)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, request)
+		assertRateLimit(t, bifrostErr)
+	})
+}
+
+func TestChatCompletion_Prediction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		supported []string
+		want      bool
+	}{
+		{name: "supporting_provider", supported: []string{"Groq"}, want: true},
+		{name: "unsupported_provider", supported: []string{"together"}},
+		{name: "not_configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.huggingFaceConfig.PredictionInferenceProviders = tt.supported
+			key := schemas.Key{Aliases: schemas.KeyAliases{"editor": "groq/meta-llama/Llama-3.3-70B-Instruct"}}
+			request := testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct")
+			request.Params = &schemas.ChatParameters{Prediction: &schemas.ChatPrediction{Type: "content", Content: "func main() {}"}}
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			_, bifrostErr := provider.ChatCompletion(ctx, key, request)
+			require.Nil(t, bifrostErr)
+
+			if !tt.want {
+				assert.NotContains(t, body, "prediction")
+				return
+			}
+			assert.Equal(t, map[string]interface{}{"type": "content", "content": "func main() {}"}, body["prediction"])
+		})
+	}
+}
+
+func TestChatCompletion_ToolCallingPayload(t *testing.T) {
+	t.Parallel()
+
+	weatherTool := schemas.ChatTool{
+		Type: schemas.ChatToolTypeFunction,
+		Function: &schemas.ChatToolFunction{
+			Name: "get_weather",
+			Parameters: &schemas.ToolFunctionParameters{
+				Type:       "object",
+				Properties: schemas.NewOrderedMapFromPairs(schemas.KV("location", map[string]interface{}{"type": "string"})),
+				Required:   []string{"location"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		toolChoice *schemas.ChatToolChoice
+		want       interface{}
+	}{
+		{name: "string", toolChoice: &schemas.ChatToolChoice{ChatToolChoiceStr: schemas.Ptr("required")}, want: "required"},
+		{
+			name: "function",
+			toolChoice: &schemas.ChatToolChoice{ChatToolChoiceStruct: &schemas.ChatToolChoiceStruct{
+				Type:     schemas.ChatToolChoiceTypeFunction,
+				Function: &schemas.ChatToolChoiceFunction{Name: "get_weather"},
+			}},
+			want: map[string]interface{}{"type": "function", "function": map[string]interface{}{"name": "get_weather"}},
+		},
+		{name: "struct_any", toolChoice: &schemas.ChatToolChoice{ChatToolChoiceStruct: &schemas.ChatToolChoiceStruct{Type: schemas.ChatToolChoiceTypeAny}}, want: "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Paris\"}"}}]},"finish_reason":"tool_calls"}]}`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			request := testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct")
+			request.Params = &schemas.ChatParameters{
+				Tools:             []schemas.ChatTool{weatherTool},
+				ToolChoice:        tt.toolChoice,
+				ParallelToolCalls: schemas.Ptr(true),
+			}
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, request)
+			require.Nil(t, bifrostErr)
+
+			require.Len(t, body["tools"], 1)
+			assert.Equal(t, "get_weather", body["tools"].([]interface{})[0].(map[string]interface{})["function"].(map[string]interface{})["name"])
+			assert.Equal(t, tt.want, body["tool_choice"])
+			assert.Equal(t, true, body["parallel_tool_calls"])
+
+			require.Len(t, resp.Choices, 1)
+			require.NotNil(t, resp.Choices[0].ChatNonStreamResponseChoice)
+			toolCalls := resp.Choices[0].ChatNonStreamResponseChoice.Message.ChatAssistantMessage.ToolCalls
+			require.Len(t, toolCalls, 1)
+			assert.Equal(t, "get_weather", *toolCalls[0].Function.Name)
+		})
+	}
+}
+
+func TestChatCompletion_ImageContentParts(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"two cats"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	key := schemas.Key{Aliases: schemas.KeyAliases{"vision": "cohere/CohereLabs/aya-vision-32b"}}
+	blocks := []schemas.ChatContentBlock{
+		{Type: schemas.ChatContentBlockTypeText, Text: schemas.Ptr("What is in these images?")},
+		{Type: schemas.ChatContentBlockTypeImage, ImageURLStruct: &schemas.ChatInputImage{URL: "https://example.com/cat.jpg", Detail: schemas.Ptr("high")}},
+		{
+			Type:           schemas.ChatContentBlockTypeImage,
+			ImageURLStruct: &schemas.ChatInputImage{URL: "data:image/png;base64,iVBORw0KGgo="},
+			CacheControl:   &schemas.CacheControl{Type: schemas.CacheControlTypeEphemeral},
+		},
+	}
+	request := &schemas.BifrostChatRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "cohere/CohereLabs/aya-vision-32b",
+		Input: []schemas.ChatMessage{{
+			Role:    schemas.ChatMessageRoleUser,
+			Content: &schemas.ChatMessageContent{ContentBlocks: blocks},
+		}},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.ChatCompletion(ctx, key, request)
+	require.Nil(t, bifrostErr)
+	require.NotNil(t, resp)
+
+	assert.Equal(t, "CohereLabs/aya-vision-32b:cohere", body["model"])
+	messages := body["messages"].([]interface{})
+	require.Len(t, messages, 1)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "text", "text": "What is in these images?"},
+		map[string]interface{}{"type": "image_url", "image_url": map[string]interface{}{"url": "https://example.com/cat.jpg", "detail": "high"}},
+		map[string]interface{}{"type": "image_url", "image_url": map[string]interface{}{"url": "data:image/png;base64,iVBORw0KGgo="}},
+	}, messages[0].(map[string]interface{})["content"])
+	assert.NotNil(t, blocks[2].CacheControl, "caller's content blocks must not be modified")
+}
+
+func TestChatCompletion_DedicatedEndpoint(t *testing.T) {
+	t.Parallel()
+
+	router := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("router should not be called, got %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer router.Close()
+
+	var endpointPath, sentModel string
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpointPath = r.URL.Path
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sentModel, _ = body["model"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"tgi","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer endpoint.Close()
+
+	provider := newTestHuggingFaceProvider(t, router.URL)
+	key := schemas.Key{
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			InferenceProvider: "groq",
+			Endpoints:         map[string]string{"acme/support-llama": endpoint.URL + "/"},
+		},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.ChatCompletion(ctx, key, testHuggingFaceChatRequest("acme/support-llama"))
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, "/v1/chat/completions", endpointPath)
+	assert.Equal(t, "acme/support-llama", sentModel, "no router backend suffix is added")
+	require.Len(t, resp.Choices, 1)
+}
+
+// debugRecordingLogger keeps the Debug messages it receives.
+type debugRecordingLogger struct {
+	noopLogger
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *debugRecordingLogger) Debug(msg string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(msg, args...))
+}
+
+func TestChatCompletion_LogAliasResolution(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	key := schemas.Key{
+		ID: "key-1",
+		Aliases: schemas.KeyAliases{
+			"fast":   "groq/meta-llama/Llama-3.1-8B-Instruct",
+			"quick*": "groq/meta-llama/Llama-3.1-8B-Instruct",
+		},
+	}
+	aliasLogs := func(logger *debugRecordingLogger) []string {
+		var logs []string
+		for _, message := range logger.messages {
+			if strings.Contains(message, "model alias resolved") {
+				logs = append(logs, message)
+			}
+		}
+		return logs
+	}
+	// run calls the provider the way core does: with the requested model in the context and the
+	// key's exact aliases already resolved
+	run := func(t *testing.T, enabled bool, model string) *debugRecordingLogger {
+		t.Helper()
+		logger := &debugRecordingLogger{}
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.logger = logger
+		provider.huggingFaceConfig.LogAliasResolution = enabled
+
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		ctx.SetValue(schemas.BifrostContextKeyRequestID, "req-42")
+		ctx.SetValue(schemas.BifrostContextKeyOriginalModelRequested, model)
+		_, bifrostErr := provider.ChatCompletion(ctx, key, testHuggingFaceChatRequest(key.Aliases.Resolve(model)))
+		require.Nil(t, bifrostErr)
+		return logger
+	}
+
+	t.Run("aliased request is logged", func(t *testing.T) {
+		logs := aliasLogs(run(t, true, "fast"))
+		require.Len(t, logs, 1)
+		assert.Equal(t, `huggingface: model alias resolved requested="fast" resolved="groq/meta-llama/Llama-3.1-8B-Instruct" key_id="key-1" request_id="req-42"`, logs[0])
+	})
+
+	t.Run("pattern alias is logged", func(t *testing.T) {
+		logs := aliasLogs(run(t, true, "quick-8b"))
+		require.Len(t, logs, 1)
+		assert.Equal(t, `huggingface: model alias resolved requested="quick-8b" resolved="groq/meta-llama/Llama-3.1-8B-Instruct" key_id="key-1" request_id="req-42"`, logs[0])
+	})
+
+	t.Run("unaliased request is not logged", func(t *testing.T) {
+		assert.Empty(t, aliasLogs(run(t, true, "groq/meta-llama/Llama-3.1-8B-Instruct")))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.Empty(t, aliasLogs(run(t, false, "fast")))
+	})
+}
+
+func TestChatCompletion_GenerationTiming(t *testing.T) {
+	t.Parallel()
+
+	var withTiming atomic.Bool
+	withTiming.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if withTiming.Load() {
+			w.Header().Set("X-Queue-Time", "12")
+			w.Header().Set("X-Validation-Time", "1")
+			w.Header().Set("X-Inference-Time", "400")
+			w.Header().Set("X-Total-Time", "413")
+			w.Header().Set("X-Time-Per-Token", "20")
+		}
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"tgi","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":20,"total_tokens":25}}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, &schemas.GenerationTiming{
+		QueueTimeMs:      schemas.Ptr(int64(12)),
+		ValidationTimeMs: schemas.Ptr(int64(1)),
+		InferenceTimeMs:  schemas.Ptr(int64(400)),
+		TotalTimeMs:      schemas.Ptr(int64(413)),
+		TimePerTokenMs:   schemas.Ptr(int64(20)),
+		TokensPerSecond:  schemas.Ptr(50.0),
+	}, resp.ExtraFields.GenerationTiming)
+
+	withTiming.Store(false)
+	resp, bifrostErr = provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.Nil(t, bifrostErr)
+	assert.Nil(t, resp.ExtraFields.GenerationTiming, "backends without timing headers report none")
+}
+
+func TestChatCompletion_TextCompletionLogProbs(t *testing.T) {
+	t.Parallel()
+
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"Hi there"},"finish_reason":"stop","logprobs":{"content":[{"token":"Hi","logprob":-0.1,"bytes":[72,105],"top_logprobs":[{"token":"Hi","logprob":-0.1},{"token":"Hello","logprob":-2.3}]},{"token":" there","logprob":-0.5,"bytes":null,"top_logprobs":[{"token":" there","logprob":-0.5}]}]}}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	textReq := &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "meta-llama/Llama-3.1-8B-Instruct",
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hi")},
+		Params:   &schemas.TextCompletionParameters{LogProbs: schemas.Ptr(2)},
+	}
+
+	resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, textReq.ToBifrostChatRequest())
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, true, captured["logprobs"])
+	assert.EqualValues(t, 2, captured["top_logprobs"])
+
+	textResp := resp.ToBifrostTextCompletionResponse()
+	require.Len(t, textResp.Choices, 1)
+	logProbs := textResp.Choices[0].LogProbs
+	require.NotNil(t, logProbs)
+	require.NotNil(t, logProbs.TextCompletionLogProb)
+	assert.Equal(t, []string{"Hi", " there"}, logProbs.Tokens)
+	assert.Equal(t, []float64{-0.1, -0.5}, logProbs.TokenLogProbs)
+	assert.Equal(t, []int{0, 2}, logProbs.TextOffset)
+	assert.Equal(t, []map[string]float64{{"Hi": -0.1, "Hello": -2.3}, {" there": -0.5}}, logProbs.TopLogProbs)
+}
+
+func TestChatCompletion_TextCompletionStopSequences(t *testing.T) {
+	t.Parallel()
+
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		w.Header().Set("Content-Type", "application/json")
+		// Simulates a backend that echoes the matched stop sequence and keeps going
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"1, 2, 3\n\nUser: count again"},"finish_reason":"length"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	textReq := &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "meta-llama/Llama-3.1-8B-Instruct",
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Count to three")},
+		Params: &schemas.TextCompletionParameters{
+			Stop:        []string{"User:", "\n\n"},
+			MaxTokens:   schemas.Ptr(64),
+			Temperature: schemas.Ptr(0.2),
+			TopP:        schemas.Ptr(0.9),
+		},
+	}
+
+	resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, textReq.ToBifrostChatRequest())
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, []interface{}{"User:", "\n\n"}, captured["stop"])
+	assert.EqualValues(t, 64, captured["max_tokens"])
+	assert.EqualValues(t, 0.2, captured["temperature"])
+	assert.EqualValues(t, 0.9, captured["top_p"])
+
+	textResp := resp.ToBifrostTextCompletionResponse()
+	require.Len(t, textResp.Choices, 1)
+	require.NotNil(t, textResp.Choices[0].Text)
+	assert.Equal(t, "1, 2, 3", *textResp.Choices[0].Text)
+	assert.Equal(t, "stop", *textResp.Choices[0].FinishReason)
+}
+
+func TestChatCompletion_KeyProxy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	// A proxy address nothing listens on, so requests routed through it fail to connect
+	deadProxy := httptest.NewServer(http.NotFoundHandler())
+	deadProxyURL := deadProxy.URL
+	deadProxy.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	proxiedKey := func() schemas.Key {
+		return schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			ProxyConfig: &schemas.ProxyConfig{Type: schemas.HTTPProxy, URL: schemas.NewEnvVar(deadProxyURL)},
+		}}
+	}
+
+	_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.Nil(t, bifrostErr, "keys without a proxy use the provider-level client")
+
+	_, bifrostErr = provider.ChatCompletion(ctx, proxiedKey(), testHuggingFaceChatRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.NotNil(t, bifrostErr, "the key's proxy is used for its requests")
+
+	assert.Same(t, provider.clientsForKey(proxiedKey()), provider.clientsForKey(proxiedKey()), "keys with equal proxy settings share clients")
+	assert.Same(t, provider.client, provider.clientsForKey(schemas.Key{}).client)
+	otherProxy := proxiedKey()
+	otherProxy.HuggingFaceKeyConfig.ProxyConfig.URL = schemas.NewEnvVar("http://127.0.0.1:1")
+	assert.NotSame(t, provider.clientsForKey(proxiedKey()), provider.clientsForKey(otherProxy))
+}
+
+// newStreamUnsupportedServer answers chat streams as a model that cannot stream and plain chat
+// requests with a single message.
+func newStreamUnsupportedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if stream, _ := body["stream"].(bool); stream {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"Streaming is not supported for this model"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChatCompletionStream_StreamFallback(t *testing.T) {
+	t.Parallel()
+
+	server := newStreamUnsupportedServer(t)
+	model := "groq/meta-llama/Llama-3.3-70B-Instruct"
+
+	t.Run("disabled", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest(model))
+		require.NotNil(t, bifrostErr)
+		assert.True(t, isStreamingUnsupportedError(bifrostErr))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.huggingFaceConfig.StreamFallback = true
+		provider.huggingFaceConfig.IncludeModelHubURL = true
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		request := testHuggingFaceChatRequest(model)
+		stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, request)
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, model, request.Model, "caller's request must not be rewritten")
+		assert.Nil(t, request.Params)
+
+		chunks := collectChatStream(t, stream)
+		require.Len(t, chunks, 1)
+		chunk := chunks[0]
+		assert.Equal(t, "chat.completion.chunk", chunk.Object)
+		require.Len(t, chunk.Choices, 1)
+		require.NotNil(t, chunk.Choices[0].ChatStreamResponseChoice)
+		delta := chunk.Choices[0].Delta
+		require.NotNil(t, delta.Content)
+		assert.Equal(t, "hello there", *delta.Content)
+		require.NotNil(t, delta.Role)
+		assert.Equal(t, "assistant", *delta.Role)
+		require.NotNil(t, chunk.Choices[0].FinishReason)
+		assert.Equal(t, "stop", *chunk.Choices[0].FinishReason)
+		require.NotNil(t, chunk.Usage)
+		assert.Equal(t, 5, chunk.Usage.TotalTokens)
+		assert.Equal(t, "https://huggingface.co/meta-llama/Llama-3.3-70B-Instruct", chunk.ExtraFields.ModelHubURL)
+	})
+}
+
+func TestIsStreamingUnsupportedError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		message string
+		want    bool
+	}{
+		{"not_supported", http.StatusBadRequest, "Streaming is not supported for this model", true},
+		{"unsupported_501", http.StatusNotImplemented, "stream unsupported", true},
+		{"unrelated_400", http.StatusBadRequest, "max_tokens must be positive", false},
+		{"server_error", http.StatusInternalServerError, "streaming is not supported", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bifrostErr := &schemas.BifrostError{StatusCode: schemas.Ptr(tt.status), Error: &schemas.ErrorField{Message: tt.message}}
+			assert.Equal(t, tt.want, isStreamingUnsupportedError(bifrostErr))
+		})
+	}
+}
+
+func TestChatCompletion_GatedModelError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"Access to model meta-llama/Llama-3.1-8B-Instruct is restricted and you are not in the authorized list."}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	model := "groq/meta-llama/Llama-3.1-8B-Instruct"
+
+	assertGated := func(t *testing.T, bifrostErr *schemas.BifrostError) {
+		t.Helper()
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.StatusCode)
+		assert.Equal(t, http.StatusForbidden, *bifrostErr.StatusCode)
+		require.NotNil(t, bifrostErr.Type)
+		assert.Equal(t, gatedModelErrorType, *bifrostErr.Type)
+		assert.Contains(t, bifrostErr.Error.Message, "accept its terms at https://huggingface.co/meta-llama/Llama-3.1-8B-Instruct")
+		assert.Contains(t, bifrostErr.Error.Message, "not in the authorized list")
+	}
+
+	t.Run("chat", func(t *testing.T) {
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest(model))
+		assertGated(t, bifrostErr)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		_, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, testHuggingFaceChatRequest(model))
+		assertGated(t, bifrostErr)
+	})
+}
+
+func TestExplainGatedModelError_LeavesOtherErrors(t *testing.T) {
+	t.Parallel()
+
+	forbidden := &schemas.BifrostError{StatusCode: schemas.Ptr(http.StatusForbidden), Error: &schemas.ErrorField{Message: "This key lacks the inference permission"}}
+	assert.Equal(t, "This key lacks the inference permission", explainGatedModelError(forbidden, "org/model").Error.Message)
+	assert.Nil(t, forbidden.Type)
+
+	unauthorized := &schemas.BifrostError{StatusCode: schemas.Ptr(http.StatusUnauthorized), Error: &schemas.ErrorField{Message: "Access to model org/model is restricted"}}
+	assert.Equal(t, "Access to model org/model is restricted", explainGatedModelError(unauthorized, "org/model").Error.Message)
+
+	assert.Nil(t, explainGatedModelError(nil, "org/model"))
+}
+
+func TestSearchSimilarModels(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Query().Get("author") == "meta-llama":
+			fmt.Fprint(w, `[{"modelId":"meta-llama/Llama-3.1-8B"},{"modelId":"meta-llama/Llama-3.3-70B-Instruct"},{"modelId":"meta-llama/Llama-Guard-3-8B"},{"modelId":"meta-llama/Llama-3.1-8B-Instruct"}]`)
+		case r.URL.Query().Get("search") == "bge-m3":
+			fmt.Fprint(w, `[{"modelId":"BAAI/bge-m3"},{"modelId":"BAAI/bge-m3-unsupervised"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	t.Run("same author", func(t *testing.T) {
+		suggestions := provider.searchSimilarModels(ctx, schemas.Key{}, "meta-llama/Llama-3.1-8B-Instruc", server.URL+"/api/models")
+		assert.Equal(t, []string{"meta-llama/Llama-3.1-8B-Instruct", "meta-llama/Llama-3.3-70B-Instruct", "meta-llama/Llama-3.1-8B"}, suggestions)
+	})
+
+	t.Run("unknown author falls back to name search", func(t *testing.T) {
+		suggestions := provider.searchSimilarModels(ctx, schemas.Key{}, "BAAl/bge-m3", server.URL+"/api/models")
+		assert.Equal(t, []string{"BAAI/bge-m3", "BAAI/bge-m3-unsupervised"}, suggestions)
+	})
+
+	t.Run("hub failure yields no suggestions", func(t *testing.T) {
+		assert.Empty(t, provider.searchSimilarModels(ctx, schemas.Key{}, "org/model", "http://127.0.0.1:0/api/models"))
+	})
+}
+
+func TestExplainModelNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	notFound := &schemas.BifrostError{StatusCode: schemas.Ptr(http.StatusNotFound), Error: &schemas.ErrorField{Message: "Model not found"}}
+	explained := explainModelNotFoundError(notFound, "org/modl", []string{"org/model", "org/model-large"})
+	require.NotNil(t, explained.Type)
+	assert.Equal(t, modelNotFoundErrorType, *explained.Type)
+	assert.Equal(t, "model org/modl was not found; did you mean org/model, org/model-large? (Model not found)", explained.Error.Message)
+
+	// Without suggestions, or for other statuses, the error is left alone
+	bare := &schemas.BifrostError{StatusCode: schemas.Ptr(http.StatusNotFound), Error: &schemas.ErrorField{Message: "Model not found"}}
+	assert.Equal(t, "Model not found", explainModelNotFoundError(bare, "org/modl", nil).Error.Message)
+	badRequest := &schemas.BifrostError{StatusCode: schemas.Ptr(http.StatusBadRequest), Error: &schemas.ErrorField{Message: "bad input"}}
+	assert.Equal(t, "bad input", explainModelNotFoundError(badRequest, "org/modl", []string{"org/model"}).Error.Message)
+	assert.Nil(t, explainModelNotFoundError(nil, "org/modl", []string{"org/model"}))
+
+	// The Hub is only searched when SuggestModelsOnNotFound is set
+	provider := newTestHuggingFaceProvider(t, "http://127.0.0.1:0")
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	unchanged := provider.suggestModelsOnNotFound(ctx, schemas.Key{}, bare, "org/modl")
+	assert.Nil(t, unchanged.Type)
+	assert.Equal(t, "Model not found", unchanged.Error.Message)
+}
+
+func TestRankModelSuggestions(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"org/alpha", "org/model-v2", "org/Model", "org/model-v2", "org/modl"}
+	assert.Equal(t, []string{"org/modl", "org/model-v2"}, rankModelSuggestions("org/model", candidates, 2))
+	assert.Empty(t, rankModelSuggestions("org/model", []string{"org/model"}, 3))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+}
+
+func TestChatCompletionStream_EveryChunkNamesModel(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		// Only the first delta names the model
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"meta-llama/Llama-3.3-70B-Instruct\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	textReq := &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "groq/meta-llama/Llama-3.3-70B-Instruct",
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hello")},
+	}
+
+	stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, textReq.ToBifrostChatRequest())
+	require.Nil(t, bifrostErr)
+
+	var chunks int
+	for chunk := range stream {
+		require.Nil(t, chunk.BifrostError)
+		require.NotNil(t, chunk.BifrostChatResponse)
+		chunks++
+		assert.NotEmpty(t, chunk.BifrostChatResponse.Model, "chunk %d", chunks)
+		assert.NotEmpty(t, chunk.BifrostChatResponse.ToBifrostTextCompletionResponse().Model, "text chunk %d", chunks)
+	}
+	assert.Greater(t, chunks, 1)
+}
+
+func TestChatCompletion_Seed(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		// Simulates a backend whose sampling is fully determined by the seed
+		content := fmt.Sprintf("sample-%v", payload["seed"])
+		if stream, _ := payload["stream"].(bool); stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":%q},\"finish_reason\":\"stop\"}]}\n\n", content)
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":%q},"finish_reason":"stop"}]}`, content)
+	}))
+	defer server.Close()
+
+	lastSeed := func() interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(bodies[len(bodies)-1], &payload))
+		return payload["seed"]
+	}
+
+	const model = "groq/meta-llama/Llama-3.3-70B-Instruct"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+
+	t.Run("chat_forwards_seed", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		req := testHuggingFaceChatRequest(model)
+		req.Params = &schemas.ChatParameters{Seed: schemas.Ptr(42)}
+
+		resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, req)
+		require.Nil(t, bifrostErr)
+		assert.EqualValues(t, 42, lastSeed())
+		assert.Equal(t, "sample-42", *resp.Choices[0].Message.Content.ContentStr)
+	})
+
+	t.Run("text_completion_forwards_seed", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		textReq := &schemas.BifrostTextCompletionRequest{
+			Provider: schemas.HuggingFace,
+			Model:    model,
+			Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hi")},
+			Params:   &schemas.TextCompletionParameters{Seed: schemas.Ptr(7)},
+		}
+
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, textReq.ToBifrostChatRequest())
+		require.Nil(t, bifrostErr)
+		assert.EqualValues(t, 7, lastSeed())
+	})
+
+	t.Run("stream_forwards_seed", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		req := testHuggingFaceChatRequest(model)
+		req.Params = &schemas.ChatParameters{Seed: schemas.Ptr(42)}
+
+		stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, req)
+		require.Nil(t, bifrostErr)
+		var content strings.Builder
+		for chunk := range stream {
+			require.Nil(t, chunk.BifrostError)
+			for _, choice := range chunk.BifrostChatResponse.Choices {
+				if choice.ChatStreamResponseChoice != nil && choice.Delta != nil && choice.Delta.Content != nil {
+					content.WriteString(*choice.Delta.Content)
+				}
+			}
+		}
+		assert.EqualValues(t, 42, lastSeed())
+		assert.Equal(t, "sample-42", content.String())
+	})
+
+	t.Run("same_seed_sends_identical_payloads", func(t *testing.T) {
+		var outputs []string
+		for range 2 {
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			req := testHuggingFaceChatRequest(model)
+			req.Params = &schemas.ChatParameters{Seed: schemas.Ptr(1234), Temperature: schemas.Ptr(0.7)}
+
+			resp, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, req)
+			require.Nil(t, bifrostErr)
+			outputs = append(outputs, *resp.Choices[0].Message.Content.ContentStr)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, string(bodies[len(bodies)-2]), string(bodies[len(bodies)-1]))
+		assert.Equal(t, outputs[0], outputs[1])
+	})
+
+	t.Run("no_seed_omitted", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest(model))
+		require.Nil(t, bifrostErr)
+		assert.Nil(t, lastSeed())
+	})
+}
+
+func TestChatCompletion_EstimatedCost(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1000,"completion_tokens":500,"total_tokens":1500}}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	key := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+		Pricing: map[string]schemas.HuggingFaceModelPrice{
+			"groq/meta-llama/Llama-3.3-70B-Instruct": {InputPerMillionTokens: 0.6, OutputPerMillionTokens: 0.8},
+		},
+	}}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.ChatCompletion(ctx, key, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+	require.NotNil(t, resp.ExtraFields.EstimatedCost)
+	assert.InDelta(t, 0.0006, resp.ExtraFields.EstimatedCost.InputTokensCost, 1e-12)
+	assert.InDelta(t, 0.0004, resp.ExtraFields.EstimatedCost.OutputTokensCost, 1e-12)
+	assert.InDelta(t, 0.001, resp.ExtraFields.EstimatedCost.TotalCost, 1e-12)
+
+	ctx = schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr = provider.ChatCompletion(ctx, key, testHuggingFaceChatRequest("novita/meta-llama/Llama-3.3-70B-Instruct"))
+	require.Nil(t, bifrostErr)
+	assert.Nil(t, resp.ExtraFields.EstimatedCost, "models without a price get no estimate")
+}
+
+func TestTextCompletionStream_UsageOnFinalChunk(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[],\"usage\":{\"prompt_tokens\":4,\"completion_tokens\":2,\"total_tokens\":6}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	newTextRequest := func(params *schemas.TextCompletionParameters) *schemas.BifrostTextCompletionRequest {
+		return &schemas.BifrostTextCompletionRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "groq/meta-llama/Llama-3.3-70B-Instruct",
+			Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hello")},
+			Params:   params,
+		}
+	}
+
+	t.Run("usage_requested_and_carried_on_final_chunk", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		stream, bifrostErr := provider.TextCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, newTextRequest(nil))
+		require.Nil(t, bifrostErr)
+
+		var textChunks []*schemas.BifrostTextCompletionResponse
+		for chunk := range stream {
+			require.Nil(t, chunk.BifrostError)
+			require.NotNil(t, chunk.BifrostTextCompletionResponse)
+			textChunks = append(textChunks, chunk.BifrostTextCompletionResponse)
+		}
+		mu.Lock()
+		assert.Equal(t, map[string]interface{}{"include_usage": true}, captured["stream_options"])
+		mu.Unlock()
+
+		require.NotEmpty(t, textChunks)
+		last := textChunks[len(textChunks)-1]
+		require.NotNil(t, last.Usage, "the final chunk carries the stream's usage")
+		assert.Equal(t, 4, last.Usage.PromptTokens)
+		assert.Equal(t, 2, last.Usage.CompletionTokens)
+		assert.Equal(t, 6, last.Usage.TotalTokens)
+		for i, chunk := range textChunks[:len(textChunks)-1] {
+			assert.Nil(t, chunk.Usage, "chunk %d", i)
+		}
+	})
+
+	t.Run("caller_opt_out_kept", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		req := newTextRequest(&schemas.TextCompletionParameters{StreamOptions: &schemas.ChatStreamOptions{IncludeUsage: schemas.Ptr(false)}})
+		stream, bifrostErr := provider.TextCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, req)
+		require.Nil(t, bifrostErr)
+		for range stream {
+		}
+		mu.Lock()
+		assert.Equal(t, map[string]interface{}{"include_usage": false}, captured["stream_options"])
+		mu.Unlock()
+	})
+}
+
+func TestChatCompletionStream_IncludeUsagePassthrough(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		streamOptions *schemas.ChatStreamOptions
+	}{
+		{name: "caller_requested", streamOptions: &schemas.ChatStreamOptions{IncludeUsage: schemas.Ptr(true)}},
+		{name: "bifrost_default", streamOptions: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"},\"finish_reason\":\"stop\"}]}\n\n")
+				fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":1,\"total_tokens\":6}}\n\n")
+				fmt.Fprint(w, "data: [DONE]\n\n")
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			req := testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct")
+			req.Params = &schemas.ChatParameters{StreamOptions: tt.streamOptions}
+
+			stream, bifrostErr := provider.ChatCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, req)
+			require.Nil(t, bifrostErr)
+			var last *schemas.BifrostChatResponse
+			for chunk := range stream {
+				require.Nil(t, chunk.BifrostError)
+				last = chunk.BifrostChatResponse
+			}
+
+			assert.Equal(t, map[string]interface{}{"include_usage": true}, captured["stream_options"])
+			require.NotNil(t, last)
+			require.NotNil(t, last.Usage, "the terminal chunk carries usage")
+			assert.Equal(t, 5, last.Usage.PromptTokens)
+			assert.Equal(t, 1, last.Usage.CompletionTokens)
+			assert.Equal(t, 6, last.Usage.TotalTokens)
+			assert.Equal(t, schemas.UsageAccuracyExact, last.ExtraFields.UsageAccuracy)
+		})
+	}
+}
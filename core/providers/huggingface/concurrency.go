@@ -0,0 +1,76 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// maxConcurrency returns the key's in-flight request limit for model and the ID it was configured
+// under, matching "{org}/{model}" the same way dedicated endpoints are matched. A limit of 0 means
+// the model is not capped.
+func maxConcurrency(key schemas.Key, model string) (int, string) {
+	if key.HuggingFaceKeyConfig == nil || len(key.HuggingFaceKeyConfig.MaxConcurrency) == 0 {
+		return 0, ""
+	}
+	model = strings.TrimSpace(model)
+	limit, ok := key.HuggingFaceKeyConfig.MaxConcurrency[model]
+	if !ok && strings.Count(model, "/") > 1 {
+		_, model, _ = strings.Cut(model, "/")
+		limit, ok = key.HuggingFaceKeyConfig.MaxConcurrency[model]
+	}
+	if !ok || limit <= 0 {
+		return 0, ""
+	}
+	return limit, model
+}
+
+// acquireModelSlot waits for one of the key's concurrency slots for model, queuing until a slot
+// frees or ctx ends. Requests to the same dedicated endpoint (or, without one, the same model)
+// under the same limit share slots across keys. The returned release must be called once the
+// request is done; it is a no-op when the model is not capped.
+func (provider *HuggingFaceProvider) acquireModelSlot(ctx context.Context, key schemas.Key, model string, endpointURL string) (func(), *schemas.BifrostError) {
+	limit, configuredModel := maxConcurrency(key, model)
+	if limit == 0 {
+		return func() {}, nil
+	}
+
+	deployment := configuredModel
+	if endpointURL != "" {
+		deployment = endpointURL
+	}
+	value, _ := provider.concurrencySlots.LoadOrStore(fmt.Sprintf("%s#%d", deployment, limit), make(chan struct{}, limit))
+	slots := value.(chan struct{})
+	release := func() { <-slots }
+
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: all %d concurrency slots for %s are busy, queuing", limit, deployment), nil))
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, providerUtils.NewBifrostTimeoutError(fmt.Sprintf("timed out waiting for one of %d concurrency slots for %s", limit, deployment), ctx.Err())
+	}
+	statusCode := 499
+	return nil, &schemas.BifrostError{
+		IsBifrostError: true,
+		StatusCode:     &statusCode,
+		Error: &schemas.ErrorField{
+			Type:    schemas.Ptr(schemas.RequestCancelled),
+			Message: schemas.ErrRequestCancelled,
+			Error:   ctx.Err(),
+		},
+	}
+}
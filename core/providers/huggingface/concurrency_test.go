@@ -0,0 +1,124 @@
+package huggingface
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	key := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{MaxConcurrency: map[string]int{
+		"meta-llama/Llama-3.1-8B-Instruct": 2,
+		"BAAI/bge-m3":                      0,
+	}}}
+
+	tests := []struct {
+		name      string
+		model     string
+		wantLimit int
+		wantModel string
+	}{
+		{"bare model ID", "meta-llama/Llama-3.1-8B-Instruct", 2, "meta-llama/Llama-3.1-8B-Instruct"},
+		{"provider-prefixed model ID", "together/meta-llama/Llama-3.1-8B-Instruct", 2, "meta-llama/Llama-3.1-8B-Instruct"},
+		{"zero limit is uncapped", "BAAI/bge-m3", 0, ""},
+		{"unconfigured model", "Qwen/Qwen3-8B", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, model := maxConcurrency(key, tt.model)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantModel, model)
+		})
+	}
+
+	limit, _ := maxConcurrency(schemas.Key{}, "meta-llama/Llama-3.1-8B-Instruct")
+	assert.Zero(t, limit)
+}
+
+func TestChatCompletion_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	model := "meta-llama/Llama-3.1-8B-Instruct"
+	key := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+		Endpoints:      map[string]string{model: server.URL},
+		MaxConcurrency: map[string]int{model: 2},
+	}}
+
+	var wg sync.WaitGroup
+	errs := make(chan *schemas.BifrostError, 6)
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			_, bifrostErr := provider.ChatCompletion(ctx, key, testHuggingFaceChatRequest(model))
+			errs <- bifrostErr
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for bifrostErr := range errs {
+		assert.Nil(t, bifrostErr)
+	}
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestAcquireModelSlot_QueuesUntilDeadline(t *testing.T) {
+	t.Parallel()
+
+	provider := newTestHuggingFaceProvider(t, "http://localhost")
+	model := "meta-llama/Llama-3.1-8B-Instruct"
+	key := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{MaxConcurrency: map[string]int{model: 1}}}
+
+	release, bifrostErr := provider.acquireModelSlot(context.Background(), key, model, "")
+	require.Nil(t, bifrostErr)
+
+	// The only slot is taken, so the second request waits out its deadline
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, bifrostErr = provider.acquireModelSlot(timeoutCtx, key, model, "")
+	require.NotNil(t, bifrostErr)
+	require.NotNil(t, bifrostErr.Error.Type)
+	assert.Equal(t, schemas.RequestTimedOut, *bifrostErr.Error.Type)
+
+	cancelledCtx, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	_, bifrostErr = provider.acquireModelSlot(cancelledCtx, key, model, "")
+	require.NotNil(t, bifrostErr)
+	require.NotNil(t, bifrostErr.Error.Type)
+	assert.Equal(t, schemas.RequestCancelled, *bifrostErr.Error.Type)
+
+	// A released slot is handed to the next request
+	release()
+	release, bifrostErr = provider.acquireModelSlot(context.Background(), key, model, "")
+	require.Nil(t, bifrostErr)
+	release()
+}
@@ -1,12 +1,36 @@
 package huggingface
 
 import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/bytedance/sonic"
 	"github.com/maximhq/bifrost/core/schemas"
 )
 
+var errAmbiguousEmbeddingInput = errors.New("embedding input sets both text and texts; send one, or enable merge_embedding_text_inputs to embed both")
+
+// resolveAmbiguousEmbeddingInput rejects an input that sets both Text and Texts, or, when merge is
+// enabled, folds Text into the front of Texts so it gets the first embedding index.
+func resolveAmbiguousEmbeddingInput(input *schemas.EmbeddingInput, merge bool) error {
+	if input == nil || input.Text == nil || len(input.Texts) == 0 {
+		return nil
+	}
+	if !merge {
+		return errAmbiguousEmbeddingInput
+	}
+	input.Texts = append([]string{*input.Text}, input.Texts...)
+	input.Text = nil
+	return nil
+}
+
 // ToHuggingFaceEmbeddingRequest converts a Bifrost embedding request to HuggingFace format
 func ToHuggingFaceEmbeddingRequest(bifrostReq *schemas.BifrostEmbeddingRequest) (*HuggingFaceEmbeddingRequest, error) {
 	if bifrostReq == nil {
@@ -28,14 +52,37 @@ func ToHuggingFaceEmbeddingRequest(bifrostReq *schemas.BifrostEmbeddingRequest)
 		hfReq = &HuggingFaceEmbeddingRequest{}
 	}
 
+	// Newline handling is opt-in and only affects the outbound payload
+	newlinePolicy := NewlinePolicyNone
+	if bifrostReq.Params != nil && bifrostReq.Params.ExtraParams != nil {
+		if rawPolicy, ok := bifrostReq.Params.ExtraParams["newline_policy"]; ok {
+			delete(bifrostReq.Params.ExtraParams, "newline_policy")
+			policy, err := parseNewlinePolicy(rawPolicy)
+			if err != nil {
+				return nil, err
+			}
+			newlinePolicy = policy
+		}
+	}
+
 	// Convert input
+	if bifrostReq.Input != nil && bifrostReq.Input.Text != nil && len(bifrostReq.Input.Texts) > 0 {
+		return nil, errAmbiguousEmbeddingInput
+	}
 	if bifrostReq.Input != nil {
 		var input InputsCustomType
 		if bifrostReq.Input.Text != nil {
-			input = InputsCustomType{Text: bifrostReq.Input.Text}
+			input = InputsCustomType{Text: schemas.Ptr(applyNewlinePolicy(*bifrostReq.Input.Text, newlinePolicy))}
 
 		} else if bifrostReq.Input.Texts != nil {
-			input = InputsCustomType{Texts: bifrostReq.Input.Texts}
+			texts := bifrostReq.Input.Texts
+			if newlinePolicy != NewlinePolicyNone {
+				texts = make([]string, len(bifrostReq.Input.Texts))
+				for i, text := range bifrostReq.Input.Texts {
+					texts[i] = applyNewlinePolicy(text, newlinePolicy)
+				}
+			}
+			input = InputsCustomType{Texts: texts}
 		}
 		if inferenceProvider == hfInference {
 			hfReq.Inputs = &input
@@ -49,32 +96,63 @@ func ToHuggingFaceEmbeddingRequest(bifrostReq *schemas.BifrostEmbeddingRequest)
 		params := bifrostReq.Params
 
 		// Map standard parameters
-		if params.EncodingFormat != nil {
+		// base64 is produced by Bifrost from the decoded floats, so upstream always sends floats
+		if params.EncodingFormat != nil && EncodingType(*params.EncodingFormat) != EncodingTypeBase64 {
 			encodingType := EncodingType(*params.EncodingFormat)
 			hfReq.EncodingFormat = &encodingType
 		}
 		if params.Dimensions != nil {
+			if *params.Dimensions <= 0 {
+				return nil, fmt.Errorf("dimensions must be a positive integer, got %d", *params.Dimensions)
+			}
 			hfReq.Dimensions = params.Dimensions
 		}
 
 		// Check for HuggingFace-specific parameters in ExtraParams
 		if params.ExtraParams != nil {
-			if normalize, ok := params.ExtraParams["normalize"].(bool); ok {
+			// normalize may arrive as a string (e.g. from query params or form-encoded clients)
+			if normalize, ok := schemas.SafeExtractBool(params.ExtraParams["normalize"]); ok {
 				delete(params.ExtraParams, "normalize")
 				hfReq.Normalize = &normalize
 			}
 			if promptName, ok := params.ExtraParams["prompt_name"].(string); ok {
 				delete(params.ExtraParams, "prompt_name")
-				hfReq.PromptName = &promptName
+				if promptName != "" {
+					hfReq.PromptName = &promptName
+				}
 			}
-			if truncate, ok := params.ExtraParams["truncate"].(bool); ok {
+			if truncate, ok := schemas.SafeExtractBool(params.ExtraParams["truncate"]); ok {
 				delete(params.ExtraParams, "truncate")
 				hfReq.Truncate = &truncate
 			}
-			if truncationDirection, ok := params.ExtraParams["truncation_direction"].(string); ok {
+			if rawDirection, ok := params.ExtraParams["truncation_direction"]; ok {
 				delete(params.ExtraParams, "truncation_direction")
+				truncationDirection, err := parseTruncationDirection(rawDirection)
+				if err != nil {
+					return nil, err
+				}
 				hfReq.TruncationDirection = &truncationDirection
 			}
+			if rawOverrides, ok := params.ExtraParams["truncation_by_prompt"]; ok {
+				delete(params.ExtraParams, "truncation_by_prompt")
+				overrides, err := parsePromptTruncation(rawOverrides)
+				if err != nil {
+					return nil, err
+				}
+				if hfReq.PromptName != nil {
+					if override, ok := overrides[*hfReq.PromptName]; ok {
+						if override.truncate != nil {
+							hfReq.Truncate = override.truncate
+						}
+						if override.direction != nil {
+							hfReq.TruncationDirection = override.direction
+						}
+					}
+				}
+			}
+			if inferenceProvider == hfInference {
+				hfReq.Options = extractInferenceOptions(params.ExtraParams)
+			}
 		}
 		hfReq.ExtraParams = params.ExtraParams
 	}
@@ -82,27 +160,338 @@ func ToHuggingFaceEmbeddingRequest(bifrostReq *schemas.BifrostEmbeddingRequest)
 	return hfReq, nil
 }
 
+// parseTruncationDirection validates a truncation_direction value, accepting either casing
+// of the two directions TEI understands and normalizing it to the form TEI expects.
+func parseTruncationDirection(value interface{}) (TruncationDirection, error) {
+	direction, ok := value.(string)
+	if ok {
+		switch {
+		case strings.EqualFold(direction, string(TruncationDirectionLeft)):
+			return TruncationDirectionLeft, nil
+		case strings.EqualFold(direction, string(TruncationDirectionRight)):
+			return TruncationDirectionRight, nil
+		}
+	}
+	return "", fmt.Errorf("invalid truncation_direction %v: must be %q or %q", value, TruncationDirectionLeft, TruncationDirectionRight)
+}
+
+// parseNewlinePolicy validates a newline_policy value, accepting any casing of the known policies.
+func parseNewlinePolicy(value interface{}) (NewlinePolicy, error) {
+	policy, ok := value.(string)
+	if ok {
+		for _, known := range []NewlinePolicy{NewlinePolicyNone, NewlinePolicyReplace, NewlinePolicyCollapse} {
+			if strings.EqualFold(policy, string(known)) {
+				return known, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("invalid newline_policy %v: must be %q, %q or %q", value, NewlinePolicyNone, NewlinePolicyReplace, NewlinePolicyCollapse)
+}
+
+// promptTruncation overrides truncate and truncation_direction for inputs sent with one prompt
+// name. A nil field leaves the request's own setting in place.
+type promptTruncation struct {
+	truncate  *bool
+	direction *TruncationDirection
+}
+
+// parsePromptTruncation reads ExtraParams["truncation_by_prompt"], which maps a prompt name to an
+// object with optional "truncate" and "truncation_direction" fields, e.g.
+// {"query": {"truncate": false}, "passage": {"truncate": true, "truncation_direction": "right"}}.
+func parsePromptTruncation(value interface{}) (map[string]promptTruncation, error) {
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("truncation_by_prompt must be an object keyed by prompt name")
+	}
+	overrides := make(map[string]promptTruncation, len(entries))
+	for promptName, rawEntry := range entries {
+		entry, ok := rawEntry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("truncation_by_prompt[%q] must be an object", promptName)
+		}
+		var override promptTruncation
+		for field, fieldValue := range entry {
+			switch field {
+			case "truncate":
+				truncate, ok := schemas.SafeExtractBool(fieldValue)
+				if !ok {
+					return nil, fmt.Errorf("truncation_by_prompt[%q].truncate must be a boolean", promptName)
+				}
+				override.truncate = &truncate
+			case "truncation_direction":
+				direction, err := parseTruncationDirection(fieldValue)
+				if err != nil {
+					return nil, fmt.Errorf("truncation_by_prompt[%q]: %w", promptName, err)
+				}
+				override.direction = &direction
+			default:
+				return nil, fmt.Errorf("truncation_by_prompt[%q] has unknown field %q: must be truncate or truncation_direction", promptName, field)
+			}
+		}
+		overrides[promptName] = override
+	}
+	return overrides, nil
+}
+
+// EmbeddingBatchErrorPolicy decides what happens to the other calls of a split embedding batch
+// (one call per prompt name) when one of them fails. Set via HuggingFaceConfig.EmbeddingBatchErrorPolicy.
+type EmbeddingBatchErrorPolicy string
+
+const (
+	// EmbeddingBatchErrorPolicyFailFast cancels the calls still in flight on the first error (default).
+	EmbeddingBatchErrorPolicyFailFast EmbeddingBatchErrorPolicy = "fail_fast"
+	// EmbeddingBatchErrorPolicyBestEffort lets every call finish before reporting the first error.
+	EmbeddingBatchErrorPolicyBestEffort EmbeddingBatchErrorPolicy = "best_effort"
+)
+
+// embeddingPromptGroup is the slice of a batched embedding request whose inputs share a prompt name.
+type embeddingPromptGroup struct {
+	indices []int // positions of this group's inputs in the original batch
+	request *schemas.BifrostEmbeddingRequest
+}
+
+// splitEmbeddingRequestByPromptName splits a batch carrying per-input prompt names
+// (ExtraParams["prompt_names"], parallel to Input.Texts) into one request per distinct
+// prompt name, since feature-extraction accepts a single prompt_name per call.
+// An empty name sends its inputs without a prompt_name. Returns nil groups when the
+// request has no per-input prompt names.
+func splitEmbeddingRequestByPromptName(request *schemas.BifrostEmbeddingRequest) ([]embeddingPromptGroup, error) {
+	if request == nil || request.Params == nil || request.Params.ExtraParams == nil {
+		return nil, nil
+	}
+	rawPromptNames, ok := request.Params.ExtraParams["prompt_names"]
+	if !ok {
+		return nil, nil
+	}
+	promptNames, ok := schemas.SafeExtractStringSlice(rawPromptNames)
+	if !ok {
+		return nil, fmt.Errorf("prompt_names must be an array of strings")
+	}
+	if request.Input == nil || len(request.Input.Texts) != len(promptNames) {
+		return nil, fmt.Errorf("prompt_names must have exactly one entry per input text")
+	}
+
+	groups := make([]embeddingPromptGroup, 0)
+	groupByName := make(map[string]int)
+	for i, promptName := range promptNames {
+		groupIdx, exists := groupByName[promptName]
+		if !exists {
+			extraParams := maps.Clone(request.Params.ExtraParams)
+			delete(extraParams, "prompt_names")
+			delete(extraParams, "prompt_name")
+			if promptName != "" {
+				extraParams["prompt_name"] = promptName
+			}
+			params := *request.Params
+			params.ExtraParams = extraParams
+			groupReq := *request
+			groupReq.Input = &schemas.EmbeddingInput{}
+			groupReq.Params = &params
+
+			groups = append(groups, embeddingPromptGroup{request: &groupReq})
+			groupIdx = len(groups) - 1
+			groupByName[promptName] = groupIdx
+		}
+		groups[groupIdx].indices = append(groups[groupIdx].indices, i)
+		groups[groupIdx].request.Input.Texts = append(groups[groupIdx].request.Input.Texts, request.Input.Texts[i])
+	}
+
+	return groups, nil
+}
+
+// defaultEmbeddingStreamChunkSize is how many inputs each EmbeddingStream sub-request carries
+// unless HuggingFaceConfig.EmbeddingStreamChunkSize says otherwise.
+const defaultEmbeddingStreamChunkSize = 32
+
+// splitEmbeddingRequestIntoChunks splits a batch into sub-requests of at most chunkSize inputs,
+// first by prompt name (see splitEmbeddingRequestByPromptName) so each chunk sends one prompt.
+// A request that is not a text batch comes back as a single chunk.
+func splitEmbeddingRequestIntoChunks(request *schemas.BifrostEmbeddingRequest, chunkSize int) ([]embeddingPromptGroup, error) {
+	groups, err := embeddingBatchGroups(request)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]embeddingPromptGroup, 0, len(groups))
+	for _, group := range groups {
+		for start := 0; start < len(group.indices); start += chunkSize {
+			chunks = append(chunks, group.slice(start, min(start+chunkSize, len(group.indices))))
+		}
+	}
+	return chunks, nil
+}
+
+// defaultMaxEmbeddingRequestBytes is HF's request body limit, used unless
+// HuggingFaceConfig.MaxEmbeddingRequestBytes says otherwise.
+const defaultMaxEmbeddingRequestBytes = 2 * 1024 * 1024
+
+// estimateEmbeddingRequestBytes estimates the size of the JSON body an embedding request is sent
+// as, from its serialized input and parameters plus the model name the body may carry. Returns 0
+// when they cannot be serialized.
+func estimateEmbeddingRequestBytes(request *schemas.BifrostEmbeddingRequest) int {
+	input, err := sonic.Marshal(request.Input)
+	if err != nil {
+		return 0
+	}
+	size := len(input) + len(`,"model":""`) + len(request.Model)
+	if request.Params != nil {
+		params, err := sonic.Marshal(request.Params)
+		if err != nil {
+			return 0
+		}
+		size += len(params)
+		if len(request.Params.ExtraParams) > 0 {
+			extraParams, err := sonic.Marshal(request.Params.ExtraParams)
+			if err != nil {
+				return 0
+			}
+			size += len(extraParams)
+		}
+	}
+	return size
+}
+
+// splitEmbeddingRequestBySize splits a batch into sub-requests whose estimated bodies fit in
+// maxBytes, packing consecutive inputs of the same prompt name greedily. An input that does not
+// fit on its own is an error.
+func splitEmbeddingRequestBySize(request *schemas.BifrostEmbeddingRequest, maxBytes int) ([]embeddingPromptGroup, error) {
+	groups, err := embeddingBatchGroups(request)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]embeddingPromptGroup, 0, len(groups))
+	for _, group := range groups {
+		// Everything in the body except the texts themselves
+		empty := group.slice(0, 0)
+		overhead := estimateEmbeddingRequestBytes(empty.request)
+		start, size := 0, overhead
+		for i, text := range group.request.Input.Texts {
+			encoded, err := sonic.Marshal(text)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize embedding input %d: %w", group.indices[i], err)
+			}
+			textSize := len(encoded) + 1 // and its separating comma
+			if overhead+textSize > maxBytes {
+				return nil, fmt.Errorf("embedding input %d alone makes a %d byte request body, exceeding the %d byte limit", group.indices[i], overhead+textSize, maxBytes)
+			}
+			if size+textSize > maxBytes {
+				chunks = append(chunks, group.slice(start, i))
+				start, size = i, overhead
+			}
+			size += textSize
+		}
+		chunks = append(chunks, group.slice(start, len(group.indices)))
+	}
+	return chunks, nil
+}
+
+// embeddingBatchGroups returns the batch split by prompt name (see
+// splitEmbeddingRequestByPromptName), or as a single group when it carries no prompt names.
+func embeddingBatchGroups(request *schemas.BifrostEmbeddingRequest) ([]embeddingPromptGroup, error) {
+	groups, err := splitEmbeddingRequestByPromptName(request)
+	if err != nil {
+		return nil, err
+	}
+	if groups != nil {
+		return groups, nil
+	}
+	if request.Input == nil || len(request.Input.Texts) == 0 {
+		return []embeddingPromptGroup{{indices: []int{0}, request: request}}, nil
+	}
+	indices := make([]int, len(request.Input.Texts))
+	for i := range indices {
+		indices[i] = i
+	}
+	return []embeddingPromptGroup{{indices: indices, request: request}}, nil
+}
+
+// slice returns the sub-request of the group's inputs [start, end). A group that is not a text
+// batch is returned whole.
+func (group embeddingPromptGroup) slice(start, end int) embeddingPromptGroup {
+	if group.request.Input == nil || len(group.request.Input.Texts) == 0 {
+		return group
+	}
+	chunkReq := *group.request
+	chunkReq.Input = &schemas.EmbeddingInput{Texts: group.request.Input.Texts[start:end]}
+	// Converters consume extra params, so every chunk needs its own copy
+	if group.request.Params != nil {
+		params := *group.request.Params
+		params.ExtraParams = maps.Clone(group.request.Params.ExtraParams)
+		chunkReq.Params = &params
+	}
+	return embeddingPromptGroup{indices: group.indices[start:end], request: &chunkReq}
+}
+
+// applyNewlinePolicy rewrites an embedding input according to the requested newline policy.
+func applyNewlinePolicy(text string, policy NewlinePolicy) string {
+	switch policy {
+	case NewlinePolicyReplace:
+		return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+	case NewlinePolicyCollapse:
+		return strings.Join(strings.Fields(text), " ")
+	default:
+		return text
+	}
+}
+
 // UnmarshalHuggingFaceEmbeddingResponse unmarshals HuggingFace API response directly into BifrostEmbeddingResponse
-// Handles multiple formats: standard object, 2D array, or 1D array
+// Handles multiple formats: standard object, 3D token-level array (mean pooled), 2D array, or 1D array
 func UnmarshalHuggingFaceEmbeddingResponse(data []byte, model string) (*schemas.BifrostEmbeddingResponse, error) {
+	return unmarshalHuggingFaceEmbeddingResponse(data, model, EmbeddingPoolingMean)
+}
+
+func unmarshalHuggingFaceEmbeddingResponse(data []byte, model string, pooling EmbeddingPooling) (*schemas.BifrostEmbeddingResponse, error) {
 	if data == nil {
 		return nil, fmt.Errorf("response data is nil")
 	}
 
+	// Token-level output can't be told apart from a batch by decoding attempts alone, so peek at the nesting
+	if embeddingArrayDepth(data) == 3 {
+		var arr3D [][][]float64
+		if err := sonic.Unmarshal(data, &arr3D); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal token-level HuggingFace embedding response: %w", err)
+		}
+		embeddings := make([]schemas.EmbeddingData, len(arr3D))
+		for idx, tokens := range arr3D {
+			embeddings[idx] = schemas.EmbeddingData{
+				Index:  idx,
+				Object: "embedding",
+			}
+			if pooling == EmbeddingPoolingNone {
+				embeddings[idx].Embedding = schemas.EmbeddingStruct{Embedding2DArray: tokens}
+			} else {
+				embeddings[idx].Embedding = schemas.EmbeddingStruct{EmbeddingArray: meanPool(tokens)}
+			}
+		}
+		return &schemas.BifrostEmbeddingResponse{
+			Data:   embeddings,
+			Model:  model,
+			Object: "list",
+			Usage:  &schemas.BifrostLLMUsage{},
+		}, nil
+	}
+
 	// Try standard object format first
 	type tempResponse struct {
 		Data  []schemas.EmbeddingData  `json:"data,omitempty"`
 		Model *string                  `json:"model,omitempty"`
 		Usage *schemas.BifrostLLMUsage `json:"usage,omitempty"`
+		DType EmbeddingDType           `json:"dtype,omitempty"`
+		Scale *float64                 `json:"scale,omitempty"`
 	}
 	var obj tempResponse
 	if err := sonic.Unmarshal(data, &obj); err == nil {
 		if obj.Data != nil || obj.Model != nil || obj.Usage != nil {
+			if err := dequantizeEmbeddings(obj.Data, obj.DType, obj.Scale); err != nil {
+				return nil, err
+			}
 			bifrostResponse := &schemas.BifrostEmbeddingResponse{
 				Data:   obj.Data,
 				Model:  model,
 				Object: "list",
 			}
+			bifrostResponse.ExtraFields.EmbeddingDType = string(obj.DType)
 			if obj.Model != nil {
 				bifrostResponse.Model = *obj.Model
 			}
@@ -163,3 +552,215 @@ func UnmarshalHuggingFaceEmbeddingResponse(data []byte, model string) (*schemas.
 
 	return nil, fmt.Errorf("failed to unmarshal HuggingFace embedding response: unexpected structure")
 }
+
+// embeddingInputCount returns how many embeddings a text input should produce, or 0 when the
+// count can't be known up front (token ID inputs).
+func embeddingInputCount(input *schemas.EmbeddingInput) int {
+	if input == nil {
+		return 0
+	}
+	if input.Text != nil {
+		return 1
+	}
+	return len(input.Texts)
+}
+
+// alignEmbeddingsToInputs checks that HF returned exactly one embedding per input and orders them
+// by index. A short batch is rejected rather than mapped positionally, which would pair the
+// remaining vectors with the wrong inputs. An inputCount of 0 skips the check.
+func alignEmbeddingsToInputs(data []schemas.EmbeddingData, inputCount int) error {
+	if inputCount == 0 {
+		return nil
+	}
+	if len(data) != inputCount {
+		return fmt.Errorf("HuggingFace returned %d embeddings for %d inputs", len(data), inputCount)
+	}
+	seen := make([]bool, inputCount)
+	for _, embedding := range data {
+		if embedding.Index < 0 || embedding.Index >= inputCount || seen[embedding.Index] {
+			return fmt.Errorf("HuggingFace returned embeddings whose indices do not cover each of the %d inputs exactly once", inputCount)
+		}
+		seen[embedding.Index] = true
+	}
+	slices.SortFunc(data, func(a, b schemas.EmbeddingData) int { return a.Index - b.Index })
+	return nil
+}
+
+// dequantizeEmbeddings turns int8/uint8 embeddings back into floats by multiplying each value by
+// the server-provided scale. Without a scale the quantized values are returned as integers
+// (uint8 in EmbeddingInt32Array, as for OpenAI's ubinary format). Float dtypes need no work.
+func dequantizeEmbeddings(data []schemas.EmbeddingData, dtype EmbeddingDType, scale *float64) error {
+	var lowest, highest float64
+	switch dtype {
+	case EmbeddingDTypeInt8:
+		lowest, highest = math.MinInt8, math.MaxInt8
+	case EmbeddingDTypeUint8:
+		lowest, highest = 0, math.MaxUint8
+	default:
+		return nil
+	}
+	if scale != nil && *scale <= 0 {
+		return fmt.Errorf("HuggingFace returned %s embeddings with non-positive scale %g", dtype, *scale)
+	}
+
+	for i := range data {
+		values := data[i].Embedding.EmbeddingArray
+		for _, value := range values {
+			if value != math.Trunc(value) || value < lowest || value > highest {
+				return fmt.Errorf("HuggingFace returned %s embeddings containing out-of-range value %g", dtype, value)
+			}
+		}
+		if scale != nil {
+			for j := range values {
+				values[j] *= *scale
+			}
+			continue
+		}
+		data[i].Embedding.EmbeddingArray = nil
+		if dtype == EmbeddingDTypeInt8 {
+			data[i].Embedding.EmbeddingInt8Array = make([]int8, len(values))
+			for j, value := range values {
+				data[i].Embedding.EmbeddingInt8Array[j] = int8(value)
+			}
+		} else {
+			data[i].Embedding.EmbeddingInt32Array = make([]int32, len(values))
+			for j, value := range values {
+				data[i].Embedding.EmbeddingInt32Array[j] = int32(value)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeEmbeddingsBase64 replaces each float vector with the base64 of its little-endian float32
+// bytes, as OpenAI returns for encoding_format "base64". Other embedding shapes are left as is.
+func encodeEmbeddingsBase64(data []schemas.EmbeddingData) {
+	for i := range data {
+		values := data[i].Embedding.EmbeddingArray
+		if values == nil {
+			continue
+		}
+		raw := make([]byte, 4*len(values))
+		for j, value := range values {
+			binary.LittleEndian.PutUint32(raw[4*j:], math.Float32bits(float32(value)))
+		}
+		encoded := base64.StdEncoding.EncodeToString(raw)
+		data[i].Embedding.EmbeddingStr = &encoded
+		data[i].Embedding.EmbeddingArray = nil
+	}
+}
+
+// truncateEmbeddingDimensions shortens vectors longer than dimensions, for backends that ignore
+// the dimensions parameter. Matryoshka models put the most significant components first, so the
+// prefix is kept; vectors that arrived L2-normalized are renormalized after the cut so they stay
+// unit length. Base64 and token-level embeddings are left untouched.
+func truncateEmbeddingDimensions(data []schemas.EmbeddingData, dimensions int) {
+	for i := range data {
+		vector := data[i].Embedding.EmbeddingArray
+		if len(vector) <= dimensions {
+			continue
+		}
+		normalized := math.Abs(l2Norm(vector)-1) < 1e-3
+		vector = vector[:dimensions:dimensions]
+		if norm := l2Norm(vector); normalized && norm > 0 {
+			for j := range vector {
+				vector[j] /= norm
+			}
+		}
+		data[i].Embedding.EmbeddingArray = vector
+	}
+}
+
+func l2Norm(vector []float64) float64 {
+	var sum float64
+	for _, value := range vector {
+		sum += value * value
+	}
+	return math.Sqrt(sum)
+}
+
+// embeddingArrayDepth reports how deeply the leading JSON arrays in data are nested
+// (e.g. 3 for [[[0.1]]]), or 0 when data does not start with an array.
+func embeddingArrayDepth(data []byte) int {
+	depth := 0
+	for _, b := range data {
+		switch b {
+		case '[':
+			depth++
+		case ' ', '\t', '\n', '\r':
+		default:
+			return depth
+		}
+	}
+	return depth
+}
+
+// meanPool averages token vectors into a single vector.
+func meanPool(tokens [][]float64) []float64 {
+	if len(tokens) == 0 {
+		return []float64{}
+	}
+	pooled := make([]float64, len(tokens[0]))
+	for _, token := range tokens {
+		for i := 0; i < len(pooled) && i < len(token); i++ {
+			pooled[i] += token[i]
+		}
+	}
+	for i := range pooled {
+		pooled[i] /= float64(len(tokens))
+	}
+	return pooled
+}
+
+// estimateTokens approximates the token count of text at roughly four characters per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// estimateEmbeddingUsage counts prompt usage for an embedding input when the provider reports none.
+func estimateEmbeddingUsage(input *schemas.EmbeddingInput, counter tokenCounter) *schemas.BifrostLLMUsage {
+	tokens := 0
+	if input != nil {
+		if input.Text != nil {
+			tokens += counter.countTokens(*input.Text)
+		}
+		for _, text := range input.Texts {
+			tokens += counter.countTokens(text)
+		}
+	}
+	return &schemas.BifrostLLMUsage{
+		PromptTokens: tokens,
+		TotalTokens:  tokens,
+	}
+}
+
+// embeddingUsageFromHeaders reads the prompt token count that text-embeddings-inference
+// reports in the x-compute-tokens response header.
+func embeddingUsageFromHeaders(headers map[string]string) *schemas.BifrostLLMUsage {
+	value, ok := getHeaderValue(headers, "x-compute-tokens")
+	if !ok {
+		return nil
+	}
+	tokens, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || tokens < 0 {
+		return nil
+	}
+	return &schemas.BifrostLLMUsage{
+		PromptTokens: tokens,
+		TotalTokens:  tokens,
+	}
+}
+
+// reportedEmbeddingUsage returns the usage the provider reported for an embedding response,
+// preferring the token count from the response headers over usage in the body.
+// Returns nil when neither carries real numbers.
+func reportedEmbeddingUsage(bodyUsage *schemas.BifrostLLMUsage, headers map[string]string) *schemas.BifrostLLMUsage {
+	if usage := embeddingUsageFromHeaders(headers); usage != nil {
+		return usage
+	}
+	// UnmarshalHuggingFaceEmbeddingResponse fills in zero usage when the body carries none
+	if bodyUsage != nil && (bodyUsage.PromptTokens > 0 || bodyUsage.TotalTokens > 0) {
+		return bodyUsage
+	}
+	return nil
+}
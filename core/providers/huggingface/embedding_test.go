@@ -0,0 +1,1630 @@
+package huggingface
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHuggingFaceEmbeddingRequest_NewlinePolicy(t *testing.T) {
+	const input = "first line\nsecond  line\r\n\tthird"
+
+	tests := []struct {
+		name   string
+		policy *string
+		want   string
+	}{
+		{name: "default_untouched", policy: nil, want: input},
+		{name: "none", policy: schemas.Ptr("none"), want: input},
+		{name: "replace", policy: schemas.Ptr("replace"), want: "first line second  line \tthird"},
+		{name: "collapse", policy: schemas.Ptr("collapse"), want: "first line second line third"},
+		{name: "mixed_case", policy: schemas.Ptr("Collapse"), want: "first line second line third"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &schemas.EmbeddingParameters{}
+			if tt.policy != nil {
+				params.ExtraParams = map[string]interface{}{"newline_policy": *tt.policy}
+			}
+			texts := []string{input, input}
+			req := &schemas.BifrostEmbeddingRequest{
+				Model:  "hf-inference/sentence-transformers/all-MiniLM-L6-v2",
+				Input:  &schemas.EmbeddingInput{Texts: texts},
+				Params: params,
+			}
+
+			result, err := ToHuggingFaceEmbeddingRequest(req)
+			require.NoError(t, err)
+			require.NotNil(t, result.Inputs)
+			assert.Equal(t, []string{tt.want, tt.want}, result.Inputs.Texts)
+			assert.NotContains(t, result.ExtraParams, "newline_policy")
+			// Caller's slice must not be rewritten in place
+			assert.Equal(t, input, texts[0])
+		})
+	}
+
+	t.Run("single_text", func(t *testing.T) {
+		req := &schemas.BifrostEmbeddingRequest{
+			Model: "sambanova/intfloat/e5-mistral-7b-instruct",
+			Input: &schemas.EmbeddingInput{Text: schemas.Ptr(input)},
+			Params: &schemas.EmbeddingParameters{
+				ExtraParams: map[string]interface{}{"newline_policy": "collapse"},
+			},
+		}
+
+		result, err := ToHuggingFaceEmbeddingRequest(req)
+		require.NoError(t, err)
+		require.NotNil(t, result.Input)
+		require.NotNil(t, result.Input.Text)
+		assert.Equal(t, "first line second line third", *result.Input.Text)
+	})
+
+	for name, policy := range map[string]interface{}{"unknown": "bogus", "non_string": true} {
+		t.Run("invalid_"+name, func(t *testing.T) {
+			req := &schemas.BifrostEmbeddingRequest{
+				Model: "hf-inference/sentence-transformers/all-MiniLM-L6-v2",
+				Input: &schemas.EmbeddingInput{Text: schemas.Ptr(input)},
+				Params: &schemas.EmbeddingParameters{
+					ExtraParams: map[string]interface{}{"newline_policy": policy},
+				},
+			}
+
+			_, err := ToHuggingFaceEmbeddingRequest(req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "newline_policy")
+		})
+	}
+}
+
+func TestEmbedding_PerInputPromptNames(t *testing.T) {
+	t.Parallel()
+
+	type capturedRequest struct {
+		Inputs     []string `json:"inputs"`
+		PromptName *string  `json:"prompt_name"`
+	}
+	var mu sync.Mutex
+	var captured []capturedRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req capturedRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		mu.Lock()
+		captured = append(captured, req)
+		mu.Unlock()
+
+		// One vector per input whose only value is the input length, so ordering can be verified
+		vectors := make([][]float64, len(req.Inputs))
+		for i, input := range req.Inputs {
+			vectors[i] = []float64{float64(len(input))}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(vectors)
+	}))
+	defer server.Close()
+
+	const modelName = "intfloat/multilingual-e5-large-instruct"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"q1", "doc one", "q22", "doc"}},
+		Params: &schemas.EmbeddingParameters{
+			ExtraParams: map[string]interface{}{
+				"prompt_names": []interface{}{"query", "passage", "query", ""},
+			},
+		},
+	})
+	require.Nil(t, bifrostErr)
+
+	require.Len(t, captured, 3)
+	byPrompt := make(map[string][]string)
+	for _, req := range captured {
+		name := ""
+		if req.PromptName != nil {
+			name = *req.PromptName
+		}
+		byPrompt[name] = req.Inputs
+	}
+	assert.Equal(t, []string{"q1", "q22"}, byPrompt["query"])
+	assert.Equal(t, []string{"doc one"}, byPrompt["passage"])
+	assert.Equal(t, []string{"doc"}, byPrompt[""])
+
+	require.Len(t, resp.Data, 4)
+	for i, want := range []float64{2, 7, 3, 3} {
+		assert.Equal(t, i, resp.Data[i].Index)
+		assert.Equal(t, []float64{want}, resp.Data[i].Embedding.EmbeddingArray)
+	}
+}
+
+func TestSplitEmbeddingRequestByPromptName_LengthMismatch(t *testing.T) {
+	_, err := splitEmbeddingRequestByPromptName(&schemas.BifrostEmbeddingRequest{
+		Model: "hf-inference/some/model",
+		Input: &schemas.EmbeddingInput{Texts: []string{"a", "b"}},
+		Params: &schemas.EmbeddingParameters{
+			ExtraParams: map[string]interface{}{"prompt_names": []string{"query"}},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestEmbedding_UsageAccuracy(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		body         string
+		wantAccuracy schemas.UsageAccuracy
+		wantTokens   int
+	}{
+		{
+			name:         "estimated_without_reported_usage",
+			body:         `[[0.1],[0.2]]`,
+			wantAccuracy: schemas.UsageAccuracyEstimated,
+			wantTokens:   3, // "hello" -> 2, "hi" -> 1
+		},
+		{
+			name:         "exact_from_headers",
+			headers:      map[string]string{"x-compute-tokens": "7"},
+			body:         `[[0.1],[0.2]]`,
+			wantAccuracy: schemas.UsageAccuracyExact,
+			wantTokens:   7,
+		},
+		{
+			name:         "exact_from_body",
+			body:         `{"data":[{"embedding":[0.1],"index":0},{"embedding":[0.2],"index":1}],"usage":{"prompt_tokens":5,"total_tokens":5}}`,
+			wantAccuracy: schemas.UsageAccuracyExact,
+			wantTokens:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Texts: []string{"hello", "hi"}},
+			})
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, tt.wantAccuracy, resp.ExtraFields.UsageAccuracy)
+			require.NotNil(t, resp.Usage)
+			assert.Equal(t, tt.wantTokens, resp.Usage.PromptTokens)
+			assert.Equal(t, tt.wantTokens, resp.Usage.TotalTokens)
+		})
+	}
+}
+
+func TestEmbedding_UsageEstimatorPerRequest(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+	const text = "Hello, unaffable world"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[0.1]]`)
+	}))
+	defer server.Close()
+
+	tokenizer, err := parseHubTokenizer([]byte(wordPieceTokenizerJSON))
+	require.NoError(t, err)
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.DisableTokenizerFetch = false
+	provider.tokenizerCache.Store(modelName, tokenizerCacheEntry{tokenizer: tokenizer, loadedAt: time.Now()})
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	tests := []struct {
+		name       string
+		estimator  any
+		wantTokens int
+	}{
+		{name: "default_uses_tokenizer", wantTokens: tokenizer.countTokens(text)},
+		{name: "heuristic", estimator: UsageEstimatorHeuristic, wantTokens: estimateTokens(text)},
+		{name: "tokenizer", estimator: UsageEstimatorTokenizer, wantTokens: tokenizer.countTokens(text)},
+		{name: "heuristic_string", estimator: "heuristic", wantTokens: estimateTokens(text)},
+	}
+	require.NotEqual(t, tokenizer.countTokens(text), estimateTokens(text), "estimators must disagree for the test to be meaningful")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			if tt.estimator != nil {
+				ctx.SetValue(UsageEstimatorContextKey, tt.estimator)
+			}
+			resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Text: schemas.Ptr(text)},
+			})
+			require.Nil(t, bifrostErr)
+			require.NotNil(t, resp.Usage)
+			assert.Equal(t, schemas.UsageAccuracyEstimated, resp.ExtraFields.UsageAccuracy)
+			assert.Equal(t, tt.wantTokens, resp.Usage.PromptTokens)
+		})
+	}
+}
+
+func TestEmbedding_CountMismatch(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+
+	tests := []struct {
+		name        string
+		body        string
+		wantErr     string
+		wantIndexed [][]float64
+	}{
+		{
+			name:    "positional_short_batch",
+			body:    `[[0.1],[0.2]]`,
+			wantErr: "returned 2 embeddings for 3 inputs",
+		},
+		{
+			name:    "indexed_short_batch",
+			body:    `{"data":[{"embedding":[0.1],"index":0},{"embedding":[0.3],"index":2}]}`,
+			wantErr: "returned 2 embeddings for 3 inputs",
+		},
+		{
+			name:    "indexed_duplicate",
+			body:    `{"data":[{"embedding":[0.1],"index":0},{"embedding":[0.2],"index":0},{"embedding":[0.3],"index":2}]}`,
+			wantErr: "do not cover each of the 3 inputs exactly once",
+		},
+		{
+			name:        "indexed_out_of_order",
+			body:        `{"data":[{"embedding":[0.3],"index":2},{"embedding":[0.1],"index":0},{"embedding":[0.2],"index":1}]}`,
+			wantIndexed: [][]float64{{0.1}, {0.2}, {0.3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Texts: []string{"a", "b", "c"}},
+			})
+			if tt.wantErr != "" {
+				assert.Nil(t, resp)
+				require.NotNil(t, bifrostErr)
+				require.NotNil(t, bifrostErr.Error.Error)
+				assert.Contains(t, bifrostErr.Error.Error.Error(), tt.wantErr)
+				return
+			}
+			require.Nil(t, bifrostErr)
+			require.Len(t, resp.Data, len(tt.wantIndexed))
+			for i, want := range tt.wantIndexed {
+				assert.Equal(t, i, resp.Data[i].Index)
+				assert.Equal(t, want, resp.Data[i].Embedding.EmbeddingArray)
+			}
+		})
+	}
+}
+
+func TestToHuggingFaceEmbeddingRequest_Normalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		extraParams map[string]interface{}
+		want        *bool
+	}{
+		{name: "unset_omitted", extraParams: nil, want: nil},
+		{name: "bool_true", extraParams: map[string]interface{}{"normalize": true}, want: schemas.Ptr(true)},
+		{name: "bool_false", extraParams: map[string]interface{}{"normalize": false}, want: schemas.Ptr(false)},
+		{name: "string_value", extraParams: map[string]interface{}{"normalize": "true"}, want: schemas.Ptr(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &schemas.BifrostEmbeddingRequest{
+				Model:  "hf-inference/sentence-transformers/all-MiniLM-L6-v2",
+				Input:  &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+				Params: &schemas.EmbeddingParameters{ExtraParams: tt.extraParams},
+			}
+
+			result, err := ToHuggingFaceEmbeddingRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Normalize)
+			assert.NotContains(t, result.ExtraParams, "normalize")
+
+			body, err := json.Marshal(result)
+			require.NoError(t, err)
+			var decoded map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			if tt.want == nil {
+				assert.NotContains(t, decoded, "normalize")
+			} else {
+				assert.Equal(t, *tt.want, decoded["normalize"])
+			}
+		})
+	}
+}
+
+func TestToHuggingFaceEmbeddingRequest_PromptName(t *testing.T) {
+	tests := []struct {
+		name       string
+		promptName interface{}
+		want       *string
+	}{
+		{name: "query", promptName: "query", want: schemas.Ptr("query")},
+		{name: "passage", promptName: "passage", want: schemas.Ptr("passage")},
+		{name: "empty_omitted", promptName: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &schemas.BifrostEmbeddingRequest{
+				Model: "nebius/intfloat/multilingual-e5-large-instruct",
+				Input: &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+				Params: &schemas.EmbeddingParameters{
+					ExtraParams: map[string]interface{}{"prompt_name": tt.promptName},
+				},
+			}
+
+			result, err := ToHuggingFaceEmbeddingRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.PromptName)
+			assert.NotContains(t, result.ExtraParams, "prompt_name")
+
+			body, err := json.Marshal(result)
+			require.NoError(t, err)
+			var decoded map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			if tt.want == nil {
+				assert.NotContains(t, decoded, "prompt_name")
+			} else {
+				assert.Equal(t, *tt.want, decoded["prompt_name"])
+			}
+		})
+	}
+}
+
+func TestEmbedding_ErrorObjectWithOKStatus(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+
+	for name, body := range map[string]string{
+		"error_only":         `{"error":"input too long"}`,
+		"error_with_partial": `{"error":"input too long","data":[{"embedding":[0.1],"index":0}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, body)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Texts: []string{"hello", "world"}},
+			})
+			assert.Nil(t, resp)
+			require.NotNil(t, bifrostErr)
+			require.NotNil(t, bifrostErr.Error)
+			assert.Equal(t, "input too long", bifrostErr.Error.Message)
+		})
+	}
+}
+
+func TestToHuggingFaceEmbeddingRequest_Truncation(t *testing.T) {
+	newRequest := func(extraParams map[string]interface{}) *schemas.BifrostEmbeddingRequest {
+		return &schemas.BifrostEmbeddingRequest{
+			Model:  "hf-inference/BAAI/bge-large-en-v1.5",
+			Input:  &schemas.EmbeddingInput{Text: schemas.Ptr("a very long document")},
+			Params: &schemas.EmbeddingParameters{ExtraParams: extraParams},
+		}
+	}
+
+	t.Run("unset_omitted", func(t *testing.T) {
+		result, err := ToHuggingFaceEmbeddingRequest(newRequest(nil))
+		require.NoError(t, err)
+		assert.Nil(t, result.Truncate)
+		assert.Nil(t, result.TruncationDirection)
+	})
+
+	for direction, want := range map[string]TruncationDirection{
+		"Left":  TruncationDirectionLeft,
+		"left":  TruncationDirectionLeft,
+		"RIGHT": TruncationDirectionRight,
+	} {
+		t.Run("direction_"+direction, func(t *testing.T) {
+			result, err := ToHuggingFaceEmbeddingRequest(newRequest(map[string]interface{}{
+				"truncate":             true,
+				"truncation_direction": direction,
+			}))
+			require.NoError(t, err)
+			require.NotNil(t, result.Truncate)
+			assert.True(t, *result.Truncate)
+			require.NotNil(t, result.TruncationDirection)
+			assert.Equal(t, want, *result.TruncationDirection)
+			assert.NotContains(t, result.ExtraParams, "truncate")
+			assert.NotContains(t, result.ExtraParams, "truncation_direction")
+		})
+	}
+
+	for name, direction := range map[string]interface{}{"unknown": "middle", "non_string": 1} {
+		t.Run("invalid_"+name, func(t *testing.T) {
+			_, err := ToHuggingFaceEmbeddingRequest(newRequest(map[string]interface{}{
+				"truncate":             true,
+				"truncation_direction": direction,
+			}))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "truncation_direction")
+		})
+	}
+}
+
+func TestToHuggingFaceEmbeddingRequest_TruncationByPrompt(t *testing.T) {
+	overrides := func() map[string]interface{} {
+		return map[string]interface{}{
+			"query":   map[string]interface{}{"truncate": false},
+			"passage": map[string]interface{}{"truncate": true, "truncation_direction": "right"},
+		}
+	}
+	newRequest := func(extraParams map[string]interface{}) *schemas.BifrostEmbeddingRequest {
+		return &schemas.BifrostEmbeddingRequest{
+			Model:  "hf-inference/intfloat/multilingual-e5-large",
+			Input:  &schemas.EmbeddingInput{Text: schemas.Ptr("a very long document")},
+			Params: &schemas.EmbeddingParameters{ExtraParams: extraParams},
+		}
+	}
+
+	t.Run("prompt_override_wins", func(t *testing.T) {
+		result, err := ToHuggingFaceEmbeddingRequest(newRequest(map[string]interface{}{
+			"prompt_name":          "passage",
+			"truncate":             false,
+			"truncation_direction": "left",
+			"truncation_by_prompt": overrides(),
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, result.Truncate)
+		assert.True(t, *result.Truncate)
+		require.NotNil(t, result.TruncationDirection)
+		assert.Equal(t, TruncationDirectionRight, *result.TruncationDirection)
+		assert.NotContains(t, result.ExtraParams, "truncation_by_prompt")
+	})
+
+	t.Run("unset_fields_fall_back_to_request", func(t *testing.T) {
+		result, err := ToHuggingFaceEmbeddingRequest(newRequest(map[string]interface{}{
+			"prompt_name":          "query",
+			"truncate":             true,
+			"truncation_direction": "left",
+			"truncation_by_prompt": overrides(),
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, result.Truncate)
+		assert.False(t, *result.Truncate)
+		require.NotNil(t, result.TruncationDirection)
+		assert.Equal(t, TruncationDirectionLeft, *result.TruncationDirection)
+	})
+
+	t.Run("no_override_for_prompt", func(t *testing.T) {
+		for _, extraParams := range []map[string]interface{}{
+			{"prompt_name": "document", "truncate": true, "truncation_by_prompt": overrides()},
+			{"truncate": true, "truncation_by_prompt": overrides()},
+		} {
+			result, err := ToHuggingFaceEmbeddingRequest(newRequest(extraParams))
+			require.NoError(t, err)
+			require.NotNil(t, result.Truncate)
+			assert.True(t, *result.Truncate)
+			assert.Nil(t, result.TruncationDirection)
+		}
+	})
+
+	for name, value := range map[string]interface{}{
+		"not_an_object":    "query",
+		"entry_not_object": map[string]interface{}{"query": false},
+		"bad_truncate":     map[string]interface{}{"query": map[string]interface{}{"truncate": "sometimes"}},
+		"bad_direction":    map[string]interface{}{"query": map[string]interface{}{"truncation_direction": "middle"}},
+		"unknown_field":    map[string]interface{}{"query": map[string]interface{}{"max_length": 128}},
+	} {
+		t.Run("invalid_"+name, func(t *testing.T) {
+			_, err := ToHuggingFaceEmbeddingRequest(newRequest(map[string]interface{}{
+				"prompt_name":          "query",
+				"truncation_by_prompt": value,
+			}))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "truncation_by_prompt")
+		})
+	}
+}
+
+func TestEmbedding_SingleStringInputFlatResponse(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+
+	var sentInputs interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		sentInputs = body["inputs"]
+		// sentence-transformers pipelines answer a bare string input with a flat vector
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[0.25,-0.5,0.125]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello world")},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "hello world", sentInputs)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, 0, resp.Data[0].Index)
+	assert.Equal(t, []float64{0.25, -0.5, 0.125}, resp.Data[0].Embedding.EmbeddingArray)
+}
+
+func TestUnmarshalHuggingFaceEmbeddingResponse_TokenLevel(t *testing.T) {
+	// Two inputs, with two and one tokens respectively
+	body := []byte(` [[[1.0, 2.0], [3.0, 4.0]], [[5.0, 6.0]]]`)
+
+	t.Run("mean_pooled_by_default", func(t *testing.T) {
+		resp, err := UnmarshalHuggingFaceEmbeddingResponse(body, "m")
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, []float64{2, 3}, resp.Data[0].Embedding.EmbeddingArray)
+		assert.Equal(t, []float64{5, 6}, resp.Data[1].Embedding.EmbeddingArray)
+		assert.Equal(t, 1, resp.Data[1].Index)
+	})
+
+	t.Run("token_matrix_without_pooling", func(t *testing.T) {
+		resp, err := unmarshalHuggingFaceEmbeddingResponse(body, "m", EmbeddingPoolingNone)
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 2)
+		assert.Nil(t, resp.Data[0].Embedding.EmbeddingArray)
+		assert.Equal(t, [][]float64{{1, 2}, {3, 4}}, resp.Data[0].Embedding.Embedding2DArray)
+		assert.Equal(t, [][]float64{{5, 6}}, resp.Data[1].Embedding.Embedding2DArray)
+	})
+
+	t.Run("2d_unaffected", func(t *testing.T) {
+		resp, err := unmarshalHuggingFaceEmbeddingResponse([]byte(`[[1.0, 2.0]]`), "m", EmbeddingPoolingNone)
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, []float64{1, 2}, resp.Data[0].Embedding.EmbeddingArray)
+	})
+}
+
+func TestEmbedding_PoolingParamNotForwarded(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "google-bert/bert-base-uncased"
+
+	var sentBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&sentBody))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[[1.0, 2.0], [3.0, 4.0]]]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyPassthroughExtraParams, true)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"hello"}},
+		Params: &schemas.EmbeddingParameters{
+			ExtraParams: map[string]interface{}{"pooling": "none"},
+		},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.NotContains(t, sentBody, "pooling")
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, [][]float64{{1, 2}, {3, 4}}, resp.Data[0].Embedding.Embedding2DArray)
+}
+
+func TestEmbedding_JSONContentType(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "google-bert/bert-base-uncased"
+
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{"default includes charset", "", "application/json; charset=utf-8"},
+		{"configured bare type", "application/json", "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotContentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotContentType = r.Header.Get("Content-Type")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `[[1.0, 2.0]]`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.huggingFaceConfig.JSONContentType = tt.configured
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			_, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+			})
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, tt.want, gotContentType)
+		})
+	}
+}
+
+func TestEmbedding_ModelHubURL(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "google-bert/bert-base-uncased"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[1.0, 2.0]]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.IncludeModelHubURL = true
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+	})
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, "https://huggingface.co/google-bert/bert-base-uncased", resp.ExtraFields.ModelHubURL)
+}
+
+func TestEmbedding_KeyInferenceProvider(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "intfloat/e5-mistral-7b-instruct"
+
+	tests := []struct {
+		name     string
+		key      schemas.Key
+		wantPath string
+	}{
+		{"defaults_to_hf_inference", schemas.Key{}, "/hf-inference/models/" + modelName + "/pipeline/feature-extraction"},
+		{"routes_to_key_preference", schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: "sambanova"}}, "/sambanova/v1/embeddings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `[[1.0, 2.0]]`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+				sambanova:   {ProviderTask: "feature-extraction", ProviderModelID: "E5-Mistral-7B-Instruct"},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			_, bifrostErr := provider.Embedding(ctx, tt.key, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    modelName,
+				Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+			})
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, tt.wantPath, gotPath)
+		})
+	}
+}
+
+func TestEmbedding_TextAndTextsBothSet(t *testing.T) {
+	t.Parallel()
+
+	const modelName = "google-bert/bert-base-uncased"
+
+	tests := []struct {
+		name       string
+		merge      bool
+		wantErr    bool
+		wantInputs []string
+	}{
+		{name: "rejected_by_default", merge: false, wantErr: true},
+		{name: "merged_when_enabled", merge: true, wantInputs: []string{"first", "second", "third"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sentInputs []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req HuggingFaceEmbeddingRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				sentInputs = req.Inputs.Texts
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `[[1.0], [2.0], [3.0]]`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.huggingFaceConfig.MergeEmbeddingTextInputs = tt.merge
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("first"), Texts: []string{"second", "third"}},
+			})
+			if tt.wantErr {
+				require.NotNil(t, bifrostErr)
+				assert.Nil(t, resp)
+				assert.Nil(t, sentInputs, "ambiguous input must not be sent upstream")
+				return
+			}
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, tt.wantInputs, sentInputs)
+			assert.Len(t, resp.Data, 3)
+		})
+	}
+}
+
+func TestEmbedding_AuthHeaderWithPrefixedToken(t *testing.T) {
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+
+	for _, token := range []string{"hf_test", "Bearer hf_test", "bearer  hf_test"} {
+		t.Run(token, func(t *testing.T) {
+			var authHeaders []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authHeaders = r.Header.Values("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `[[0.1]]`)
+			}))
+			defer server.Close()
+
+			provider := newTestHuggingFaceProvider(t, server.URL)
+			provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+			})
+
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			_, bifrostErr := provider.Embedding(ctx, schemas.Key{Value: *schemas.NewEnvVar(token)}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + modelName,
+				Input:    &schemas.EmbeddingInput{Texts: []string{"a"}},
+			})
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, []string{"Bearer hf_test"}, authHeaders)
+		})
+	}
+}
+
+func TestEmbedding_EffectiveConfig(t *testing.T) {
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[0.1],[0.2],[0.3]]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+	key := schemas.Key{Aliases: schemas.KeyAliases{"minilm": modelName}}
+	request := func() *schemas.BifrostEmbeddingRequest {
+		return &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    modelName,
+			Input:    &schemas.EmbeddingInput{Texts: []string{"a", "b", "c"}},
+		}
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, key, request())
+	require.Nil(t, bifrostErr)
+	assert.Nil(t, resp.ExtraFields.EffectiveConfig, "effective config is opt-in")
+
+	provider.huggingFaceConfig.IncludeEffectiveConfig = true
+	resp, bifrostErr = provider.Embedding(ctx, key, request())
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, &HuggingFaceEffectiveConfig{
+		Model:             modelName,
+		InferenceProvider: string(hfInference),
+		Task:              "feature-extraction",
+		AppliedDefaults:   []string{"inference_provider", "pooling"},
+		BatchSize:         3,
+	}, resp.ExtraFields.EffectiveConfig)
+}
+
+func TestToHuggingFaceEmbeddingRequest_Dimensions(t *testing.T) {
+	for _, dimensions := range []int{0, -8} {
+		_, err := ToHuggingFaceEmbeddingRequest(&schemas.BifrostEmbeddingRequest{
+			Model:  "hf-inference/nomic-ai/nomic-embed-text-v1.5",
+			Input:  &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+			Params: &schemas.EmbeddingParameters{Dimensions: schemas.Ptr(dimensions)},
+		})
+		assert.ErrorContains(t, err, "dimensions must be a positive integer")
+	}
+
+	hfReq, err := ToHuggingFaceEmbeddingRequest(&schemas.BifrostEmbeddingRequest{
+		Model:  "hf-inference/nomic-ai/nomic-embed-text-v1.5",
+		Input:  &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+		Params: &schemas.EmbeddingParameters{Dimensions: schemas.Ptr(256)},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, hfReq.Dimensions)
+	assert.Equal(t, 256, *hfReq.Dimensions)
+}
+
+func TestEmbedding_TruncatesIgnoredDimensions(t *testing.T) {
+	const modelName = "nomic-ai/nomic-embed-text-v1.5"
+
+	var sentDimensions interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sentDimensions = body["dimensions"]
+		// The backend ignores dimensions: a unit vector and an unnormalized one at full size
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[0.6,0.0,0.8,0.0],[3,4,5,6]]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"a", "b"}},
+		Params:   &schemas.EmbeddingParameters{Dimensions: schemas.Ptr(2)},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, float64(2), sentDimensions)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, []float64{1, 0}, resp.Data[0].Embedding.EmbeddingArray, "normalized vectors stay unit length")
+	assert.Equal(t, []float64{3, 4}, resp.Data[1].Embedding.EmbeddingArray)
+}
+
+func TestEmbedding_Int8QuantizedResponse(t *testing.T) {
+	const modelName = "BAAI/bge-small-en-v1.5"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"object":"list","dtype":"int8","scale":0.5,"data":[`+
+			`{"object":"embedding","index":0,"embedding":[2,-4,127]},`+
+			`{"object":"embedding","index":1,"embedding":[-128,0,1]}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"a", "b"}},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "int8", resp.ExtraFields.EmbeddingDType)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, []float64{1, -2, 63.5}, resp.Data[0].Embedding.EmbeddingArray)
+	assert.Equal(t, []float64{-64, 0, 0.5}, resp.Data[1].Embedding.EmbeddingArray)
+}
+
+func TestDequantizeEmbeddings(t *testing.T) {
+	newData := func(values ...float64) []schemas.EmbeddingData {
+		return []schemas.EmbeddingData{{Embedding: schemas.EmbeddingStruct{EmbeddingArray: values}}}
+	}
+
+	t.Run("int8 without scale stays integer", func(t *testing.T) {
+		data := newData(1, -128, 127)
+		require.NoError(t, dequantizeEmbeddings(data, EmbeddingDTypeInt8, nil))
+		assert.Nil(t, data[0].Embedding.EmbeddingArray)
+		assert.Equal(t, []int8{1, -128, 127}, data[0].Embedding.EmbeddingInt8Array)
+	})
+
+	t.Run("uint8 without scale stays integer", func(t *testing.T) {
+		data := newData(0, 255)
+		require.NoError(t, dequantizeEmbeddings(data, EmbeddingDTypeUint8, nil))
+		assert.Equal(t, []int32{0, 255}, data[0].Embedding.EmbeddingInt32Array)
+	})
+
+	t.Run("float dtypes are untouched", func(t *testing.T) {
+		data := newData(0.25, -0.5)
+		require.NoError(t, dequantizeEmbeddings(data, EmbeddingDTypeFloat16, schemas.Ptr(2.0)))
+		assert.Equal(t, []float64{0.25, -0.5}, data[0].Embedding.EmbeddingArray)
+	})
+
+	t.Run("out of range value", func(t *testing.T) {
+		assert.Error(t, dequantizeEmbeddings(newData(200), EmbeddingDTypeInt8, schemas.Ptr(0.1)))
+		assert.Error(t, dequantizeEmbeddings(newData(1.5), EmbeddingDTypeInt8, schemas.Ptr(0.1)))
+	})
+
+	t.Run("non-positive scale", func(t *testing.T) {
+		assert.Error(t, dequantizeEmbeddings(newData(1), EmbeddingDTypeInt8, schemas.Ptr(0.0)))
+	})
+}
+
+func TestEmbedding_FallbackModel(t *testing.T) {
+	const primaryModel = "BAAI/bge-large-en-v1.5"
+	const fallbackModel = "BAAI/bge-small-en-v1.5"
+
+	var requestedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, primaryModel) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, `{"error":"Service Unavailable"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `[[0.1,0.2]]`)
+	}))
+	defer server.Close()
+
+	newProvider := func(t *testing.T) *HuggingFaceProvider {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		for _, model := range []string{primaryModel, fallbackModel} {
+			provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: model},
+			})
+		}
+		provider.huggingFaceConfig.EmbeddingFallbackModel = "hf-inference/" + fallbackModel
+		return provider
+	}
+	newRequest := func() *schemas.BifrostEmbeddingRequest {
+		return &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "hf-inference/" + primaryModel,
+			Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+		}
+	}
+
+	t.Run("primary 503 is served by the fallback", func(t *testing.T) {
+		requestedPaths = nil
+		provider := newProvider(t)
+
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, newRequest())
+		require.Nil(t, bifrostErr)
+
+		require.Len(t, requestedPaths, 2)
+		assert.Contains(t, requestedPaths[0], primaryModel)
+		assert.Contains(t, requestedPaths[1], fallbackModel)
+		assert.Equal(t, "hf-inference/"+fallbackModel, resp.ExtraFields.FallbackModelUsed)
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, []float64{0.1, 0.2}, resp.Data[0].Embedding.EmbeddingArray)
+	})
+
+	t.Run("statuses outside the configured list are returned", func(t *testing.T) {
+		requestedPaths = nil
+		provider := newProvider(t)
+		provider.huggingFaceConfig.EmbeddingFallbackStatusCodes = []int{http.StatusNotFound}
+
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.Embedding(ctx, schemas.Key{}, newRequest())
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.StatusCode)
+		assert.Equal(t, http.StatusServiceUnavailable, *bifrostErr.StatusCode)
+		assert.Len(t, requestedPaths, 1)
+	})
+}
+
+func TestEmbedding_DedicatedEndpoint(t *testing.T) {
+	router := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("router should not be called, got %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer router.Close()
+
+	var endpointPath string
+	var sentBody map[string]interface{}
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpointPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&sentBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[0.1,0.2]]`)
+	}))
+	defer endpoint.Close()
+
+	// No provider mapping is cached: a dedicated endpoint must not need the Hub lookup
+	provider := newTestHuggingFaceProvider(t, router.URL)
+	key := schemas.Key{
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			Endpoints: map[string]string{"BAAI/bge-m3": endpoint.URL},
+		},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, key, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/BAAI/bge-m3",
+		Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+	})
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, "/", endpointPath)
+	assert.Equal(t, "hello", sentBody["inputs"])
+	assert.NotContains(t, sentBody, "model")
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, []float64{0.1, 0.2}, resp.Data[0].Embedding.EmbeddingArray)
+}
+
+func TestEmbedding_PathAndBodyModelAgree(t *testing.T) {
+	var requestPath, bodyModel string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodyModel, _ = body["model"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[0.1,0.2]]`)
+	}))
+	defer server.Close()
+
+	// The alias target differs in case from the provider's model ID, as Hub lookups are case-insensitive
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store("baai/bge-m3", map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: "BAAI/bge-m3"},
+	})
+	key := schemas.Key{Aliases: schemas.KeyAliases{"embed": "hf-inference/baai/bge-m3"}}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.Embedding(ctx, key, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/baai/bge-m3",
+		Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "BAAI/bge-m3", bodyModel)
+	assert.Equal(t, "/hf-inference/models/BAAI/bge-m3/pipeline/feature-extraction", requestPath)
+}
+
+func TestEmbedding_Base64EncodingFormat(t *testing.T) {
+	const modelName = "BAAI/bge-small-en-v1.5"
+
+	var sentBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sentBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[[0.5,-1.25,3.0],[0.1,0.2,0.3]]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"a", "b"}},
+		Params:   &schemas.EmbeddingParameters{EncodingFormat: schemas.Ptr("base64")},
+	})
+	require.Nil(t, bifrostErr)
+	assert.NotContains(t, sentBody, "encoding_format", "upstream always returns floats")
+
+	decode := func(t *testing.T, embedding schemas.EmbeddingStruct) []float32 {
+		t.Helper()
+		require.Nil(t, embedding.EmbeddingArray)
+		require.NotNil(t, embedding.EmbeddingStr)
+		raw, err := base64.StdEncoding.DecodeString(*embedding.EmbeddingStr)
+		require.NoError(t, err)
+		require.Zero(t, len(raw)%4)
+		values := make([]float32, len(raw)/4)
+		for i := range values {
+			values[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[4*i:]))
+		}
+		return values
+	}
+
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, []float32{0.5, -1.25, 3.0}, decode(t, resp.Data[0].Embedding))
+	assert.Equal(t, []float32{0.1, 0.2, 0.3}, decode(t, resp.Data[1].Embedding))
+}
+
+func TestEmbedding_PromptGroupErrorPolicy(t *testing.T) {
+	const modelName = "intfloat/multilingual-e5-large-instruct"
+
+	// The "bad" group fails outright; the others answer a 503 once and would be resent after
+	// Retry-After unless their call has been cancelled by then
+	newServer := func(slowRequests *atomic.Int32) *httptest.Server {
+		var mu sync.Mutex
+		seen := make(map[string]bool)
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				PromptName string `json:"prompt_name"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("Content-Type", "application/json")
+			if body.PromptName == "bad" {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = io.WriteString(w, `{"error":"boom"}`)
+				return
+			}
+			slowRequests.Add(1)
+			mu.Lock()
+			retried := seen[body.PromptName]
+			seen[body.PromptName] = true
+			mu.Unlock()
+			if !retried {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = io.WriteString(w, `{"error":"busy"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `[[0.1]]`)
+		}))
+	}
+	run := func(t *testing.T, policy EmbeddingBatchErrorPolicy, slowRequests *atomic.Int32) (*schemas.BifrostError, time.Duration) {
+		t.Helper()
+		server := newServer(slowRequests)
+		t.Cleanup(server.Close)
+
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+			hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+		})
+		provider.huggingFaceConfig.TransientErrorRetries = 1
+		provider.huggingFaceConfig.EmbeddingBatchErrorPolicy = string(policy)
+
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		start := time.Now()
+		_, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "hf-inference/" + modelName,
+			Input:    &schemas.EmbeddingInput{Texts: []string{"q", "p", "x"}},
+			Params: &schemas.EmbeddingParameters{
+				ExtraParams: map[string]interface{}{"prompt_names": []interface{}{"query", "passage", "bad"}},
+			},
+		})
+		return bifrostErr, time.Since(start)
+	}
+
+	t.Run("fail fast cancels the other groups", func(t *testing.T) {
+		var slowRequests atomic.Int32
+		bifrostErr, elapsed := run(t, EmbeddingBatchErrorPolicyFailFast, &slowRequests)
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.StatusCode)
+		assert.Equal(t, http.StatusInternalServerError, *bifrostErr.StatusCode)
+		assert.Less(t, elapsed, 900*time.Millisecond, "returns without waiting for the other groups")
+
+		// Give a resend every chance to happen: the cancelled groups must not send again
+		time.Sleep(1500 * time.Millisecond)
+		assert.LessOrEqual(t, slowRequests.Load(), int32(2))
+	})
+
+	t.Run("best effort lets the other groups finish", func(t *testing.T) {
+		var slowRequests atomic.Int32
+		bifrostErr, elapsed := run(t, EmbeddingBatchErrorPolicyBestEffort, &slowRequests)
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.StatusCode)
+		assert.Equal(t, http.StatusInternalServerError, *bifrostErr.StatusCode)
+		assert.GreaterOrEqual(t, elapsed, time.Second)
+		assert.Equal(t, int32(4), slowRequests.Load(), "both groups were resent and completed")
+	})
+}
+
+func TestEmbedding_GzipRequestBody(t *testing.T) {
+	const modelName = "BAAI/bge-small-en-v1.5"
+
+	type captured struct {
+		contentEncoding string
+		rawBody         []byte
+		inputs          []string
+	}
+	var mu sync.Mutex
+	var last captured
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawBody, _ := io.ReadAll(r.Body)
+		body := rawBody
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(bytes.NewReader(rawBody))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body, _ = io.ReadAll(reader)
+		}
+		var decoded struct {
+			Inputs []string `json:"inputs"`
+		}
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		last = captured{contentEncoding: r.Header.Get("Content-Encoding"), rawBody: rawBody, inputs: decoded.Inputs}
+		mu.Unlock()
+
+		vectors := make([][]float64, len(decoded.Inputs))
+		for i := range vectors {
+			vectors[i] = []float64{float64(i)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(vectors)
+	}))
+	defer server.Close()
+
+	texts := make([]string, 200)
+	for i := range texts {
+		texts[i] = "the same sentence repeated for a large, highly compressible embedding batch"
+	}
+	run := func(t *testing.T, minBytes int) captured {
+		t.Helper()
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+			hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+		})
+		provider.huggingFaceConfig.GzipEmbeddingRequestMinBytes = minBytes
+
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		resp, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "hf-inference/" + modelName,
+			Input:    &schemas.EmbeddingInput{Texts: texts},
+		})
+		require.Nil(t, bifrostErr)
+		require.Len(t, resp.Data, len(texts))
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+
+	t.Run("enabled above the threshold", func(t *testing.T) {
+		got := run(t, 1024)
+		assert.Equal(t, "gzip", got.contentEncoding)
+		require.GreaterOrEqual(t, len(got.rawBody), 2)
+		assert.Equal(t, []byte{0x1f, 0x8b}, got.rawBody[:2], "body is gzip data")
+		assert.Equal(t, texts, got.inputs, "endpoint decompresses the original batch")
+		assert.Less(t, len(got.rawBody), 1024, "repetitive batch compresses well below its JSON size")
+	})
+
+	t.Run("below the threshold", func(t *testing.T) {
+		got := run(t, 1<<20)
+		assert.Empty(t, got.contentEncoding)
+		assert.Equal(t, texts, got.inputs)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		got := run(t, 0)
+		assert.Empty(t, got.contentEncoding)
+		assert.Equal(t, byte('{'), got.rawBody[0])
+	})
+}
+
+func TestEmbedding_GatedModelError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"error":"Access to model google/embeddinggemma-300m is restricted. You must have access to it and be authenticated to access it."}`)
+	}))
+	defer server.Close()
+
+	const modelName = "google/embeddinggemma-300m"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+	})
+	require.NotNil(t, bifrostErr)
+	require.NotNil(t, bifrostErr.Type)
+	assert.Equal(t, gatedModelErrorType, *bifrostErr.Type)
+	assert.Contains(t, bifrostErr.Error.Message, "model google/embeddinggemma-300m is gated")
+}
+
+func TestToHuggingFaceEmbeddingRequest_InferenceOptions(t *testing.T) {
+	req, err := ToHuggingFaceEmbeddingRequest(&schemas.BifrostEmbeddingRequest{
+		Model: "hf-inference/sentence-transformers/all-MiniLM-L6-v2",
+		Input: &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+		Params: &schemas.EmbeddingParameters{
+			ExtraParams: map[string]interface{}{"wait_for_model": true, "use_cache": "false"},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, req.Options)
+	assert.Equal(t, schemas.Ptr(true), req.Options.WaitForModel)
+	assert.Equal(t, schemas.Ptr(false), req.Options.UseCache)
+	assert.Empty(t, req.ExtraParams)
+
+	body, marshalErr := json.Marshal(req)
+	require.NoError(t, marshalErr)
+	assert.Contains(t, string(body), `"options":{"wait_for_model":true,"use_cache":false}`)
+
+	// Other inference providers speak the OpenAI-style API, which has no options object
+	req, err = ToHuggingFaceEmbeddingRequest(&schemas.BifrostEmbeddingRequest{
+		Model: "nebius/Qwen/Qwen3-Embedding-8B",
+		Input: &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+		Params: &schemas.EmbeddingParameters{
+			ExtraParams: map[string]interface{}{"wait_for_model": true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, req.Options)
+}
+
+func TestEmbeddingStream_EmitsChunksWithOriginalIndices(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			Inputs []string `json:"inputs"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+		// Hold back the first chunk so chunks complete out of order
+		if req.Inputs[0] == "a" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		vectors := make([][]float64, len(req.Inputs))
+		for i, input := range req.Inputs {
+			vectors[i] = []float64{float64(len(input))}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(vectors)
+	}))
+	defer server.Close()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.EmbeddingStreamChunkSize = 2
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.EmbeddingStream(ctx, noopPostHookRunner, nil, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"a", "bb", "ccc", "dddd", "eeeee"}},
+	})
+	require.Nil(t, bifrostErr)
+
+	embeddings := make(map[int]float64)
+	var chunks []*schemas.BifrostEmbeddingResponse
+	for chunk := range stream {
+		require.Nil(t, chunk.BifrostError)
+		require.NotNil(t, chunk.BifrostEmbeddingResponse)
+		chunks = append(chunks, chunk.BifrostEmbeddingResponse)
+		for _, data := range chunk.BifrostEmbeddingResponse.Data {
+			embeddings[data.Index] = data.Embedding.EmbeddingArray[0]
+		}
+	}
+
+	require.Len(t, chunks, 3)
+	// The delayed first chunk ("a", "bb") arrives last
+	assert.Equal(t, 0, chunks[2].Data[0].Index)
+	for i, chunk := range chunks {
+		assert.Equal(t, i, chunk.ExtraFields.ChunkIndex)
+	}
+	assert.Equal(t, map[int]float64{0: 1, 1: 2, 2: 3, 3: 4, 4: 5}, embeddings)
+}
+
+func TestEmbeddingStream_ClosesOnError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"bad input"}`))
+	}))
+	defer server.Close()
+
+	const modelName = "sentence-transformers/all-MiniLM-L6-v2"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.EmbeddingStreamChunkSize = 1
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.EmbeddingStream(ctx, noopPostHookRunner, nil, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.EmbeddingInput{Texts: []string{"a", "b", "c"}},
+	})
+	require.Nil(t, bifrostErr)
+
+	var received []*schemas.BifrostStreamChunk
+	for chunk := range stream {
+		received = append(received, chunk)
+	}
+	require.Len(t, received, 1)
+	require.NotNil(t, received[0].BifrostError)
+}
+
+func TestEmbedding_OversizedRequestBody(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var bodySizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			Inputs []string `json:"inputs"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+		mu.Lock()
+		bodySizes = append(bodySizes, len(body))
+		mu.Unlock()
+
+		// One vector per input whose only value is the input length, so ordering can be verified
+		vectors := make([][]float64, len(req.Inputs))
+		for i, input := range req.Inputs {
+			vectors[i] = []float64{float64(len(input))}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(vectors)
+	}))
+	defer server.Close()
+
+	const modelName = "intfloat/multilingual-e5-large-instruct"
+	const maxBytes = 1024
+	texts := make([]string, 10)
+	for i := range texts {
+		texts[i] = strings.Repeat("x", 200+i)
+	}
+	newRequest := func(texts []string) *schemas.BifrostEmbeddingRequest {
+		return &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "hf-inference/" + modelName,
+			Input:    &schemas.EmbeddingInput{Texts: texts},
+		}
+	}
+	newProvider := func(chunk bool) *HuggingFaceProvider {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.huggingFaceConfig.MaxEmbeddingRequestBytes = maxBytes
+		provider.huggingFaceConfig.ChunkOversizedEmbeddingRequests = chunk
+		provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+			hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+		})
+		return provider
+	}
+
+	t.Run("rejected_before_sending", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := newProvider(false).Embedding(ctx, schemas.Key{}, newRequest(texts))
+		require.NotNil(t, bifrostErr)
+		assert.Contains(t, bifrostErr.Error.Error.Error(), "exceeding the 1024 byte limit")
+		mu.Lock()
+		assert.Empty(t, bodySizes)
+		mu.Unlock()
+	})
+
+	t.Run("chunked_to_fit", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		resp, bifrostErr := newProvider(true).Embedding(ctx, schemas.Key{}, newRequest(texts))
+		require.Nil(t, bifrostErr)
+
+		mu.Lock()
+		assert.Greater(t, len(bodySizes), 1)
+		for _, size := range bodySizes {
+			assert.LessOrEqual(t, size, maxBytes)
+		}
+		bodySizes = nil
+		mu.Unlock()
+		require.Len(t, resp.Data, len(texts))
+		for i, text := range texts {
+			assert.Equal(t, i, resp.Data[i].Index)
+			assert.Equal(t, []float64{float64(len(text))}, resp.Data[i].Embedding.EmbeddingArray)
+		}
+	})
+
+	t.Run("single_input_too_large", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := newProvider(true).Embedding(ctx, schemas.Key{}, newRequest([]string{"short", strings.Repeat("y", 2*maxBytes)}))
+		require.NotNil(t, bifrostErr)
+		assert.Contains(t, bifrostErr.Error.Error.Error(), "embedding input 1 alone")
+	})
+
+	t.Run("small_batch_unchanged", func(t *testing.T) {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := newProvider(true).Embedding(ctx, schemas.Key{}, newRequest(texts[:2]))
+		require.Nil(t, bifrostErr)
+		mu.Lock()
+		assert.Len(t, bodySizes, 1)
+		bodySizes = nil
+		mu.Unlock()
+	})
+}
@@ -1,14 +1,70 @@
 package huggingface
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/bytedance/sonic"
+	"github.com/maximhq/bifrost/core/providers/openai"
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
 	"github.com/maximhq/bifrost/core/schemas"
 	"github.com/valyala/fasthttp"
 )
 
+// modelLoadingErrorType marks a 503 from serverless hf-inference while a cold model is being
+// loaded. The status code is kept so the request is retried like any other 503.
+const modelLoadingErrorType = "model_loading"
+
+// gatedModelErrorType marks a 403 for a gated model whose terms the key's account has not
+// accepted on the Hub.
+const gatedModelErrorType = "gated_model"
+
+// modelNotFoundErrorType marks a 404 for a model HF does not serve that was given similar model
+// suggestions (see HuggingFaceConfig.SuggestModelsOnNotFound).
+const modelNotFoundErrorType = "model_not_found"
+
+// maxModelSuggestions caps the similar model IDs named in a model-not-found error.
+const maxModelSuggestions = 3
+
+// defaultModelLoadingWaitSeconds is the cold-start wait used when HF omits estimated_time and
+// HuggingFaceConfig.DefaultModelLoadingWaitSeconds is unset.
+const defaultModelLoadingWaitSeconds = 10.0
+
+// ModelLoadingError is the underlying error of a cold-start 503 (BifrostError.Error.Error).
+// The 503 status is kept so Bifrost retries it; callers doing their own back-off can read the
+// wait HF suggested with ModelLoadingEstimatedTime.
+type ModelLoadingError struct {
+	Message       string
+	EstimatedTime *float64 // Seconds until the model is ready, when HF sent estimated_time
+}
+
+func (e *ModelLoadingError) Error() string {
+	return e.Message
+}
+
+// ModelLoadingEstimatedTime reports whether bifrostErr is a HuggingFace cold-start error and,
+// if HF sent one, how long it estimated the model needs to load.
+func ModelLoadingEstimatedTime(bifrostErr *schemas.BifrostError) (time.Duration, bool) {
+	if bifrostErr == nil || bifrostErr.Error == nil {
+		return 0, false
+	}
+	var loadingErr *ModelLoadingError
+	if !errors.As(bifrostErr.Error.Error, &loadingErr) {
+		return 0, false
+	}
+	if loadingErr.EstimatedTime == nil {
+		return 0, true
+	}
+	return time.Duration(*loadingErr.EstimatedTime * float64(time.Second)), true
+}
+
+// defaultTransientRetryBaseDelay is the first resend delay for 429/503s when
+// HuggingFaceConfig.TransientRetryBaseDelayInMs is unset.
+const defaultTransientRetryBaseDelay = 500 * time.Millisecond
+
 // parseHuggingFaceImageError parses HuggingFace error responses
 func parseHuggingFaceImageError(resp *fasthttp.Response) *schemas.BifrostError {
 	var errorResp HuggingFaceResponseError
@@ -53,5 +109,164 @@ func parseHuggingFaceImageError(resp *fasthttp.Response) *schemas.BifrostError {
 		bifrostErr.Error.Message = errorResp.Error
 	}
 
+	if isModelLoadingResponse(resp.StatusCode(), &errorResp) {
+		bifrostErr.Type = schemas.Ptr(modelLoadingErrorType)
+		bifrostErr.Error.Type = schemas.Ptr(modelLoadingErrorType)
+		bifrostErr.Error.Error = &ModelLoadingError{Message: bifrostErr.Error.Message, EstimatedTime: errorResp.EstimatedTime}
+		if errorResp.EstimatedTime != nil {
+			bifrostErr.Error.Message = fmt.Sprintf("%s (estimated time until ready: %.0fs)", bifrostErr.Error.Message, *errorResp.EstimatedTime)
+		}
+	}
+
+	return withErrorRateLimit(bifrostErr, resp)
+}
+
+// parseHuggingFaceChatError is the error converter for the OpenAI-compatible chat routes: the
+// cold-start 503 is tagged like on the other routes, anything else is parsed as an OpenAI error.
+func parseHuggingFaceChatError(resp *fasthttp.Response) *schemas.BifrostError {
+	if _, loading := decodeModelLoadingResponse(resp); loading {
+		return parseHuggingFaceImageError(resp)
+	}
+	return withErrorRateLimit(openai.ParseOpenAIError(resp), resp)
+}
+
+// isModelLoadingResponse reports whether an error response is hf-inference's cold-start 503.
+// HF usually includes estimated_time, but some loading responses only say so in the message.
+func isModelLoadingResponse(statusCode int, errorResp *HuggingFaceResponseError) bool {
+	if statusCode != fasthttp.StatusServiceUnavailable {
+		return false
+	}
+	return errorResp.EstimatedTime != nil || strings.Contains(strings.ToLower(errorResp.Error), "is currently loading")
+}
+
+// decodeModelLoadingResponse returns the decoded error body when resp is a cold-start 503.
+func decodeModelLoadingResponse(resp *fasthttp.Response) (*HuggingFaceResponseError, bool) {
+	if resp.StatusCode() != fasthttp.StatusServiceUnavailable {
+		return nil, false
+	}
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil, false
+	}
+	var errorResp HuggingFaceResponseError
+	if err := sonic.Unmarshal(body, &errorResp); err != nil || !isModelLoadingResponse(resp.StatusCode(), &errorResp) {
+		return nil, false
+	}
+	return &errorResp, true
+}
+
+// modelLoadingWait returns how long to wait before resending a request that got a cold-start
+// 503: HF's estimated_time when present, otherwise the configured default. Returns false for
+// any other response.
+func (provider *HuggingFaceProvider) modelLoadingWait(resp *fasthttp.Response) (time.Duration, bool) {
+	errorResp, loading := decodeModelLoadingResponse(resp)
+	if !loading {
+		return 0, false
+	}
+
+	seconds := defaultModelLoadingWaitSeconds
+	if errorResp.EstimatedTime != nil {
+		seconds = *errorResp.EstimatedTime
+	} else if configured := provider.huggingFaceConfig.DefaultModelLoadingWaitSeconds; configured > 0 {
+		seconds = configured
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// transientErrorWait returns how long to wait before the given resend (0-based) of a request
+// that got a 429 or a non-cold-start 503: the Retry-After header when HF sent one,
+// otherwise the base delay doubled per previous resend. Returns false for any other response,
+// so client errors such as 400 are never resent.
+func (provider *HuggingFaceProvider) transientErrorWait(resp *fasthttp.Response, retry int) (time.Duration, bool) {
+	if statusCode := resp.StatusCode(); statusCode != fasthttp.StatusTooManyRequests && statusCode != fasthttp.StatusServiceUnavailable {
+		return 0, false
+	}
+	if retryAfter := parseRetryAfter(string(resp.Header.Peek("Retry-After"))); retryAfter != nil {
+		return time.Duration(*retryAfter) * time.Second, true
+	}
+
+	delay := defaultTransientRetryBaseDelay
+	if configured := provider.huggingFaceConfig.TransientRetryBaseDelayInMs; configured > 0 {
+		delay = time.Duration(configured) * time.Millisecond
+	}
+	return delay << min(retry, 16), true
+}
+
+// parseHuggingFaceInlineError detects the TGI error object ({"error": "...", "error_type": "..."})
+// that can arrive with a 200 status or mid-stream, e.g. when generation runs out of memory.
+// Returns nil when the payload is not such an error.
+func parseHuggingFaceInlineError(data []byte) *schemas.BifrostError {
+	if errorNode, _ := sonic.Get(data, "error"); !errorNode.Exists() {
+		return nil
+	}
+
+	var errorResp HuggingFaceResponseError
+	if err := sonic.Unmarshal(data, &errorResp); err != nil || strings.TrimSpace(errorResp.Error) == "" {
+		return nil
+	}
+
+	bifrostErr := &schemas.BifrostError{
+		IsBifrostError: false,
+		Error: &schemas.ErrorField{
+			Message: errorResp.Error,
+		},
+	}
+	if errorResp.ErrorType != "" {
+		bifrostErr.Error.Type = schemas.Ptr(errorResp.ErrorType)
+	}
+	return bifrostErr
+}
+
+// streamChunkHandler decodes chat stream chunks for the shared OpenAI-compatible handler, which
+// ends the stream on any error it is handed. Only a TGI inline error event ends it; a chunk that
+// does not decode is logged and left empty, so the handler skips it as it does for other providers.
+func (provider *HuggingFaceProvider) streamChunkHandler(ctx context.Context) func([]byte, *schemas.BifrostChatResponse, []byte, bool, bool) (interface{}, interface{}, *schemas.BifrostError) {
+	return func(responseBody []byte, response *schemas.BifrostChatResponse, requestBody []byte, sendBackRawRequest bool, sendBackRawResponse bool) (interface{}, interface{}, *schemas.BifrostError) {
+		if inlineErr := parseHuggingFaceInlineError(responseBody); inlineErr != nil {
+			var rawResponse interface{}
+			if sendBackRawResponse {
+				rawResponse = string(responseBody)
+			}
+			return nil, rawResponse, inlineErr
+		}
+		if err := sonic.Unmarshal(responseBody, response); err != nil {
+			provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: skipping stream chunk that failed to parse: %v", err), nil))
+			*response = schemas.BifrostChatResponse{}
+		}
+		return nil, nil, nil
+	}
+}
+
+// explainGatedModelError rewrites the 403 HF answers for a gated model the key's account has not
+// been granted, whose bare "access is restricted" message is easily mistaken for a bad key, to
+// point at the model page where its terms are accepted. Other errors are returned unchanged.
+func explainGatedModelError(bifrostErr *schemas.BifrostError, modelName string) *schemas.BifrostError {
+	if bifrostErr == nil || bifrostErr.Error == nil || bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != fasthttp.StatusForbidden {
+		return bifrostErr
+	}
+	message := strings.ToLower(bifrostErr.Error.Message)
+	if !strings.Contains(message, "gated") && !strings.Contains(message, "restricted") && !strings.Contains(message, "authorized list") {
+		return bifrostErr
+	}
+	bifrostErr.Type = schemas.Ptr(gatedModelErrorType)
+	bifrostErr.Error.Message = fmt.Sprintf("model %s is gated: accept its terms at %s/%s while signed in to the account that owns this key, then retry (%s)", modelName, modelHubBaseURL, modelName, bifrostErr.Error.Message)
+	return bifrostErr
+}
+
+// explainModelNotFoundError names suggestions, the Hub models most like modelName, in the 404 HF
+// answered for it. Without suggestions, or for other errors, bifrostErr is returned unchanged.
+func explainModelNotFoundError(bifrostErr *schemas.BifrostError, modelName string, suggestions []string) *schemas.BifrostError {
+	if len(suggestions) == 0 || bifrostErr == nil || bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != fasthttp.StatusNotFound {
+		return bifrostErr
+	}
+	if bifrostErr.Error == nil {
+		bifrostErr.Error = &schemas.ErrorField{}
+	}
+	message := fmt.Sprintf("model %s was not found; did you mean %s?", modelName, strings.Join(suggestions, ", "))
+	if bifrostErr.Error.Message != "" {
+		message = fmt.Sprintf("%s (%s)", message, bifrostErr.Error.Message)
+	}
+	bifrostErr.Type = schemas.Ptr(modelNotFoundErrorType)
+	bifrostErr.Error.Message = message
 	return bifrostErr
 }
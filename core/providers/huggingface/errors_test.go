@@ -0,0 +1,86 @@
+package huggingface
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestHuggingFaceResponseError_ErrorShapes(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantError   string
+		wantMessage string
+	}{
+		{"string", `{"error":"Model is overloaded"}`, "Model is overloaded", ""},
+		{"array", `{"error":["Input validation error: inputs must not be empty","max_new_tokens must be at most 2048"]}`, "Input validation error: inputs must not be empty; max_new_tokens must be at most 2048", ""},
+		{"object", `{"error":{"message":"Invalid token","type":"authentication_error"}}`, "Invalid token", ""},
+		{"object without message", `{"error":{"code": 42}}`, `{"code":42}`, ""},
+		{"detail string", `{"detail":"Not Found"}`, "", "Not Found"},
+		{"detail object", `{"detail":{"error":"Rate limit reached"}}`, "", "Rate limit reached"},
+		{"null", `{"error":null,"message":"Bad request"}`, "", "Bad request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errorResp HuggingFaceResponseError
+			require.NoError(t, sonic.Unmarshal([]byte(tt.body), &errorResp))
+			assert.Equal(t, tt.wantError, errorResp.Error)
+			assert.Equal(t, tt.wantMessage, errorResp.Message)
+		})
+	}
+
+	t.Run("validation details kept", func(t *testing.T) {
+		var errorResp HuggingFaceResponseError
+		require.NoError(t, sonic.Unmarshal([]byte(`{"detail":[{"loc":["body","inputs"],"msg":"field required","type":"value_error.missing"}],"estimated_time":3.5}`), &errorResp))
+		require.Len(t, errorResp.Detail, 1)
+		assert.Equal(t, "field required", errorResp.Detail[0].Msg)
+		assert.Empty(t, errorResp.Message)
+		require.NotNil(t, errorResp.EstimatedTime)
+		assert.Equal(t, 3.5, *errorResp.EstimatedTime)
+	})
+}
+
+func TestHuggingFaceHubError_ErrorShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want HuggingFaceHubError
+	}{
+		{"string", `{"error":"Repository not found"}`, HuggingFaceHubError{Error: "Repository not found"}},
+		{"array", `{"error":["Invalid username or password.","Token expired"]}`, HuggingFaceHubError{Error: "Invalid username or password.; Token expired"}},
+		{"object", `{"error":{"message":"Access denied"}}`, HuggingFaceHubError{Error: "Access denied"}},
+		{"detail", `{"detail":"Not Found"}`, HuggingFaceHubError{Message: "Not Found"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hubErr HuggingFaceHubError
+			require.NoError(t, sonic.Unmarshal([]byte(tt.body), &hubErr))
+			assert.Equal(t, tt.want, hubErr)
+		})
+	}
+}
+
+func TestParseHuggingFaceImageError_ErrorShapes(t *testing.T) {
+	for body, want := range map[string]string{
+		`{"error":"Model too busy"}`:                   "Model too busy",
+		`{"error":["first problem","second problem"]}`: "first problem; second problem",
+		`{"error":{"message":"Nested problem"}}`:       "Nested problem",
+		`{"detail":"Not Found"}`:                       "Not Found",
+	} {
+		resp := fasthttp.AcquireResponse()
+		resp.SetStatusCode(http.StatusBadRequest)
+		resp.Header.SetContentType("application/json")
+		resp.SetBodyString(body)
+
+		bifrostErr := parseHuggingFaceImageError(resp)
+		fasthttp.ReleaseResponse(resp)
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.Error)
+		assert.Equal(t, want, bifrostErr.Error.Message, body)
+	}
+}
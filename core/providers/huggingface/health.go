@@ -0,0 +1,110 @@
+package huggingface
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// HealthStatus classifies a HealthCheck outcome so a readiness probe can tell a bad key
+// from an upstream outage.
+type HealthStatus string
+
+const (
+	HealthStatusOK           HealthStatus = "ok"           // the Hub answered and accepted the key
+	HealthStatusUnauthorized HealthStatus = "unauthorized" // the key is missing, invalid or revoked
+	HealthStatusUnreachable  HealthStatus = "unreachable"  // the Hub could not be reached or failed to answer
+)
+
+// HealthCheckResult is the structured outcome of a HealthCheck.
+type HealthCheckResult struct {
+	Status     HealthStatus `json:"status"`
+	StatusCode int          `json:"status_code,omitempty"` // the Hub's HTTP status, when it answered
+	Account    string       `json:"account,omitempty"`     // the user or org name the key belongs to
+	Latency    int64        `json:"latency_ms"`
+	Message    string       `json:"message,omitempty"`
+}
+
+// hubWhoAmIResponse is the part of /api/whoami-v2 the health check reads.
+type hubWhoAmIResponse struct {
+	Name string `json:"name"`
+}
+
+// HealthCheck verifies connectivity to the Hub and the key's validity with a GET on
+// /api/whoami-v2, without running any inference. It never returns an error; failures are
+// reported through the result's Status.
+func (provider *HuggingFaceProvider) HealthCheck(ctx *schemas.BifrostContext, key schemas.Key) *HealthCheckResult {
+	return provider.checkHealth(ctx, key, modelHubBaseURL+"/api/whoami-v2")
+}
+
+// checkHealth runs the health check against whoAmIURL.
+func (provider *HuggingFaceProvider) checkHealth(ctx *schemas.BifrostContext, key schemas.Key, whoAmIURL string) *HealthCheckResult {
+	authHeader := bearerAuthHeader(key.Value.GetValue())
+	if authHeader == "" {
+		return &HealthCheckResult{Status: HealthStatusUnauthorized, Message: "no API key configured"}
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
+	req.SetRequestURI(whoAmIURL)
+	req.Header.SetMethod(http.MethodGet)
+	req.Header.Set("Authorization", authHeader)
+
+	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).client, req, resp)
+	defer wait()
+	result := &HealthCheckResult{Latency: latency.Milliseconds()}
+	if bifrostErr != nil {
+		result.Status = HealthStatusUnreachable
+		if bifrostErr.Error != nil {
+			result.Message = bifrostErr.Error.Message
+		}
+		return result
+	}
+
+	result.StatusCode = resp.StatusCode()
+	switch {
+	case resp.StatusCode() == fasthttp.StatusOK:
+		result.Status = HealthStatusOK
+		if body, err := providerUtils.CheckAndDecodeBody(resp); err == nil {
+			var whoAmI hubWhoAmIResponse
+			if sonic.Unmarshal(body, &whoAmI) == nil {
+				result.Account = whoAmI.Name
+			}
+		}
+	case resp.StatusCode() == fasthttp.StatusUnauthorized || resp.StatusCode() == fasthttp.StatusForbidden:
+		result.Status = HealthStatusUnauthorized
+		result.Message = hubErrorMessage(resp)
+	default:
+		result.Status = HealthStatusUnreachable
+		result.Message = hubErrorMessage(resp)
+	}
+	return result
+}
+
+// hubErrorMessage extracts the Hub's error message from a failed response, falling back to
+// the HTTP status.
+func hubErrorMessage(resp *fasthttp.Response) string {
+	if body, err := providerUtils.CheckAndDecodeBody(resp); err == nil {
+		var hubErr HuggingFaceHubError
+		if sonic.Unmarshal(body, &hubErr) == nil {
+			if message := strings.TrimSpace(hubErr.Message); message != "" {
+				return message
+			}
+			if message := strings.TrimSpace(hubErr.Error); message != "" {
+				return message
+			}
+		}
+	}
+	return fmt.Sprintf("hub returned status %d", resp.StatusCode())
+}
@@ -0,0 +1,75 @@
+package huggingface
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHealth(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Header.Get("Authorization") {
+		case "Bearer hf_valid":
+			_, _ = io.WriteString(w, `{"type":"user","name":"octocat","auth":{"type":"access_token"}}`)
+		case "Bearer hf_outage":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, `{"error":"Service Unavailable"}`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error":"Invalid credentials in Authorization header"}`)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	whoAmIURL := server.URL + "/api/whoami-v2"
+
+	t.Run("ok", func(t *testing.T) {
+		result := provider.checkHealth(ctx, schemas.Key{Value: *schemas.NewEnvVar("hf_valid")}, whoAmIURL)
+		require.NotNil(t, result)
+		assert.Equal(t, HealthStatusOK, result.Status)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "octocat", result.Account)
+	})
+
+	t.Run("invalid_key", func(t *testing.T) {
+		result := provider.checkHealth(ctx, schemas.Key{Value: *schemas.NewEnvVar("hf_revoked")}, whoAmIURL)
+		assert.Equal(t, HealthStatusUnauthorized, result.Status)
+		assert.Equal(t, http.StatusUnauthorized, result.StatusCode)
+		assert.Equal(t, "Invalid credentials in Authorization header", result.Message)
+	})
+
+	t.Run("missing_key_skips_request", func(t *testing.T) {
+		result := provider.checkHealth(ctx, schemas.Key{}, whoAmIURL)
+		assert.Equal(t, HealthStatusUnauthorized, result.Status)
+		assert.Zero(t, result.StatusCode)
+	})
+
+	t.Run("upstream_error", func(t *testing.T) {
+		result := provider.checkHealth(ctx, schemas.Key{Value: *schemas.NewEnvVar("hf_outage")}, whoAmIURL)
+		assert.Equal(t, HealthStatusUnreachable, result.Status)
+		assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+		assert.Equal(t, "Service Unavailable", result.Message)
+	})
+
+	t.Run("connection_refused", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closedURL := closed.URL
+		closed.Close()
+
+		result := provider.checkHealth(ctx, schemas.Key{Value: *schemas.NewEnvVar("hf_valid")}, closedURL+"/api/whoami-v2")
+		assert.Equal(t, HealthStatusUnreachable, result.Status)
+		assert.Zero(t, result.StatusCode)
+		assert.NotEmpty(t, result.Message)
+	})
+}
@@ -2,11 +2,14 @@ package huggingface
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -18,16 +21,35 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// RawModelListContextKey, when set to true, makes ListModels attach the Hub's model entries
+// verbatim to ExtraFields.RawResponse["hub_models"], keyed by inference provider, alongside the
+// parsed model list. With list_models_from_router, the router's entries are attached as a list
+// under ExtraFields.RawResponse["router_models"] instead.
+const RawModelListContextKey schemas.BifrostContextKey = "huggingface-raw-model-list"
+
 // HuggingFaceProvider implements the Provider interface for Hugging Face's inference APIs.
 type HuggingFaceProvider struct {
 	logger                    schemas.Logger
 	client                    *fasthttp.Client // unary API requests (ReadTimeout bounds overall response)
 	streamingClient           *fasthttp.Client // streaming API requests (no ReadTimeout; idle governed by NewIdleTimeoutReader)
+	tokenizerClient           *fasthttp.Client // tokenizer.json downloads (response size capped)
 	networkConfig             schemas.NetworkConfig
 	sendBackRawResponse       bool
 	sendBackRawRequest        bool
 	customProviderConfig      *schemas.CustomProviderConfig
+	huggingFaceConfig         schemas.HuggingFaceConfig
 	modelProviderMappingCache *sync.Map
+	tokenizerCache            *sync.Map // model name -> tokenizerCacheEntry
+	keyProxyClients           *sync.Map // proxy settings fingerprint -> *huggingFaceClients, for keys with their own proxy
+	concurrencySlots          *sync.Map // "{deployment}#{limit}" -> chan struct{}, for models with a max concurrency
+	modelReadiness            *sync.Map // deployment -> modelReadinessObservation
+}
+
+// huggingFaceClients is the set of clients sharing one proxy configuration.
+type huggingFaceClients struct {
+	client          *fasthttp.Client
+	streamingClient *fasthttp.Client
+	tokenizerClient *fasthttp.Client // the unary client with its response size capped at maxTokenizerBytes
 }
 
 var huggingFaceTranscriptionResponsePool = sync.Pool{
@@ -70,15 +92,9 @@ func releaseHuggingFaceSpeechResponse(resp *HuggingFaceSpeechResponse) {
 func NewHuggingFaceProvider(config *schemas.ProviderConfig, logger schemas.Logger) *HuggingFaceProvider {
 	config.CheckAndSetDefaults()
 
-	requestTimeout := time.Second * time.Duration(config.NetworkConfig.DefaultRequestTimeoutInSeconds)
-	client := &fasthttp.Client{
-		ReadTimeout:         requestTimeout,
-		WriteTimeout:        requestTimeout,
-		MaxConnsPerHost:     config.NetworkConfig.MaxConnsPerHost,
-		MaxIdleConnDuration: 30 * time.Second,
-		MaxConnWaitTimeout:  requestTimeout,
-		MaxConnDuration:     time.Second * time.Duration(schemas.DefaultMaxConnDurationInSeconds),
-		ConnPoolStrategy:    fasthttp.FIFO,
+	var huggingFaceConfig schemas.HuggingFaceConfig
+	if config.HuggingFaceConfig != nil {
+		huggingFaceConfig = *config.HuggingFaceConfig
 	}
 
 	// Pre-warm response pools
@@ -87,27 +103,79 @@ func NewHuggingFaceProvider(config *schemas.ProviderConfig, logger schemas.Logge
 		huggingFaceTranscriptionResponsePool.Put(&HuggingFaceTranscriptionResponse{})
 	}
 
-	client = providerUtils.ConfigureProxy(client, config.ProxyConfig, logger)
-	client = providerUtils.ConfigureDialer(client)
-	client = providerUtils.ConfigureTLS(client, config.NetworkConfig, logger)
-	streamingClient := providerUtils.BuildStreamingClient(client)
+	clients := newHuggingFaceClients(config.NetworkConfig, huggingFaceConfig, config.ProxyConfig, logger)
 	if config.NetworkConfig.BaseURL == "" {
 		config.NetworkConfig.BaseURL = defaultInferenceBaseURL
 	}
-	config.NetworkConfig.BaseURL = strings.TrimRight(config.NetworkConfig.BaseURL, "/")
+	config.NetworkConfig.BaseURL = normalizeBaseURL(config.NetworkConfig.BaseURL)
 
 	return &HuggingFaceProvider{
 		logger:                    logger,
-		client:                    client,
-		streamingClient:           streamingClient,
+		client:                    clients.client,
+		streamingClient:           clients.streamingClient,
+		tokenizerClient:           clients.tokenizerClient,
 		networkConfig:             config.NetworkConfig,
 		sendBackRawResponse:       config.SendBackRawResponse,
 		sendBackRawRequest:        config.SendBackRawRequest,
 		customProviderConfig:      config.CustomProviderConfig,
+		huggingFaceConfig:         huggingFaceConfig,
 		modelProviderMappingCache: &sync.Map{},
+		tokenizerCache:            &sync.Map{},
+		keyProxyClients:           &sync.Map{},
+		concurrencySlots:          &sync.Map{},
+		modelReadiness:            &sync.Map{},
+	}
+}
+
+// newHuggingFaceClients builds the unary and streaming clients for one proxy configuration.
+func newHuggingFaceClients(networkConfig schemas.NetworkConfig, huggingFaceConfig schemas.HuggingFaceConfig, proxyConfig *schemas.ProxyConfig, logger schemas.Logger) *huggingFaceClients {
+	requestTimeout := time.Second * time.Duration(networkConfig.DefaultRequestTimeoutInSeconds)
+	client := &fasthttp.Client{
+		ReadTimeout:         requestTimeout,
+		WriteTimeout:        requestTimeout,
+		MaxConnsPerHost:     networkConfig.MaxConnsPerHost,
+		MaxIdleConnDuration: 30 * time.Second,
+		MaxConnWaitTimeout:  requestTimeout,
+		MaxConnDuration:     time.Second * time.Duration(schemas.DefaultMaxConnDurationInSeconds),
+		ConnPoolStrategy:    fasthttp.FIFO,
+		Name:                userAgent(huggingFaceConfig), // sent as User-Agent unless a request sets its own
+	}
+	if huggingFaceConfig.MaxIdleConnDurationInSeconds > 0 {
+		client.MaxIdleConnDuration = time.Second * time.Duration(huggingFaceConfig.MaxIdleConnDurationInSeconds)
+	}
+	if huggingFaceConfig.MaxConnDurationInSeconds > 0 {
+		client.MaxConnDuration = time.Second * time.Duration(huggingFaceConfig.MaxConnDurationInSeconds)
+	}
+
+	client = providerUtils.ConfigureProxy(client, proxyConfig, logger)
+	client = providerUtils.ConfigureDialer(client)
+	client = providerUtils.ConfigureTLS(client, networkConfig, logger)
+	tokenizerClient := providerUtils.CloneFastHTTPClientConfig(client)
+	tokenizerClient.MaxResponseBodySize = maxTokenizerBytes
+	return &huggingFaceClients{
+		client:          client,
+		streamingClient: providerUtils.BuildStreamingClient(client),
+		tokenizerClient: tokenizerClient,
 	}
 }
 
+// clientsForKey returns the clients for requests made with key: a pooled pair for the key's
+// own proxy configuration when it has one, the provider-level pair otherwise. Keys with equal
+// proxy settings share one pair. Keyless Hub lookups (inference provider mappings) always use
+// the provider-level clients.
+func (provider *HuggingFaceProvider) clientsForKey(key schemas.Key) *huggingFaceClients {
+	if key.HuggingFaceKeyConfig == nil || key.HuggingFaceKeyConfig.ProxyConfig == nil || provider.keyProxyClients == nil {
+		return &huggingFaceClients{client: provider.client, streamingClient: provider.streamingClient, tokenizerClient: provider.tokenizerClient}
+	}
+	proxyConfig := key.HuggingFaceKeyConfig.ProxyConfig
+	fingerprint := proxyConfigFingerprint(proxyConfig)
+	if clients, ok := provider.keyProxyClients.Load(fingerprint); ok {
+		return clients.(*huggingFaceClients)
+	}
+	clients, _ := provider.keyProxyClients.LoadOrStore(fingerprint, newHuggingFaceClients(provider.networkConfig, provider.huggingFaceConfig, proxyConfig, provider.logger))
+	return clients.(*huggingFaceClients)
+}
+
 // GetProviderKey returns the provider key, taking custom providers into account.
 func (provider *HuggingFaceProvider) GetProviderKey() schemas.ModelProvider {
 	return providerUtils.GetProviderName(schemas.HuggingFace, provider.customProviderConfig)
@@ -119,14 +187,29 @@ func (provider *HuggingFaceProvider) buildRequestURL(ctx *schemas.BifrostContext
 	if isCompleteURL {
 		return path
 	}
+	// A dedicated endpoint serves a single model, so the router's per-provider paths don't apply
+	if isDedicatedEndpointURL(provider.networkConfig.BaseURL) {
+		return dedicatedEndpointRequestURL(provider.networkConfig.BaseURL, requestType)
+	}
 	return provider.networkConfig.BaseURL + path
 }
 
+// defaultJSONContentType carries the charset because some strict endpoints reject bare application/json.
+const defaultJSONContentType = "application/json; charset=utf-8"
+
+// jsonContentType returns the Content-Type sent with JSON request bodies.
+func (provider *HuggingFaceProvider) jsonContentType() string {
+	if provider.huggingFaceConfig.JSONContentType != "" {
+		return provider.huggingFaceConfig.JSONContentType
+	}
+	return defaultJSONContentType
+}
+
 // completeRequestWithModelAliasCache performs a request and retries once on 404 by clearing the cache and refetching model info
 func (provider *HuggingFaceProvider) completeRequestWithModelAliasCache(
 	ctx *schemas.BifrostContext,
 	jsonData []byte,
-	key string,
+	key schemas.Key,
 	isHFInferenceAudioRequest bool,
 	isHFInferenceImageRequest bool,
 	inferenceProvider inferenceProvider,
@@ -135,12 +218,6 @@ func (provider *HuggingFaceProvider) completeRequestWithModelAliasCache(
 	requestType schemas.RequestType,
 ) ([]byte, time.Duration, map[string]string, *schemas.BifrostError) {
 
-	// Build URL with original model name
-	url, urlErr := provider.getInferenceProviderRouteURL(ctx, inferenceProvider, originalModelName, requestType)
-	if urlErr != nil {
-		return nil, 0, nil, providerUtils.NewUnsupportedOperationError(requestType, provider.GetProviderKey())
-	}
-
 	// For fal-ai, nebius, and together image generation, skip validation (model format is already correct)
 	skipValidation := (inferenceProvider == falAI || inferenceProvider == nebius || inferenceProvider == together) && requestType == schemas.ImageGenerationRequest
 	var modelName string
@@ -155,6 +232,13 @@ func (provider *HuggingFaceProvider) completeRequestWithModelAliasCache(
 		}
 	}
 
+	// Build the URL from the same model ID that goes in the body, so routes that read the model
+	// from both places never see two different names
+	url, urlErr := provider.getInferenceProviderRouteURL(ctx, inferenceProvider, modelName, requestType, requiredTask)
+	if urlErr != nil {
+		return nil, 0, nil, providerUtils.NewUnsupportedOperationError(requestType, provider.GetProviderKey())
+	}
+
 	// Update the model field in the JSON body if it's not an audio request
 	updatedJSONData := jsonData
 	// Skip body modification for fal-ai, nebius, and together image generation - they have special requirements
@@ -169,7 +253,8 @@ func (provider *HuggingFaceProvider) completeRequestWithModelAliasCache(
 	}
 
 	// Make the request
-	responseBody, latency, providerResponseHeaders, err := provider.completeRequest(ctx, updatedJSONData, url, key, isHFInferenceAudioRequest, isHFInferenceImageRequest)
+	deployment := modelDeployment("", inferenceProvider, originalModelName)
+	responseBody, latency, providerResponseHeaders, err := provider.completeRequest(ctx, updatedJSONData, url, deployment, key, isHFInferenceAudioRequest, isHFInferenceImageRequest, requestType == schemas.EmbeddingRequest)
 	if err != nil {
 		// If we got a 404, clear cache and retry once
 		if err.StatusCode != nil && *err.StatusCode == 404 {
@@ -196,18 +281,14 @@ func (provider *HuggingFaceProvider) completeRequestWithModelAliasCache(
 				}
 			}
 
-			// Rebuild URL with new model name (use original for fal-ai, nebius, and together since validation is skipped)
-			retryModelName := originalModelName
-			if !skipValidation {
-				retryModelName = modelName
-			}
-			url, urlErr = provider.getInferenceProviderRouteURL(ctx, inferenceProvider, retryModelName, requestType)
+			// Rebuild URL with the new model name, keeping path and body in agreement
+			url, urlErr = provider.getInferenceProviderRouteURL(ctx, inferenceProvider, modelName, requestType, requiredTask)
 			if urlErr != nil {
 				return nil, 0, nil, providerUtils.NewUnsupportedOperationError(requestType, provider.GetProviderKey())
 			}
 
 			// Retry the request
-			responseBody, latency, providerResponseHeaders, err = provider.completeRequest(ctx, updatedJSONData, url, key, isHFInferenceAudioRequest, isHFInferenceImageRequest)
+			responseBody, latency, providerResponseHeaders, err = provider.completeRequest(ctx, updatedJSONData, url, deployment, key, isHFInferenceAudioRequest, isHFInferenceImageRequest, requestType == schemas.EmbeddingRequest)
 			if err != nil {
 				return nil, 0, nil, err
 			}
@@ -219,7 +300,11 @@ func (provider *HuggingFaceProvider) completeRequestWithModelAliasCache(
 	return responseBody, latency, providerResponseHeaders, nil
 }
 
-func (provider *HuggingFaceProvider) completeRequest(ctx *schemas.BifrostContext, jsonData []byte, url string, key string, isHFInferenceAudioRequest bool, _ bool) ([]byte, time.Duration, map[string]string, *schemas.BifrostError) {
+// completeRequest sends the body to url, resending on cold starts and transient errors as
+// configured. compressible marks bodies the endpoint accepts gzip-encoded (embedding batches),
+// which are compressed once they reach GzipEmbeddingRequestMinBytes. The final response is
+// recorded as deployment's readiness (see modelDeployment), unless deployment is "".
+func (provider *HuggingFaceProvider) completeRequest(ctx *schemas.BifrostContext, jsonData []byte, url string, deployment string, key schemas.Key, isHFInferenceAudioRequest bool, _ bool, compressible bool) ([]byte, time.Duration, map[string]string, *schemas.BifrostError) {
 	req := fasthttp.AcquireRequest()
 	resp := fasthttp.AcquireResponse()
 	defer fasthttp.ReleaseRequest(req)
@@ -227,6 +312,7 @@ func (provider *HuggingFaceProvider) completeRequest(ctx *schemas.BifrostContext
 
 	// Set any extra headers from network config
 	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
 
 	req.SetRequestURI(url)
 	req.Header.SetMethod(http.MethodPost)
@@ -236,27 +322,75 @@ func (provider *HuggingFaceProvider) completeRequest(ctx *schemas.BifrostContext
 		mimeType := getMimeTypeForAudioType(audioType)
 		req.Header.Set("Content-Type", mimeType)
 	} else {
-		req.Header.SetContentType("application/json")
+		req.Header.SetContentType(provider.jsonContentType())
 	}
-	if key != "" {
-		req.Header.Set("Authorization", "Bearer "+key)
+	if authHeader := bearerAuthHeader(key.Value.GetValue()); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
 	}
 
+	// A streamed large-payload body is consumed by the first send, so it cannot be resent
+	modelLoadingRetries, transientErrorRetries := 0, 0
 	if !providerUtils.ApplyLargePayloadRequestBodyWithModelNormalization(ctx, req, schemas.HuggingFace) {
-		req.SetBody(jsonData)
-	}
+		if minBytes := provider.huggingFaceConfig.GzipEmbeddingRequestMinBytes; compressible && minBytes > 0 && len(jsonData) >= minBytes {
+			req.Header.Set("Content-Encoding", "gzip")
+			req.SetBody(fasthttp.AppendGzipBytes(nil, jsonData))
+		} else {
+			req.SetBody(jsonData)
+		}
+		modelLoadingRetries = provider.huggingFaceConfig.ModelLoadingRetries
+		transientErrorRetries = provider.huggingFaceConfig.TransientErrorRetries
+	}
+
+	client := provider.clientsForKey(key).client
+	var latency time.Duration
+	// Resend on cold starts and transient 429/503s, each up to its own configured retries
+sendLoop:
+	for loadingAttempt, transientAttempt := 0, 0; ; {
+		attemptLatency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, client, req, resp)
+		latency += attemptLatency
+		if bifrostErr != nil {
+			wait()
+			return nil, latency, nil, bifrostErr
+		}
 
-	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.client, req, resp)
-	defer wait()
-	if bifrostErr != nil {
-		return nil, latency, nil, bifrostErr
+		var retryWait time.Duration
+		if loadingWait, loading := provider.modelLoadingWait(resp); loading {
+			if loadingAttempt >= modelLoadingRetries {
+				break
+			}
+			loadingAttempt++
+			retryWait = loadingWait
+			provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: model at %s is loading, resending in %s", url, retryWait), resp))
+		} else if transientWait, transient := provider.transientErrorWait(resp, transientAttempt); transient {
+			if transientAttempt >= transientErrorRetries {
+				break
+			}
+			transientAttempt++
+			retryWait = transientWait
+			provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: %s answered %d, resending in %s", url, resp.StatusCode(), retryWait), resp))
+		} else {
+			break
+		}
+
+		// Return the error now rather than sleeping into a deadline the resend could not meet
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < retryWait {
+			break
+		}
+		select {
+		case <-time.After(retryWait):
+		case <-ctx.Done():
+			break sendLoop
+		}
+		resp.Reset()
 	}
 
 	// Extract provider response headers before status check so error responses also forward them
 	providerResponseHeaders := providerUtils.ExtractProviderResponseHeaders(resp)
+	provider.recordModelReadiness(deployment, resp.StatusCode(), providerResponseHeaders, resp.Body())
 
 	// Handle error response
 	if resp.StatusCode() != fasthttp.StatusOK {
+		provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: %s answered %d", url, resp.StatusCode()), resp))
 		return nil, latency, providerResponseHeaders, parseHuggingFaceImageError(resp)
 	}
 
@@ -280,85 +414,94 @@ func (provider *HuggingFaceProvider) listModelsByKey(ctx *schemas.BifrostContext
 		response *HuggingFaceListModelsResponse
 		latency  int64
 		rawResp  map[string]interface{}
+		rawHub   []json.RawMessage
 		err      *schemas.BifrostError
 	}
 
-	resultsChan := make(chan providerResult, len(INFERENCE_PROVIDERS))
+	captureRawHub, _ := ctx.Value(RawModelListContextKey).(bool)
+	gatedMode, gatedModeErr := parseGatedModelMode(request.ExtraParams)
+	if gatedModeErr != nil {
+		return nil, providerUtils.NewBifrostOperationError("invalid list models request", gatedModeErr)
+	}
+
+	if provider.huggingFaceConfig.ListModelsFromRouter {
+		// The router does not say which models are gated, so there is nothing to check access for
+		if gatedMode != GatedModelModeOff {
+			return nil, providerUtils.NewBifrostOperationError("invalid list models request", fmt.Errorf("gated_model_mode %q is not supported with list_models_from_router, which does not report gating", gatedMode))
+		}
+		response, bifrostErr := provider.listRouterModels(ctx, key, request, provider.buildRequestURL(ctx, "/v1/models", schemas.ListModelsRequest), captureRawHub)
+		if bifrostErr != nil {
+			return nil, bifrostErr
+		}
+		if mode, ok := request.ExtraParams["duplicate_model_mode"].(string); ok && DuplicateModelMode(mode) == DuplicateModelModeCollapse {
+			response.Data = collapseDuplicateModels(response.Data, providerName)
+		}
+		truncateModelDescriptions(response.Data, provider.huggingFaceConfig.MaxModelDescriptionLength)
+		return response, nil
+	}
+
+	// A key that prefers a backend only lists the models that backend serves
+	inferenceProviders := INFERENCE_PROVIDERS
+	if preferred := keyInferenceProvider(key); preferred != "" {
+		inferenceProviders = []inferenceProvider{preferred}
+	}
+
+	resultsChan := make(chan providerResult, len(inferenceProviders))
 	var wg sync.WaitGroup
 
-	for _, infProvider := range INFERENCE_PROVIDERS {
+	for _, infProvider := range inferenceProviders {
 		wg.Add(1)
 		go func(inferProvider inferenceProvider) {
 			defer wg.Done()
 
-			req := fasthttp.AcquireRequest()
-			resp := fasthttp.AcquireResponse()
-			defer fasthttp.ReleaseRequest(req)
-			defer fasthttp.ReleaseResponse(resp)
-
-			providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
-
-			modelHubURL := provider.buildModelHubURL(request, inferProvider)
-			req.SetRequestURI(modelHubURL)
-			req.Header.SetMethod(http.MethodGet)
-			req.Header.SetContentType("application/json")
-			if key.Value.GetValue() != "" {
-				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", key.Value.GetValue()))
-			}
-
-			latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.client, req, resp)
-			defer wait()
-			if bifrostErr != nil {
-				resultsChan <- providerResult{provider: inferProvider, err: bifrostErr}
-				return
+			// Each page holds at most maxModelFetchLimit models, so follow the Hub's cursor
+			// until the requested page size is filled or the listing runs out
+			wanted := request.PageSize
+			if wanted <= 0 {
+				wanted = provider.defaultModelFetchLimit()
 			}
 
-			if resp.StatusCode() != fasthttp.StatusOK {
-				var errorResp HuggingFaceHubError
-				bifrostErr := providerUtils.HandleProviderAPIError(resp, &errorResp)
-				if bifrostErr.Error == nil {
-					bifrostErr.Error = &schemas.ErrorField{}
-				}
-				if strings.TrimSpace(errorResp.Message) != "" {
-					bifrostErr.Error.Message = errorResp.Message
+			aggregated := &HuggingFaceListModelsResponse{}
+			var totalLatency int64
+			var firstRawResp map[string]interface{}
+			var rawHub []json.RawMessage
+			cursor := ""
+			for pageIndex := 0; ; pageIndex++ {
+				modelHubURL, urlErr := provider.buildModelHubURL(request, inferProvider, cursor)
+				if urlErr != nil {
+					resultsChan <- providerResult{provider: inferProvider, err: providerUtils.NewBifrostOperationError("invalid list models request", urlErr)}
+					return
 				}
-				resultsChan <- providerResult{provider: inferProvider, err: bifrostErr}
-				return
-			}
 
-			body, err := providerUtils.CheckAndDecodeBody(resp)
-			if err != nil {
-				resultsChan <- providerResult{provider: inferProvider, err: providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, err)}
-				return
-			}
+				page, bifrostErr := provider.fetchModelHubPage(ctx, key, modelHubURL, captureRawHub)
+				if bifrostErr != nil {
+					if pageIndex == 0 {
+						resultsChan <- providerResult{provider: inferProvider, err: bifrostErr}
+						return
+					}
+					// Keep the pages already fetched rather than failing the whole listing
+					provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: stopped paginating %s models after %d pages: %v", inferProvider, pageIndex, bifrostErr.Error), nil))
+					break
+				}
 
-			var huggingfaceAPIResponse HuggingFaceListModelsResponse
-			var rawResponse interface{}
-			var rawRequest interface{}
-			rawRequest, rawResponse, bifrostErr = providerUtils.HandleProviderResponse(body, &huggingfaceAPIResponse, nil, providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest), providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse))
-			if bifrostErr != nil {
-				resultsChan <- providerResult{provider: inferProvider, err: bifrostErr}
-				return
-			}
-			var rawRespMap map[string]interface{}
-			if rawResponse != nil {
-				if converted, ok := rawResponse.(map[string]interface{}); ok {
-					rawRespMap = converted
+				aggregated.Models = append(aggregated.Models, page.response.Models...)
+				rawHub = append(rawHub, page.rawHub...)
+				totalLatency += page.latency
+				if pageIndex == 0 {
+					firstRawResp = page.rawResp
 				}
-			}
-			// If raw request was requested, attach it to the raw response map
-			if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) && rawRequest != nil {
-				if rawRespMap == nil {
-					rawRespMap = make(map[string]interface{})
+				if page.nextCursor == "" || page.nextCursor == cursor || len(aggregated.Models) >= wanted {
+					break
 				}
-				rawRespMap["raw_request"] = rawRequest
+				cursor = page.nextCursor
 			}
 
 			resultsChan <- providerResult{
 				provider: inferProvider,
-				response: &huggingfaceAPIResponse,
-				latency:  latency.Milliseconds(),
-				rawResp:  rawRespMap,
+				response: aggregated,
+				latency:  totalLatency,
+				rawResp:  firstRawResp,
+				rawHub:   rawHub,
 			}
 		}(infProvider)
 	}
@@ -377,6 +520,7 @@ func (provider *HuggingFaceProvider) listModelsByKey(ctx *schemas.BifrostContext
 	var successCount int
 	var firstError *schemas.BifrostError
 	var rawResponses []map[string]interface{}
+	rawHubByProvider := make(map[string][]json.RawMessage)
 
 	for result := range resultsChan {
 		if result.err != nil {
@@ -395,6 +539,9 @@ func (provider *HuggingFaceProvider) listModelsByKey(ctx *schemas.BifrostContext
 				if result.rawResp != nil {
 					rawResponses = append(rawResponses, result.rawResp)
 				}
+				if captureRawHub {
+					rawHubByProvider[string(result.provider)] = result.rawHub
+				}
 			}
 		}
 	}
@@ -404,6 +551,14 @@ func (provider *HuggingFaceProvider) listModelsByKey(ctx *schemas.BifrostContext
 		return nil, firstError
 	}
 
+	if mode, ok := request.ExtraParams["duplicate_model_mode"].(string); ok && DuplicateModelMode(mode) == DuplicateModelModeCollapse {
+		aggregatedResponse.Data = collapseDuplicateModels(aggregatedResponse.Data, providerName)
+	}
+	aggregatedResponse.Data = applyGatedModelAccess(aggregatedResponse.Data, gatedMode, func(modelName string) (bool, bool) {
+		return provider.checkModelAccess(ctx, key, modelName, provider.buildModelAuthCheckURL(modelName))
+	})
+	truncateModelDescriptions(aggregatedResponse.Data, provider.huggingFaceConfig.MaxModelDescriptionLength)
+
 	// Calculate average latency
 	if successCount > 0 {
 		aggregatedResponse.ExtraFields.Latency = totalLatency / int64(successCount)
@@ -418,35 +573,578 @@ func (provider *HuggingFaceProvider) listModelsByKey(ctx *schemas.BifrostContext
 		aggregatedResponse.ExtraFields.RawResponse = combinedRaw
 	}
 
+	if captureRawHub {
+		combinedRaw, _ := aggregatedResponse.ExtraFields.RawResponse.(map[string]interface{})
+		if combinedRaw == nil {
+			combinedRaw = make(map[string]interface{})
+		}
+		combinedRaw["hub_models"] = rawHubByProvider
+		aggregatedResponse.ExtraFields.RawResponse = combinedRaw
+	}
+
 	return aggregatedResponse, nil
 }
 
+// listRouterModels lists the chat models the router serves right now from its OpenAI-compatible
+// /v1/models at routerModelsURL, limited to the key's preferred inference provider if it has one.
+// captureRaw attaches the router's entries verbatim under RawResponse["router_models"].
+func (provider *HuggingFaceProvider) listRouterModels(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostListModelsRequest, routerModelsURL string, captureRaw bool) (*schemas.BifrostListModelsResponse, *schemas.BifrostError) {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
+	req.SetRequestURI(routerModelsURL)
+	req.Header.SetMethod(http.MethodGet)
+	if authHeader := bearerAuthHeader(key.Value.GetValue()); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+	if resp.StatusCode() != fasthttp.StatusOK {
+		return nil, parseHuggingFaceImageError(resp)
+	}
+
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, err)
+	}
+
+	var routerResponse HuggingFaceRouterModelsResponse
+	_, rawResponse, bifrostErr := providerUtils.HandleProviderResponse(body, &routerResponse, nil, false, providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse))
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	response := routerResponse.ToBifrostListModelsResponse(provider.GetProviderKey(), keyInferenceProvider(key), key.Models, key.BlacklistedModels, key.Aliases, request.Unfiltered)
+	response.ExtraFields.Latency = latency.Milliseconds()
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		response.ExtraFields.RawResponse = rawResponse
+	}
+	if captureRaw {
+		var rawRouter struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := sonic.Unmarshal(body, &rawRouter); err != nil {
+			return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, err)
+		}
+		combinedRaw, _ := response.ExtraFields.RawResponse.(map[string]interface{})
+		if combinedRaw == nil {
+			combinedRaw = make(map[string]interface{})
+		}
+		combinedRaw["router_models"] = rawRouter.Data
+		response.ExtraFields.RawResponse = combinedRaw
+	}
+	return response, nil
+}
+
+// modelHubPage is one page of the Hub model listing.
+type modelHubPage struct {
+	response   *HuggingFaceListModelsResponse
+	nextCursor string // empty on the last page
+	latency    int64
+	rawResp    map[string]interface{}
+	rawHub     []json.RawMessage // the page's Hub entries verbatim, when requested via RawModelListContextKey
+}
+
+// fetchModelHubPage fetches and decodes one page of the Hub model listing.
+func (provider *HuggingFaceProvider) fetchModelHubPage(ctx *schemas.BifrostContext, key schemas.Key, modelHubURL string, captureRawHub bool) (*modelHubPage, *schemas.BifrostError) {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
+	req.SetRequestURI(modelHubURL)
+	req.Header.SetMethod(http.MethodGet)
+	req.Header.SetContentType("application/json")
+	if authHeader := bearerAuthHeader(key.Value.GetValue()); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	latency, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	if resp.StatusCode() != fasthttp.StatusOK {
+		var errorResp HuggingFaceHubError
+		bifrostErr := providerUtils.HandleProviderAPIError(resp, &errorResp)
+		if bifrostErr.Error == nil {
+			bifrostErr.Error = &schemas.ErrorField{}
+		}
+		if strings.TrimSpace(errorResp.Message) != "" {
+			bifrostErr.Error.Message = errorResp.Message
+		}
+		return nil, bifrostErr
+	}
+
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, err)
+	}
+
+	var huggingfaceAPIResponse HuggingFaceListModelsResponse
+	var rawResponse interface{}
+	var rawRequest interface{}
+	rawRequest, rawResponse, bifrostErr = providerUtils.HandleProviderResponse(body, &huggingfaceAPIResponse, nil, providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest), providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse))
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+	var rawRespMap map[string]interface{}
+	if rawResponse != nil {
+		if converted, ok := rawResponse.(map[string]interface{}); ok {
+			rawRespMap = converted
+		}
+	}
+	// If raw request was requested, attach it to the raw response map
+	if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) && rawRequest != nil {
+		if rawRespMap == nil {
+			rawRespMap = make(map[string]interface{})
+		}
+		rawRespMap["raw_request"] = rawRequest
+	}
+
+	page := &modelHubPage{
+		response:   &huggingfaceAPIResponse,
+		nextCursor: extractCursor(&resp.Header),
+		latency:    latency.Milliseconds(),
+		rawResp:    rawRespMap,
+	}
+	if captureRawHub {
+		// Older Hub deployments wrap the list as {"models": [...]}
+		if err := sonic.Unmarshal(body, &page.rawHub); err != nil {
+			var wrapped struct {
+				Models []json.RawMessage `json:"models"`
+			}
+			if err := sonic.Unmarshal(body, &wrapped); err == nil {
+				page.rawHub = wrapped.Models
+			}
+		}
+	}
+	return page, nil
+}
+
 // ListModels queries the Hugging Face model hub API to list models served by the inference provider.
 func (provider *HuggingFaceProvider) ListModels(ctx *schemas.BifrostContext, keys []schemas.Key, request *schemas.BifrostListModelsRequest) (*schemas.BifrostListModelsResponse, *schemas.BifrostError) {
 
 	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.ListModelsRequest); err != nil {
 		return nil, err
 	}
+	order, orderErr := parseModelListOrder(request.ExtraParams)
+	if orderErr != nil {
+		return nil, providerUtils.NewBifrostOperationError("invalid list models request", orderErr)
+	}
 	if provider.customProviderConfig != nil && provider.customProviderConfig.IsKeyLess {
 		return providerUtils.HandleKeylessListModelsRequest(provider.GetProviderKey(), func() (*schemas.BifrostListModelsResponse, *schemas.BifrostError) {
-			return provider.listModelsByKey(ctx, schemas.Key{}, request)
+			response, bifrostErr := provider.listModelsByKey(ctx, schemas.Key{}, request)
+			if response != nil && order != ModelListOrderID {
+				sortModelList(response.Data, order)
+			}
+			return response, bifrostErr
 		})
 	}
-	return providerUtils.HandleMultipleListModelsRequests(
+	if order == ModelListOrderID {
+		return providerUtils.HandleMultipleListModelsRequests(
+			ctx,
+			keys,
+			request,
+			provider.listModelsByKey,
+		)
+	}
+
+	// The shared handler sorts by ID before paginating, so collect every page, reorder, then paginate
+	unpaged := *request
+	unpaged.PageSize = 0
+	unpaged.PageToken = ""
+	response, bifrostErr := providerUtils.HandleMultipleListModelsRequests(
 		ctx,
 		keys,
-		request,
-		provider.listModelsByKey,
+		&unpaged,
+		func(ctx *schemas.BifrostContext, key schemas.Key, _ *schemas.BifrostListModelsRequest) (*schemas.BifrostListModelsResponse, *schemas.BifrostError) {
+			// The Hub fetch still follows the caller's page size
+			return provider.listModelsByKey(ctx, key, request)
+		},
 	)
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+	sortModelList(response.Data, order)
+	return response.ApplyPagination(request.PageSize, request.PageToken), nil
+}
+
+// GetModel fetches a single model's Hub entry, including the card metadata the list endpoint
+// leaves out. modelID may be an alias or carry an inference provider prefix; only the
+// {org}/{model} repo ID is looked up.
+func (provider *HuggingFaceProvider) GetModel(ctx *schemas.BifrostContext, key schemas.Key, modelID string) (*schemas.Model, *schemas.BifrostError) {
+	_, modelName, nameErr := splitIntoModelProvider(provider.resolveModelAlias(ctx, key, modelID))
+	if nameErr != nil {
+		return nil, &schemas.BifrostError{
+			IsBifrostError: false,
+			Error: &schemas.ErrorField{
+				Message: nameErr.Error(),
+				Error:   nameErr,
+			},
+		}
+	}
+	return provider.fetchModelInfo(ctx, key, modelName, provider.buildModelInfoURL(modelName))
+}
+
+// fetchModelInfo requests modelInfoURL and converts the Hub entry for modelName. Gated and
+// private models the key cannot access come back as 401/403 errors that say so.
+func (provider *HuggingFaceProvider) fetchModelInfo(ctx *schemas.BifrostContext, key schemas.Key, modelName string, modelInfoURL string) (*schemas.Model, *schemas.BifrostError) {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
+	req.SetRequestURI(modelInfoURL)
+	req.Header.SetMethod(http.MethodGet)
+	if authHeader := bearerAuthHeader(key.Value.GetValue()); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	_, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		return nil, bifrostErr
+	}
+
+	if resp.StatusCode() != fasthttp.StatusOK {
+		var errorResp HuggingFaceHubError
+		bifrostErr := providerUtils.HandleProviderAPIError(resp, &errorResp)
+		if bifrostErr.Error == nil {
+			bifrostErr.Error = &schemas.ErrorField{}
+		}
+		message := strings.TrimSpace(errorResp.Error)
+		if strings.TrimSpace(errorResp.Message) != "" {
+			message = strings.TrimSpace(errorResp.Message)
+		}
+		if message != "" {
+			bifrostErr.Error.Message = message
+		}
+		if resp.StatusCode() == fasthttp.StatusUnauthorized || resp.StatusCode() == fasthttp.StatusForbidden {
+			bifrostErr.Error.Message = fmt.Sprintf("model %s is gated or private and this key has no access to it: %s", modelName, bifrostErr.Error.Message)
+		}
+		return nil, bifrostErr
+	}
+
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, err)
+	}
+
+	var modelInfo HuggingFaceModelInfo
+	if err := sonic.Unmarshal(body, &modelInfo); err != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseUnmarshal, err)
+	}
+	if modelInfo.ModelID == "" {
+		modelInfo.ModelID = modelName
+	}
+
+	return modelInfo.ToBifrostModel(provider.GetProviderKey()), nil
+}
+
+// checkModelAccess asks authCheckURL whether the key can run modelName. known is false when the
+// Hub could not answer, in which case accessible means nothing. A request without a key is
+// never granted access to a gated model, so it is answered without calling the Hub.
+func (provider *HuggingFaceProvider) checkModelAccess(ctx *schemas.BifrostContext, key schemas.Key, modelName string, authCheckURL string) (accessible bool, known bool) {
+	authHeader := bearerAuthHeader(key.Value.GetValue())
+	if authHeader == "" {
+		return false, true
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
+	req.SetRequestURI(authCheckURL)
+	req.Header.SetMethod(http.MethodGet)
+	req.Header.Set("Authorization", authHeader)
+
+	_, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: could not check access to %s: %v", modelName, bifrostErr.Error), nil))
+		return false, false
+	}
+
+	switch resp.StatusCode() {
+	case fasthttp.StatusOK:
+		return true, true
+	case fasthttp.StatusUnauthorized, fasthttp.StatusForbidden, fasthttp.StatusNotFound:
+		return false, true
+	}
+	provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: could not check access to %s: %s", modelName, hubErrorMessage(resp)), resp))
+	return false, false
+}
 
+// suggestModelsOnNotFound names up to maxModelSuggestions Hub models resembling modelName in a 404
+// for it when HuggingFaceConfig.SuggestModelsOnNotFound is set. Other errors are returned unchanged.
+func (provider *HuggingFaceProvider) suggestModelsOnNotFound(ctx *schemas.BifrostContext, key schemas.Key, bifrostErr *schemas.BifrostError, modelName string) *schemas.BifrostError {
+	if !provider.huggingFaceConfig.SuggestModelsOnNotFound || bifrostErr == nil || bifrostErr.StatusCode == nil || *bifrostErr.StatusCode != fasthttp.StatusNotFound {
+		return bifrostErr
+	}
+	suggestions := provider.searchSimilarModels(ctx, key, modelName, modelHubBaseURL+"/api/models")
+	return explainModelNotFoundError(bifrostErr, modelName, suggestions)
 }
 
+// searchSimilarModels returns the Hub models at searchURL most like modelName: the author's most
+// downloaded models or, when the author has none, those whose name contains modelName's, ranked by
+// how close their names are. A failed lookup yields no suggestions.
+func (provider *HuggingFaceProvider) searchSimilarModels(ctx *schemas.BifrostContext, key schemas.Key, modelName string, searchURL string) []string {
+	author, name, hasAuthor := strings.Cut(modelName, "/")
+	if !hasAuthor {
+		author, name = "", modelName
+	}
+	var candidates []string
+	if author != "" {
+		candidates = provider.searchHubModels(ctx, key, fmt.Sprintf("%s?author=%s&sort=downloads&limit=100", searchURL, url.QueryEscape(author)))
+	}
+	if len(candidates) == 0 && name != "" {
+		candidates = provider.searchHubModels(ctx, key, fmt.Sprintf("%s?search=%s&sort=downloads&limit=20", searchURL, url.QueryEscape(name)))
+	}
+	return rankModelSuggestions(modelName, candidates, maxModelSuggestions)
+}
+
+// searchHubModels returns the IDs of the models listed at hubURL, or nil when the lookup fails.
+func (provider *HuggingFaceProvider) searchHubModels(ctx *schemas.BifrostContext, key schemas.Key, hubURL string) []string {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
+	req.SetRequestURI(hubURL)
+	req.Header.SetMethod(http.MethodGet)
+	if authHeader := bearerAuthHeader(key.Value.GetValue()); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	_, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).client, req, resp)
+	defer wait()
+	if bifrostErr != nil {
+		provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: could not search the hub for similar models: %v", bifrostErr.Error), nil))
+		return nil
+	}
+	if resp.StatusCode() != fasthttp.StatusOK {
+		provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: could not search the hub for similar models: %s", hubErrorMessage(resp)), resp))
+		return nil
+	}
+
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil
+	}
+	var listResp HuggingFaceListModelsResponse
+	if err := sonic.Unmarshal(body, &listResp); err != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(listResp.Models))
+	for _, model := range listResp.Models {
+		if model.ModelID != "" {
+			ids = append(ids, model.ModelID)
+		}
+	}
+	return ids
+}
+
+// TextCompletion answers through the chat completions route by default. Models whose hf-inference
+// deployment is a raw text-generation pipeline (no chat template, so no chat route) are sent the
+// prompt directly instead.
 func (provider *HuggingFaceProvider) TextCompletion(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostTextCompletionRequest) (*schemas.BifrostTextCompletionResponse, *schemas.BifrostError) {
-	return nil, providerUtils.NewUnsupportedOperationError(schemas.TextCompletionRequest, provider.GetProviderKey())
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.TextCompletionRequest); err != nil {
+		return nil, err
+	}
+
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	if endpointURL, _ := dedicatedEndpointURL(key, resolvedModel); endpointURL == "" {
+		routedModel := applyKeyInferenceProvider(key, resolvedModel, "")
+		inferenceProvider, modelName, nameErr := splitIntoModelProvider(routedModel)
+		if nameErr == nil && inferenceProvider == hfInference && provider.servesTextGeneration(ctx, modelName) {
+			var appliedDefaults []string
+			if routedModel != resolvedModel {
+				appliedDefaults = append(appliedDefaults, "inference_provider")
+			}
+			return provider.textGeneration(ctx, key, request, modelName, appliedDefaults)
+		}
+	}
+
+	chatRequest := request.ToBifrostChatRequest()
+	if chatRequest == nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, fmt.Errorf("text completion needs a prompt"))
+	}
+	chatResponse, err := provider.chatCompletion(ctx, key, chatRequest, resolvedModel)
+	if err != nil {
+		return nil, err
+	}
+	return chatResponse.ToBifrostTextCompletionResponse(), nil
 }
 
+// servesTextGeneration reports whether the model's hf-inference deployment runs the raw
+// text-generation pipeline. A failed mapping lookup leaves the request on the chat route,
+// which reports the error.
+func (provider *HuggingFaceProvider) servesTextGeneration(ctx *schemas.BifrostContext, modelName string) bool {
+	providerMapping, err := provider.getModelInferenceProviderMapping(ctx, modelName)
+	if err != nil {
+		return false
+	}
+	mapping, ok := providerMapping[hfInference]
+	return ok && mapping.ProviderTask == "text-generation"
+}
+
+// textGeneration sends a text completion to the model's hf-inference text-generation pipeline.
+// Raw prompts get the model family's default stop sequences when the caller set none.
+func (provider *HuggingFaceProvider) textGeneration(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostTextCompletionRequest, modelName string, appliedDefaults []string) (*schemas.BifrostTextCompletionResponse, *schemas.BifrostError) {
+	var stop []string
+	if request.Params != nil {
+		stop = request.Params.Stop
+	}
+	stop = provider.applyFamilyStopSequences(modelName, stop)
+
+	jsonBody, err := providerUtils.CheckContextAndGetRequestBody(
+		ctx,
+		request,
+		func() (providerUtils.RequestBodyWithExtraParams, error) {
+			return ToHuggingFaceTextGenerationRequest(request, stop)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, latency, providerResponseHeaders, err := provider.completeRequestWithModelAliasCache(
+		ctx,
+		jsonBody,
+		key,
+		false,
+		false,
+		hfInference,
+		modelName,
+		"text-generation",
+		schemas.TextCompletionRequest,
+	)
+	if providerResponseHeaders != nil {
+		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
+	}
+	if err != nil {
+		return nil, providerUtils.EnrichError(ctx, explainGatedModelError(err, modelName), jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+	if inlineErr := parseHuggingFaceInlineError(responseBody); inlineErr != nil {
+		return nil, providerUtils.EnrichError(ctx, inlineErr, jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
+	// return_full_text is only set with echo, so only then does the text start with the prompt
+	var echoedPrompt string
+	if request.Params != nil && request.Params.Echo != nil && *request.Params.Echo && request.Input != nil {
+		if request.Input.PromptStr != nil {
+			echoedPrompt = *request.Input.PromptStr
+		} else if len(request.Input.PromptArray) == 1 {
+			echoedPrompt = request.Input.PromptArray[0]
+		}
+	}
+	bifrostResponse, convErr := UnmarshalHuggingFaceTextGenerationResponse(responseBody, stop, echoedPrompt)
+	if convErr != nil {
+		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, convErr), jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
+	bifrostResponse.Model = request.Model
+	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
+	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
+	bifrostResponse.ExtraFields.EstimatedCost = estimatedCost(key, hfInference, modelName, bifrostResponse.Usage)
+	bifrostResponse.ExtraFields.ModelHubURL = provider.modelHubURL(modelName)
+	bifrostResponse.ExtraFields.EffectiveConfig = provider.effectiveConfig(HuggingFaceEffectiveConfig{
+		Model:             modelName,
+		InferenceProvider: string(hfInference),
+		Task:              "text-generation",
+		AppliedDefaults:   appliedDefaults,
+	})
+
+	// Set raw request/response if enabled
+	if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) {
+		var rawRequest interface{}
+		if err := sonic.Unmarshal(jsonBody, &rawRequest); err != nil {
+			rawRequest = string(jsonBody)
+		}
+		bifrostResponse.ExtraFields.RawRequest = rawRequest
+	}
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		var rawResponse interface{}
+		if err := sonic.Unmarshal(responseBody, &rawResponse); err != nil {
+			rawResponse = string(responseBody)
+		}
+		bifrostResponse.ExtraFields.RawResponse = rawResponse
+	}
+
+	return bifrostResponse, nil
+}
+
+// TextCompletionStream streams through the chat completions route like TextCompletion, rebuilding
+// each chat chunk as a text completion chunk. The raw text-generation pipeline is not streamed,
+// so requests for models that only serve it are rejected.
 func (provider *HuggingFaceProvider) TextCompletionStream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostTextCompletionRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
-	return nil, providerUtils.NewUnsupportedOperationError(schemas.TextCompletionStreamRequest, provider.GetProviderKey())
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.TextCompletionStreamRequest); err != nil {
+		return nil, err
+	}
+
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	if endpointURL, _ := dedicatedEndpointURL(key, resolvedModel); endpointURL == "" {
+		routedModel := applyKeyInferenceProvider(key, resolvedModel, "")
+		inferenceProvider, modelName, nameErr := splitIntoModelProvider(routedModel)
+		if nameErr == nil && inferenceProvider == hfInference && provider.servesTextGeneration(ctx, modelName) {
+			statusCode := http.StatusBadRequest
+			return nil, &schemas.BifrostError{
+				IsBifrostError: false,
+				StatusCode:     &statusCode,
+				Error: &schemas.ErrorField{
+					Message: fmt.Sprintf("%s is served by the hf-inference text-generation pipeline, which does not support streaming text completion; send the request without streaming", modelName),
+				},
+			}
+		}
+	}
+
+	chatRequest := request.ToBifrostChatRequest()
+	if chatRequest == nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, fmt.Errorf("text completion needs a prompt"))
+	}
+	return provider.chatCompletionStream(ctx, textCompletionStreamPostHookRunner(postHookRunner, request.Model), postHookSpanFinalizer, key, chatRequest, resolvedModel)
+}
+
+// textCompletionStreamPostHookRunner turns the chat chunks of a text completion stream back into
+// text completion chunks before the post hooks see them, naming the requested model on any chunk
+// that arrives without one.
+func textCompletionStreamPostHookRunner(postHookRunner schemas.PostHookRunner, model string) schemas.PostHookRunner {
+	return func(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, err *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError) {
+		if result != nil && result.ChatResponse != nil {
+			if converted := result.ChatResponse.ToBifrostTextCompletionResponse(); converted != nil {
+				if converted.Model == "" {
+					converted.Model = model
+				}
+				converted.ExtraFields.RequestType = schemas.TextCompletionStreamRequest
+				result = &schemas.BifrostResponse{TextCompletionResponse: converted}
+			}
+		}
+		return postHookRunner(ctx, result, err)
+	}
 }
 
 func (provider *HuggingFaceProvider) ChatCompletion(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostChatRequest) (*schemas.BifrostChatResponse, *schemas.BifrostError) {
@@ -454,6 +1152,27 @@ func (provider *HuggingFaceProvider) ChatCompletion(ctx *schemas.BifrostContext,
 		return nil, err
 	}
 
+	return provider.chatCompletion(ctx, key, request, provider.resolveModelAlias(ctx, key, request.Model))
+}
+
+// chatCompletion sends request to resolvedModel, the request's model with the key's aliases
+// already applied, so callers that resolved it themselves don't resolve (and log) it twice.
+func (provider *HuggingFaceProvider) chatCompletion(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostChatRequest, resolvedModel string) (*schemas.BifrostChatResponse, *schemas.BifrostError) {
+	endpointURL, endpointErr := dedicatedEndpointURL(key, resolvedModel)
+	if endpointErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderCreateRequest, endpointErr)
+	}
+	// The upstream model ID goes on a copy, leaving the caller's request as it was sent
+	chatRequest := *request
+	request = &chatRequest
+	request.Model = resolvedModel
+	var appliedDefaults []string
+	if endpointURL == "" {
+		request.Model = applyKeyInferenceProvider(key, resolvedModel, "")
+		if request.Model != resolvedModel {
+			appliedDefaults = append(appliedDefaults, "inference_provider")
+		}
+	}
 	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
@@ -464,6 +1183,10 @@ func (provider *HuggingFaceProvider) ChatCompletion(ctx *schemas.BifrostContext,
 			},
 		}
 	}
+	// A dedicated endpoint serves its one model itself, without a router backend
+	if endpointURL != "" {
+		inferenceProvider = ""
+	}
 	if inferenceProvider != "" {
 		request.Model = fmt.Sprintf("%s:%s", modelName, inferenceProvider)
 	} else {
@@ -480,6 +1203,12 @@ func (provider *HuggingFaceProvider) ChatCompletion(ctx *schemas.BifrostContext,
 			}
 			if reqBody != nil {
 				reqBody.Stream = schemas.Ptr(false)
+				provider.dropUnsupportedPrediction(ctx, reqBody, inferenceProvider)
+				hadUser := reqBody.User != nil
+				provider.applyContextUser(ctx, reqBody)
+				if !hadUser && reqBody.User != nil {
+					appliedDefaults = append(appliedDefaults, "user")
+				}
 			}
 			return reqBody, nil
 		})
@@ -488,13 +1217,25 @@ func (provider *HuggingFaceProvider) ChatCompletion(ctx *schemas.BifrostContext,
 	}
 
 	requestURL := provider.buildRequestURL(ctx, "/v1/chat/completions", schemas.ChatCompletionRequest)
+	if endpointURL != "" {
+		requestURL = dedicatedEndpointRequestURL(endpointURL, schemas.ChatCompletionRequest)
+	}
 
-	responseBody, latency, providerResponseHeaders, err := provider.completeRequest(ctx, jsonBody, requestURL, key.Value.GetValue(), false, false)
+	release, slotErr := provider.acquireModelSlot(ctx, key, resolvedModel, endpointURL)
+	if slotErr != nil {
+		return nil, slotErr
+	}
+	responseBody, latency, providerResponseHeaders, err := provider.completeRequest(ctx, jsonBody, requestURL, modelDeployment(endpointURL, inferenceProvider, modelName), key, false, false, false)
+	release()
 	if providerResponseHeaders != nil {
 		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
 	}
 	if err != nil {
-		return nil, providerUtils.EnrichError(ctx, err, jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
+		// A dedicated endpoint's 404 is about the endpoint, not a Hub model name
+		if endpointURL == "" {
+			err = provider.suggestModelsOnNotFound(ctx, key, err, modelName)
+		}
+		return nil, providerUtils.EnrichError(ctx, explainGatedModelError(err, modelName), jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
 	}
 
 	bifrostResponse := &schemas.BifrostChatResponse{}
@@ -516,8 +1257,27 @@ func (provider *HuggingFaceProvider) ChatCompletion(ctx *schemas.BifrostContext,
 		bifrostResponse.Object = "chat.completion"
 	}
 
+	if request.Params != nil {
+		truncateAtStopSequences(bifrostResponse, request.Params.Stop)
+	}
+
 	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
 	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
+	completionTokens := 0
+	if bifrostResponse.Usage != nil {
+		bifrostResponse.ExtraFields.UsageAccuracy = schemas.UsageAccuracyExact
+		completionTokens = bifrostResponse.Usage.CompletionTokens
+	}
+	bifrostResponse.ExtraFields.GenerationTiming = parseGenerationTimingHeaders(providerResponseHeaders, completionTokens)
+	bifrostResponse.ExtraFields.EstimatedCost = estimatedCost(key, inferenceProvider, modelName, bifrostResponse.Usage)
+	bifrostResponse.ExtraFields.ModelHubURL = provider.modelHubURL(modelName)
+	bifrostResponse.ExtraFields.EffectiveConfig = provider.effectiveConfig(HuggingFaceEffectiveConfig{
+		Model:             modelName,
+		InferenceProvider: string(inferenceProvider),
+		Task:              "chat-completion",
+		AppliedDefaults:   appliedDefaults,
+	})
 
 	// Set raw response if enabled
 	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
@@ -537,7 +1297,20 @@ func (provider *HuggingFaceProvider) ChatCompletionStream(ctx *schemas.BifrostCo
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	return provider.chatCompletionStream(ctx, postHookRunner, postHookSpanFinalizer, key, request, provider.resolveModelAlias(ctx, key, request.Model))
+}
+
+// chatCompletionStream is the streaming counterpart of chatCompletion.
+func (provider *HuggingFaceProvider) chatCompletionStream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostChatRequest, resolvedModel string) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+	endpointURL, endpointErr := dedicatedEndpointURL(key, resolvedModel)
+	if endpointErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderCreateRequest, endpointErr)
+	}
+	routedModel := resolvedModel
+	if endpointURL == "" {
+		routedModel = applyKeyInferenceProvider(key, resolvedModel, "")
+	}
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(routedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -547,15 +1320,38 @@ func (provider *HuggingFaceProvider) ChatCompletionStream(ctx *schemas.BifrostCo
 			},
 		}
 	}
+	if endpointURL != "" {
+		inferenceProvider = ""
+	}
+	// The stream sends a copy carrying the upstream model ID, leaving the caller's request (and so
+	// a non-streaming fallback) as it was sent
+	streamRequest := *request
 	if inferenceProvider != "" {
-		request.Model = fmt.Sprintf("%s:%s", modelName, inferenceProvider)
+		streamRequest.Model = fmt.Sprintf("%s:%s", modelName, inferenceProvider)
 	} else {
-		request.Model = modelName
+		streamRequest.Model = modelName
 	}
 
-	var authHeader map[string]string
-	if key.Value.GetValue() != "" {
-		authHeader = map[string]string{"Authorization": "Bearer " + key.Value.GetValue()}
+	// Backends only stream usage when asked for it, so ask unless the caller opted out. The shared
+	// handler also reads these params to wait for the usage chunk.
+	params := schemas.ChatParameters{}
+	if request.Params != nil {
+		params = *request.Params
+	}
+	streamOptions := schemas.ChatStreamOptions{}
+	if params.StreamOptions != nil {
+		streamOptions = *params.StreamOptions
+	}
+	if streamOptions.IncludeUsage == nil {
+		streamOptions.IncludeUsage = schemas.Ptr(true)
+	}
+	params.StreamOptions = &streamOptions
+	streamRequest.Params = &params
+
+	// The shared handler copies these over its defaults, so the configured content type wins
+	authHeader := map[string]string{"Content-Type": provider.jsonContentType(), requestIDHeader: requestID(ctx)}
+	if value := bearerAuthHeader(key.Value.GetValue()); value != "" {
+		authHeader["Authorization"] = value
 	}
 
 	customRequestConverter := func(request *schemas.BifrostChatRequest) (providerUtils.RequestBodyWithExtraParams, error) {
@@ -565,16 +1361,59 @@ func (provider *HuggingFaceProvider) ChatCompletionStream(ctx *schemas.BifrostCo
 		}
 		if reqBody != nil {
 			reqBody.Stream = schemas.Ptr(true)
+			provider.dropUnsupportedPrediction(ctx, reqBody, inferenceProvider)
+			provider.applyContextUser(ctx, reqBody)
 		}
 		return reqBody, nil
 	}
 
+	usageTracker := newStreamUsageTracker(&streamRequest, provider.huggingFaceConfig.EstimateStreamUsage, func() tokenCounter {
+		return provider.getTokenCounter(ctx, key, modelName)
+	})
+	// Some upstreams name the model only on the first chunk (or never); every chunk, and any
+	// text completion rebuilt from it, should still say which model served the stream. The
+	// shared handler builds the final chunk itself, so it gets the fingerprint seen upstream.
+	var systemFingerprint string
+	convertChunk := func(response *schemas.BifrostChatResponse) *schemas.BifrostChatResponse {
+		if response.Model == "" {
+			response.Model = modelName
+		}
+		if response.SystemFingerprint != "" {
+			systemFingerprint = response.SystemFingerprint
+		} else {
+			response.SystemFingerprint = systemFingerprint
+		}
+		response = usageTracker.convert(response)
+		if response.Usage != nil {
+			response.ExtraFields.EstimatedCost = estimatedCost(key, inferenceProvider, modelName, response.Usage)
+		}
+		return response
+	}
+
+	requestURL := provider.buildRequestURL(ctx, "/v1/chat/completions", schemas.ChatCompletionStreamRequest)
+	if endpointURL != "" {
+		requestURL = dedicatedEndpointRequestURL(endpointURL, schemas.ChatCompletionStreamRequest)
+	}
+
+	// A stream holds its slot until it ends, which is when the span finalizer runs
+	acquired, slotErr := provider.acquireModelSlot(ctx, key, resolvedModel, endpointURL)
+	if slotErr != nil {
+		return nil, slotErr
+	}
+	release := sync.OnceFunc(acquired)
+	streamFinalizer := func(ctx context.Context) {
+		release()
+		if postHookSpanFinalizer != nil {
+			postHookSpanFinalizer(ctx)
+		}
+	}
+
 	// Use shared OpenAI-compatible streaming logic
-	return openai.HandleOpenAIChatCompletionStreaming(
+	responseChan, bifrostErr := openai.HandleOpenAIChatCompletionStreaming(
 		ctx,
-		provider.streamingClient,
-		provider.buildRequestURL(ctx, "/v1/chat/completions", schemas.ChatCompletionStreamRequest),
-		request,
+		provider.clientsForKey(key).streamingClient,
+		requestURL,
+		&streamRequest,
 		authHeader,
 		provider.networkConfig.ExtraHeaders,
 		providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest),
@@ -582,50 +1421,250 @@ func (provider *HuggingFaceProvider) ChatCompletionStream(ctx *schemas.BifrostCo
 		provider.GetProviderKey(),
 		postHookRunner,
 		customRequestConverter,
+		provider.streamChunkHandler(ctx),
+		parseHuggingFaceChatError,
 		nil,
-		nil,
-		nil,
-		nil,
+		convertChunk,
 		provider.logger,
-		postHookSpanFinalizer,
+		streamFinalizer,
 	)
+	if bifrostErr != nil {
+		release()
+	}
+	if bifrostErr == nil || !provider.huggingFaceConfig.StreamFallback || !isStreamingUnsupportedError(bifrostErr) {
+		if endpointURL == "" {
+			bifrostErr = provider.suggestModelsOnNotFound(ctx, key, bifrostErr, modelName)
+		}
+		return responseChan, explainGatedModelError(bifrostErr, modelName)
+	}
+
+	provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: %s does not support streaming, falling back to a non-streaming call", streamRequest.Model), nil))
+	response, fallbackErr := provider.chatCompletion(ctx, key, request, resolvedModel)
+	if fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	return streamNonStreamingResponse(ctx, postHookRunner, postHookSpanFinalizer, response), nil
 }
 
 func (provider *HuggingFaceProvider) Responses(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostResponsesRequest) (*schemas.BifrostResponsesResponse, *schemas.BifrostError) {
 	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.ResponsesRequest); err != nil {
 		return nil, err
 	}
-
-	chatResponse, err := provider.ChatCompletion(ctx, key, request.ToChatRequest())
-	if err != nil {
-		return nil, err
+
+	prepared := provider.prepareResponsesRequest(ctx, key, request)
+	chatResponse, err := provider.chatCompletion(ctx, key, prepared.ToChatRequest(), prepared.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	response := chatResponse.ToBifrostResponsesResponse()
+
+	return response, nil
+}
+
+func (provider *HuggingFaceProvider) ResponsesStream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostResponsesRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.ResponsesStreamRequest); err != nil {
+		return nil, err
+	}
+
+	prepared := provider.prepareResponsesRequest(ctx, key, request)
+	ctx.SetValue(schemas.BifrostContextKeyIsResponsesToChatCompletionFallback, true)
+	return provider.chatCompletionStream(
+		ctx,
+		provider.responsesStreamPostHookRunner(postHookRunner, prepared.Model),
+		postHookSpanFinalizer,
+		key,
+		prepared.ToChatRequest(),
+		prepared.Model,
+	)
+}
+
+func (provider *HuggingFaceProvider) Embedding(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostEmbeddingRequest) (*schemas.BifrostEmbeddingResponse, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.EmbeddingRequest); err != nil {
+		return nil, err
+	}
+
+	if inputErr := resolveAmbiguousEmbeddingInput(request.Input, provider.huggingFaceConfig.MergeEmbeddingTextInputs); inputErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, inputErr)
+	}
+
+	response, bifrostErr := provider.embeddingForInputs(ctx, key, request)
+	if bifrostErr == nil || !provider.shouldUseEmbeddingFallback(request.Model, bifrostErr) {
+		return response, bifrostErr
+	}
+
+	fallbackModel := provider.huggingFaceConfig.EmbeddingFallbackModel
+	provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: embedding with %s failed with status %d, retrying with fallback model %s", request.Model, *bifrostErr.StatusCode, fallbackModel), nil))
+	fallbackRequest := *request
+	fallbackRequest.Model = fallbackModel
+	response, fallbackErr := provider.embeddingForInputs(ctx, key, &fallbackRequest)
+	if fallbackErr != nil {
+		// The primary failure is what the caller asked about; the fallback's is only logged
+		provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: fallback embedding model %s also failed: %s", fallbackModel, fallbackErr.Error.Message), nil))
+		return nil, bifrostErr
+	}
+	response.ExtraFields.FallbackModelUsed = fallbackModel
+	return response, nil
+}
+
+// shouldUseEmbeddingFallback reports whether a failed embedding of model should be resent with
+// the configured fallback model.
+func (provider *HuggingFaceProvider) shouldUseEmbeddingFallback(model string, bifrostErr *schemas.BifrostError) bool {
+	fallbackModel := provider.huggingFaceConfig.EmbeddingFallbackModel
+	if fallbackModel == "" || strings.TrimSpace(fallbackModel) == strings.TrimSpace(model) || bifrostErr.StatusCode == nil {
+		return false
+	}
+	status := *bifrostErr.StatusCode
+	if len(provider.huggingFaceConfig.EmbeddingFallbackStatusCodes) > 0 {
+		return slices.Contains(provider.huggingFaceConfig.EmbeddingFallbackStatusCodes, status)
+	}
+	return status == fasthttp.StatusForbidden || status == fasthttp.StatusNotFound || status == fasthttp.StatusGone || status >= fasthttp.StatusInternalServerError
+}
+
+// embeddingForInputs embeds the request in one call, or one call per prompt name when the
+// inputs ask for different prompts. A batch whose body would exceed MaxEmbeddingRequestBytes is
+// rejected before sending, or split into calls that fit when ChunkOversizedEmbeddingRequests is
+// set.
+func (provider *HuggingFaceProvider) embeddingForInputs(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostEmbeddingRequest) (*schemas.BifrostEmbeddingResponse, *schemas.BifrostError) {
+	if maxBytes := provider.maxEmbeddingRequestBytes(); maxBytes > 0 {
+		if size := estimateEmbeddingRequestBytes(request); size > maxBytes {
+			if !provider.huggingFaceConfig.ChunkOversizedEmbeddingRequests || request.Input == nil || len(request.Input.Texts) < 2 {
+				return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, fmt.Errorf("embedding request body would be about %d bytes, exceeding the %d byte limit: send fewer or shorter inputs, or enable chunk_oversized_embedding_requests", size, maxBytes))
+			}
+			chunks, splitErr := splitEmbeddingRequestBySize(request, maxBytes)
+			if splitErr != nil {
+				return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, splitErr)
+			}
+			provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: embedding request body would be about %d bytes, sending it as %d requests", size, len(chunks)), nil))
+			return provider.embeddingByPromptGroups(ctx, key, request, chunks)
+		}
+	}
+
+	groups, splitErr := splitEmbeddingRequestByPromptName(request)
+	if splitErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, splitErr)
+	}
+	if groups != nil {
+		return provider.embeddingByPromptGroups(ctx, key, request, groups)
+	}
+
+	return provider.embedding(ctx, key, request)
+}
+
+// embeddingByPromptGroups sends one feature-extraction call per group (a prompt name, or a
+// size-bounded chunk), concurrently, and reassembles the embeddings in the order of the original
+// batch. Under the fail-fast policy the first failing group cancels the calls still in flight and
+// is returned right away; best-effort lets every call finish and then returns the first group's
+// error.
+func (provider *HuggingFaceProvider) embeddingByPromptGroups(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostEmbeddingRequest, groups []embeddingPromptGroup) (*schemas.BifrostEmbeddingResponse, *schemas.BifrostError) {
+	failFast := EmbeddingBatchErrorPolicy(provider.huggingFaceConfig.EmbeddingBatchErrorPolicy) != EmbeddingBatchErrorPolicyBestEffort
+
+	type groupResult struct {
+		index    int
+		response *schemas.BifrostEmbeddingResponse
+		err      *schemas.BifrostError
+	}
+	// Buffered so calls still finishing after a fail-fast return never block
+	results := make(chan groupResult, len(groups))
+	// Each group gets its own context so a failure can cancel its siblings without touching ctx
+	groupCtxs := make([]*schemas.BifrostContext, len(groups))
+	for i := range groups {
+		groupCtxs[i], _ = schemas.NewBifrostContextWithCancel(ctx)
+	}
+	defer func() {
+		for _, groupCtx := range groupCtxs {
+			groupCtx.Cancel()
+		}
+	}()
+	for i, group := range groups {
+		go func() {
+			response, err := provider.embedding(groupCtxs[i], key, group.request)
+			if err == nil && len(response.Data) != len(group.indices) {
+				err = providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, fmt.Errorf("expected %d embeddings, got %d", len(group.indices), len(response.Data)))
+			}
+			results <- groupResult{index: i, response: response, err: err}
+		}()
+	}
+
+	responses := make([]*schemas.BifrostEmbeddingResponse, len(groups))
+	errs := make([]*schemas.BifrostError, len(groups))
+	for range groups {
+		result := <-results
+		if result.err != nil && failFast {
+			return nil, result.err
+		}
+		responses[result.index], errs[result.index] = result.response, result.err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged := &schemas.BifrostEmbeddingResponse{
+		Data:   make([]schemas.EmbeddingData, len(request.Input.Texts)),
+		Model:  request.Model,
+		Object: "list",
+		Usage:  &schemas.BifrostLLMUsage{},
+	}
+	for groupIdx, group := range groups {
+		groupResponse := responses[groupIdx]
+		for i, data := range groupResponse.Data {
+			data.Index = group.indices[i]
+			merged.Data[data.Index] = data
+		}
+		if groupResponse.Usage != nil {
+			merged.Usage.PromptTokens += groupResponse.Usage.PromptTokens
+			merged.Usage.CompletionTokens += groupResponse.Usage.CompletionTokens
+			merged.Usage.TotalTokens += groupResponse.Usage.TotalTokens
+		}
+		// The merged usage is only exact if every group reported real counts
+		if merged.ExtraFields.UsageAccuracy != schemas.UsageAccuracyEstimated {
+			merged.ExtraFields.UsageAccuracy = groupResponse.ExtraFields.UsageAccuracy
+		}
+		// The groups ran side by side, so the batch took as long as the slowest one
+		merged.ExtraFields.Latency = max(merged.ExtraFields.Latency, groupResponse.ExtraFields.Latency)
+		merged.ExtraFields.ProviderResponseHeaders = groupResponse.ExtraFields.ProviderResponseHeaders
+		merged.ExtraFields.ModelHubURL = groupResponse.ExtraFields.ModelHubURL
+		merged.ExtraFields.EmbeddingDType = groupResponse.ExtraFields.EmbeddingDType
+		if groupCost := groupResponse.ExtraFields.EstimatedCost; groupCost != nil {
+			if merged.ExtraFields.EstimatedCost == nil {
+				merged.ExtraFields.EstimatedCost = &schemas.BifrostCost{}
+			}
+			merged.ExtraFields.EstimatedCost.InputTokensCost += groupCost.InputTokensCost
+			merged.ExtraFields.EstimatedCost.OutputTokensCost += groupCost.OutputTokensCost
+			merged.ExtraFields.EstimatedCost.TotalCost += groupCost.TotalCost
+		}
+		// Groups share the model and defaults; only the batch sizes add up
+		if groupConfig, ok := groupResponse.ExtraFields.EffectiveConfig.(*HuggingFaceEffectiveConfig); ok {
+			if mergedConfig, ok := merged.ExtraFields.EffectiveConfig.(*HuggingFaceEffectiveConfig); ok {
+				mergedConfig.BatchSize += groupConfig.BatchSize
+			} else {
+				merged.ExtraFields.EffectiveConfig = groupConfig
+			}
+		}
+	}
+	if merged.ExtraFields.ProviderResponseHeaders != nil {
+		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, merged.ExtraFields.ProviderResponseHeaders)
 	}
 
-	response := chatResponse.ToBifrostResponsesResponse()
-
-	return response, nil
+	return merged, nil
 }
 
-func (provider *HuggingFaceProvider) ResponsesStream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostResponsesRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
-	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.ResponsesStreamRequest); err != nil {
-		return nil, err
+func (provider *HuggingFaceProvider) embedding(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostEmbeddingRequest) (*schemas.BifrostEmbeddingResponse, *schemas.BifrostError) {
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	endpointURL, endpointErr := dedicatedEndpointURL(key, resolvedModel)
+	if endpointErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderCreateRequest, endpointErr)
 	}
-
-	ctx.SetValue(schemas.BifrostContextKeyIsResponsesToChatCompletionFallback, true)
-	return provider.ChatCompletionStream(
-		ctx,
-		postHookRunner,
-		postHookSpanFinalizer,
-		key,
-		request.ToChatRequest(),
-	)
-}
-
-func (provider *HuggingFaceProvider) Embedding(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostEmbeddingRequest) (*schemas.BifrostEmbeddingResponse, *schemas.BifrostError) {
-	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.EmbeddingRequest); err != nil {
-		return nil, err
+	// The routed model ID goes on a copy, leaving the caller's request as it was sent
+	embeddingRequest := *request
+	request = &embeddingRequest
+	request.Model = applyKeyInferenceProvider(key, resolvedModel, hfInference)
+	var appliedDefaults []string
+	if request.Model != resolvedModel && endpointURL == "" {
+		appliedDefaults = append(appliedDefaults, "inference_provider")
 	}
-
 	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
@@ -636,6 +1675,28 @@ func (provider *HuggingFaceProvider) Embedding(ctx *schemas.BifrostContext, key
 			},
 		}
 	}
+	// Dedicated endpoints take the same bare feature-extraction payload as serverless hf-inference
+	if endpointURL != "" {
+		inferenceProvider = hfInference
+		request.Model = string(hfInference) + "/" + modelName
+	}
+
+	// pooling only shapes how Bifrost decodes the response, so it is never sent upstream
+	pooling := EmbeddingPoolingMean
+	poolingSet := false
+	if request.Params != nil && request.Params.ExtraParams != nil {
+		if value, ok := request.Params.ExtraParams["pooling"].(string); ok {
+			delete(request.Params.ExtraParams, "pooling")
+			pooling = EmbeddingPooling(value)
+			poolingSet = true
+		}
+	}
+	if !poolingSet {
+		appliedDefaults = append(appliedDefaults, "pooling")
+	}
+	if inferenceProvider == hfInference && request.Params != nil {
+		provider.warnShortWaitForModelTimeout(ctx, request.Params.ExtraParams, modelName)
+	}
 
 	jsonBody, err := providerUtils.CheckContextAndGetRequestBody(
 		ctx,
@@ -648,22 +1709,34 @@ func (provider *HuggingFaceProvider) Embedding(ctx *schemas.BifrostContext, key
 		return nil, err
 	}
 
-	responseBody, latency, providerResponseHeaders, err := provider.completeRequestWithModelAliasCache(
-		ctx,
-		jsonBody,
-		key.Value.GetValue(),
-		false,
-		false,
-		inferenceProvider,
-		modelName,
-		"feature-extraction",
-		schemas.EmbeddingRequest,
-	)
+	release, slotErr := provider.acquireModelSlot(ctx, key, resolvedModel, endpointURL)
+	if slotErr != nil {
+		return nil, slotErr
+	}
+	var responseBody []byte
+	var latency time.Duration
+	var providerResponseHeaders map[string]string
+	if endpointURL != "" {
+		responseBody, latency, providerResponseHeaders, err = provider.completeRequest(ctx, jsonBody, dedicatedEndpointRequestURL(endpointURL, schemas.EmbeddingRequest), endpointURL, key, false, false, true)
+	} else {
+		responseBody, latency, providerResponseHeaders, err = provider.completeRequestWithModelAliasCache(
+			ctx,
+			jsonBody,
+			key,
+			false,
+			false,
+			inferenceProvider,
+			modelName,
+			"feature-extraction",
+			schemas.EmbeddingRequest,
+		)
+	}
+	release()
 	if providerResponseHeaders != nil {
 		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
 	}
 	if err != nil {
-		return nil, providerUtils.EnrichError(ctx, err, jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
+		return nil, providerUtils.EnrichError(ctx, explainGatedModelError(err, modelName), jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
 	}
 
 	// Handle raw request/response for tracking
@@ -680,15 +1753,51 @@ func (provider *HuggingFaceProvider) Embedding(ctx *schemas.BifrostContext, key
 		}
 	}
 
+	// feature-extraction can answer 200 with an error object (possibly alongside partial data)
+	if inlineErr := parseHuggingFaceInlineError(responseBody); inlineErr != nil {
+		return nil, providerUtils.EnrichError(ctx, inlineErr, jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
 	// Unmarshal directly to BifrostEmbeddingResponse with custom logic
-	bifrostResponse, convErr := UnmarshalHuggingFaceEmbeddingResponse(responseBody, request.Model)
+	bifrostResponse, convErr := unmarshalHuggingFaceEmbeddingResponse(responseBody, request.Model, pooling)
+	if convErr == nil {
+		convErr = alignEmbeddingsToInputs(bifrostResponse.Data, embeddingInputCount(request.Input))
+	}
+	if convErr == nil && request.Params != nil && request.Params.Dimensions != nil {
+		truncateEmbeddingDimensions(bifrostResponse.Data, *request.Params.Dimensions)
+	}
+	if convErr == nil && request.Params != nil && request.Params.EncodingFormat != nil && EncodingType(*request.Params.EncodingFormat) == EncodingTypeBase64 {
+		encodeEmbeddingsBase64(bifrostResponse.Data)
+	}
 	if convErr != nil {
 		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, convErr), jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
 	}
 
+	if usage := reportedEmbeddingUsage(bifrostResponse.Usage, providerResponseHeaders); usage != nil {
+		bifrostResponse.Usage = usage
+		bifrostResponse.ExtraFields.UsageAccuracy = schemas.UsageAccuracyExact
+	} else {
+		bifrostResponse.Usage = estimateEmbeddingUsage(request.Input, provider.requestTokenCounter(ctx, key, modelName))
+		bifrostResponse.ExtraFields.UsageAccuracy = schemas.UsageAccuracyEstimated
+	}
+	bifrostResponse.ExtraFields.EstimatedCost = estimatedCost(key, inferenceProvider, modelName, bifrostResponse.Usage)
+	bifrostResponse.ExtraFields.ModelHubURL = provider.modelHubURL(modelName)
+	// Bare arrays carry no dtype; they are plain floats
+	if bifrostResponse.ExtraFields.EmbeddingDType == "" {
+		bifrostResponse.ExtraFields.EmbeddingDType = string(EmbeddingDTypeFloat32)
+	}
+	bifrostResponse.ExtraFields.EffectiveConfig = provider.effectiveConfig(HuggingFaceEffectiveConfig{
+		Model:             modelName,
+		InferenceProvider: string(inferenceProvider),
+		Task:              "feature-extraction",
+		AppliedDefaults:   appliedDefaults,
+		BatchSize:         embeddingInputCount(request.Input),
+	})
+
 	// Set ExtraFields
 	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
 	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
 
 	// Set raw response if enabled
 	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
@@ -703,13 +1812,107 @@ func (provider *HuggingFaceProvider) Embedding(ctx *schemas.BifrostContext, key
 	return bifrostResponse, nil
 }
 
+// maxConcurrentEmbeddingStreamChunks bounds how many EmbeddingStream sub-requests are in flight at once.
+const maxConcurrentEmbeddingStreamChunks = 8
+
+// EmbeddingStream embeds a large batch as concurrent sub-requests of at most
+// HuggingFaceConfig.EmbeddingStreamChunkSize inputs, emitting each sub-request's embeddings as
+// soon as it completes. Chunks arrive in completion order, but every EmbeddingData keeps the
+// index of its input in the original batch. The first failing chunk cancels the rest and is
+// sent as the final message; the channel closes after the last chunk or that error.
+func (provider *HuggingFaceProvider) EmbeddingStream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostEmbeddingRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.EmbeddingRequest); err != nil {
+		return nil, err
+	}
+
+	if inputErr := resolveAmbiguousEmbeddingInput(request.Input, provider.huggingFaceConfig.MergeEmbeddingTextInputs); inputErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, inputErr)
+	}
+	chunkSize := provider.huggingFaceConfig.EmbeddingStreamChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultEmbeddingStreamChunkSize
+	}
+	chunks, splitErr := splitEmbeddingRequestIntoChunks(request, chunkSize)
+	if splitErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, splitErr)
+	}
+
+	type chunkResult struct {
+		index    int
+		response *schemas.BifrostEmbeddingResponse
+		err      *schemas.BifrostError
+	}
+	// Buffered so chunks still finishing after an error never block
+	results := make(chan chunkResult, len(chunks))
+	chunkCtxs := make([]*schemas.BifrostContext, len(chunks))
+	for i := range chunks {
+		chunkCtxs[i], _ = schemas.NewBifrostContextWithCancel(ctx)
+	}
+	slots := make(chan struct{}, maxConcurrentEmbeddingStreamChunks)
+	for i, chunk := range chunks {
+		go func() {
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-chunkCtxs[i].Done():
+				results <- chunkResult{index: i, err: providerUtils.NewBifrostOperationError(schemas.ErrRequestCancelled, chunkCtxs[i].Err())}
+				return
+			}
+			response, err := provider.embedding(chunkCtxs[i], key, chunk.request)
+			if err == nil && len(response.Data) != len(chunk.indices) {
+				err = providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, fmt.Errorf("expected %d embeddings, got %d", len(chunk.indices), len(response.Data)))
+			}
+			results <- chunkResult{index: i, response: response, err: err}
+		}()
+	}
+
+	responseChan := make(chan *schemas.BifrostStreamChunk, schemas.DefaultStreamBufferSize)
+	go func() {
+		defer providerUtils.EnsureStreamFinalizerCalled(ctx, postHookSpanFinalizer)
+		defer close(responseChan)
+		defer func() {
+			for _, chunkCtx := range chunkCtxs {
+				chunkCtx.Cancel()
+			}
+		}()
+
+		startTime := time.Now()
+		for chunkIndex := range chunks {
+			result := <-results
+			if result.err != nil {
+				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+				providerUtils.ProcessAndSendBifrostError(ctx, postHookRunner, result.err, responseChan, provider.logger, postHookSpanFinalizer)
+				return
+			}
+
+			response := result.response
+			for i := range response.Data {
+				response.Data[i].Index = chunks[result.index].indices[i]
+			}
+			response.ExtraFields.ChunkIndex = chunkIndex
+			if chunkIndex == len(chunks)-1 {
+				response.ExtraFields.Latency = time.Since(startTime).Milliseconds()
+				ctx.SetValue(schemas.BifrostContextKeyStreamEndIndicator, true)
+			}
+			providerUtils.ProcessAndSendResponse(ctx, postHookRunner, &schemas.BifrostResponse{EmbeddingResponse: response}, responseChan, postHookSpanFinalizer)
+		}
+	}()
+
+	return responseChan, nil
+}
+
 func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostSpeechRequest) (*schemas.BifrostSpeechResponse, *schemas.BifrostError) {
 	// Check if Speech is allowed for this provider
 	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.SpeechRequest); err != nil {
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	// The converters read the model off the request, so they get a copy naming the resolved one
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	resolvedRequest := *request
+	resolvedRequest.Model = resolvedModel
+	request = &resolvedRequest
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(resolvedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -720,10 +1923,15 @@ func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key sch
 		}
 	}
 
+	// hf-inference takes the text under "inputs" and answers with raw audio bytes
+	isHFInferenceSpeechRequest := inferenceProvider == hfInference
 	jsonData, err := providerUtils.CheckContextAndGetRequestBody(
 		ctx,
 		request,
 		func() (providerUtils.RequestBodyWithExtraParams, error) {
+			if isHFInferenceSpeechRequest {
+				return ToHuggingFaceInferenceSpeechRequest(request)
+			}
 			return ToHuggingFaceSpeechRequest(request)
 		})
 	if err != nil {
@@ -733,7 +1941,7 @@ func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key sch
 	responseBody, latency, providerResponseHeaders, err := provider.completeRequestWithModelAliasCache(
 		ctx,
 		jsonData,
-		key.Value.GetValue(),
+		key,
 		false,
 		false,
 		inferenceProvider,
@@ -748,6 +1956,30 @@ func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key sch
 		return nil, providerUtils.EnrichError(ctx, err, jsonData, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
 	}
 
+	if isHFInferenceSpeechRequest {
+		// A JSON error object can still arrive with a 200 status, e.g. while the model is loading
+		if inlineErr := parseHuggingFaceInlineError(responseBody); inlineErr != nil {
+			return nil, providerUtils.EnrichError(ctx, inlineErr, jsonData, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+		}
+		if len(responseBody) == 0 {
+			return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, fmt.Errorf("empty audio response from hf-inference")), jsonData, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
+		}
+
+		bifrostResponse := &schemas.BifrostSpeechResponse{
+			Audio: responseBody,
+		}
+		bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
+		bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+		bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
+		if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) {
+			var rawRequest interface{}
+			if unmarshalErr := sonic.Unmarshal(jsonData, &rawRequest); unmarshalErr == nil {
+				bifrostResponse.ExtraFields.RawRequest = rawRequest
+			}
+		}
+		return bifrostResponse, nil
+	}
+
 	response := acquireHuggingFaceSpeechResponse()
 	defer releaseHuggingFaceSpeechResponse(response)
 
@@ -759,7 +1991,7 @@ func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key sch
 	}
 
 	// Download the audio file from the URL
-	audioData, downloadErr := provider.downloadAudioFromURL(ctx, response.Audio.URL)
+	audioData, downloadErr := provider.downloadFromURL(ctx, response.Audio.URL, "audio")
 	if downloadErr != nil {
 		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, downloadErr), jsonData, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
 	}
@@ -772,6 +2004,7 @@ func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key sch
 	// Set ExtraFields
 	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
 	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
 	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
 		bifrostResponse.ExtraFields.RawResponse = rawResponse
 	}
@@ -785,13 +2018,240 @@ func (provider *HuggingFaceProvider) Speech(ctx *schemas.BifrostContext, key sch
 }
 
 // Rerank is not supported by the HuggingFace provider.
+// Rerank scores documents against the query. Cross-encoder rerankers (e.g. BAAI/bge-reranker-v2-m3)
+// use the text-ranking pipeline and bi-encoders the sentence-similarity pipeline, whichever the
+// model's hf-inference mapping serves; models on a key's Dedicated Inference Endpoint are sent
+// to its TEI /rerank route. Only hf-inference serves these tasks, so models routed to other
+// inference providers are rejected.
 func (provider *HuggingFaceProvider) Rerank(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostRerankRequest) (*schemas.BifrostRerankResponse, *schemas.BifrostError) {
-	return nil, providerUtils.NewUnsupportedOperationError(schemas.RerankRequest, provider.GetProviderKey())
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.RerankRequest); err != nil {
+		return nil, err
+	}
+
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	endpointURL, endpointErr := dedicatedEndpointURL(key, resolvedModel)
+	if endpointErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrProviderCreateRequest, endpointErr)
+	}
+	routedModel := resolvedModel
+	var appliedDefaults []string
+	if endpointURL == "" {
+		routedModel = applyKeyInferenceProvider(key, resolvedModel, hfInference)
+		if routedModel != resolvedModel {
+			appliedDefaults = append(appliedDefaults, "inference_provider")
+		}
+	}
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(routedModel)
+	if nameErr != nil {
+		return nil, &schemas.BifrostError{
+			IsBifrostError: false,
+			Error: &schemas.ErrorField{
+				Message: nameErr.Error(),
+				Error:   nameErr,
+			},
+		}
+	}
+
+	// TEI-backed dedicated endpoints rerank with text-ranking; serverless models say which task they serve
+	task := "text-ranking"
+	if endpointURL != "" {
+		inferenceProvider = hfInference
+	} else {
+		if inferenceProvider != hfInference {
+			return nil, providerUtils.NewUnsupportedOperationError(schemas.RerankRequest, provider.GetProviderKey())
+		}
+		var taskErr *schemas.BifrostError
+		if task, taskErr = provider.rerankTask(ctx, modelName); taskErr != nil {
+			return nil, taskErr
+		}
+	}
+	if request.Params != nil {
+		provider.warnShortWaitForModelTimeout(ctx, request.Params.ExtraParams, modelName)
+	}
+
+	jsonBody, err := providerUtils.CheckContextAndGetRequestBody(
+		ctx,
+		request,
+		func() (providerUtils.RequestBodyWithExtraParams, error) {
+			return ToHuggingFaceRerankRequest(request, task)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	var responseBody []byte
+	var latency time.Duration
+	var providerResponseHeaders map[string]string
+	if endpointURL != "" {
+		responseBody, latency, providerResponseHeaders, err = provider.completeRequest(ctx, jsonBody, dedicatedEndpointRequestURL(endpointURL, schemas.RerankRequest), endpointURL, key, false, false, false)
+	} else {
+		responseBody, latency, providerResponseHeaders, err = provider.completeRequestWithModelAliasCache(
+			ctx,
+			jsonBody,
+			key,
+			false,
+			false,
+			inferenceProvider,
+			modelName,
+			task,
+			schemas.RerankRequest,
+		)
+	}
+	if providerResponseHeaders != nil {
+		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
+	}
+	if err != nil {
+		return nil, providerUtils.EnrichError(ctx, err, jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+	if inlineErr := parseHuggingFaceInlineError(responseBody); inlineErr != nil {
+		return nil, providerUtils.EnrichError(ctx, inlineErr, jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
+	var topN *int
+	returnDocuments := false
+	if request.Params != nil {
+		topN = request.Params.TopN
+		returnDocuments = request.Params.ReturnDocuments != nil && *request.Params.ReturnDocuments
+	}
+	bifrostResponse, convErr := UnmarshalHuggingFaceRerankResponse(responseBody, task, request.Documents, topN, returnDocuments)
+	if convErr != nil {
+		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, convErr), jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
+	bifrostResponse.Model = request.Model
+	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
+	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
+	bifrostResponse.ExtraFields.ModelHubURL = provider.modelHubURL(modelName)
+	bifrostResponse.ExtraFields.EffectiveConfig = provider.effectiveConfig(HuggingFaceEffectiveConfig{
+		Model:             modelName,
+		InferenceProvider: string(inferenceProvider),
+		Task:              task,
+		AppliedDefaults:   appliedDefaults,
+		BatchSize:         len(request.Documents),
+	})
+
+	// Set raw request/response if enabled
+	if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) {
+		var rawRequest interface{}
+		if err := sonic.Unmarshal(jsonBody, &rawRequest); err != nil {
+			rawRequest = string(jsonBody)
+		}
+		bifrostResponse.ExtraFields.RawRequest = rawRequest
+	}
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		var rawResponse interface{}
+		if err := sonic.Unmarshal(responseBody, &rawResponse); err != nil {
+			rawResponse = string(responseBody)
+		}
+		bifrostResponse.ExtraFields.RawResponse = rawResponse
+	}
+
+	return bifrostResponse, nil
 }
 
-// OCR is not supported by the Huggingface provider.
+// OCR captions an image with an hf-inference image-to-text model (e.g.
+// Salesforce/blip-image-captioning-large), returning the generated text as a single page.
 func (provider *HuggingFaceProvider) OCR(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostOCRRequest) (*schemas.BifrostOCRResponse, *schemas.BifrostError) {
-	return nil, providerUtils.NewUnsupportedOperationError(schemas.OCRRequest, provider.GetProviderKey())
+	if err := providerUtils.CheckOperationAllowed(schemas.HuggingFace, provider.customProviderConfig, schemas.OCRRequest); err != nil {
+		return nil, err
+	}
+
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	routedModel := applyKeyInferenceProvider(key, resolvedModel, hfInference)
+	var appliedDefaults []string
+	if routedModel != resolvedModel {
+		appliedDefaults = append(appliedDefaults, "inference_provider")
+	}
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(routedModel)
+	if nameErr != nil {
+		return nil, &schemas.BifrostError{
+			IsBifrostError: false,
+			Error: &schemas.ErrorField{
+				Message: nameErr.Error(),
+				Error:   nameErr,
+			},
+		}
+	}
+	if inferenceProvider != hfInference {
+		return nil, providerUtils.NewUnsupportedOperationError(schemas.OCRRequest, provider.GetProviderKey())
+	}
+
+	imageURL, inputErr := ocrImageURL(request)
+	if inputErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, inputErr)
+	}
+	image, imageErr := provider.imageBytes(ctx, imageURL)
+	if imageErr != nil {
+		return nil, providerUtils.NewBifrostOperationError(schemas.ErrRequestBodyConversion, imageErr)
+	}
+
+	jsonBody, err := providerUtils.CheckContextAndGetRequestBody(
+		ctx,
+		request,
+		func() (providerUtils.RequestBodyWithExtraParams, error) {
+			return ToHuggingFaceImageToTextRequest(request, image), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, latency, providerResponseHeaders, err := provider.completeRequestWithModelAliasCache(
+		ctx,
+		jsonBody,
+		key,
+		false,
+		true,
+		inferenceProvider,
+		modelName,
+		"image-to-text",
+		schemas.OCRRequest,
+	)
+	if providerResponseHeaders != nil {
+		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
+	}
+	if err != nil {
+		return nil, providerUtils.EnrichError(ctx, explainGatedModelError(err, modelName), jsonBody, nil, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+	if inlineErr := parseHuggingFaceInlineError(responseBody); inlineErr != nil {
+		return nil, providerUtils.EnrichError(ctx, inlineErr, jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
+	bifrostResponse, convErr := UnmarshalHuggingFaceImageToTextResponse(responseBody, len(image))
+	if convErr != nil {
+		return nil, providerUtils.EnrichError(ctx, providerUtils.NewBifrostOperationError(schemas.ErrProviderResponseDecode, convErr), jsonBody, responseBody, provider.sendBackRawRequest, provider.sendBackRawResponse)
+	}
+
+	bifrostResponse.Model = request.Model
+	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
+	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
+	bifrostResponse.ExtraFields.ModelHubURL = provider.modelHubURL(modelName)
+	bifrostResponse.ExtraFields.EffectiveConfig = provider.effectiveConfig(HuggingFaceEffectiveConfig{
+		Model:             modelName,
+		InferenceProvider: string(inferenceProvider),
+		Task:              "image-to-text",
+		AppliedDefaults:   appliedDefaults,
+		BatchSize:         1,
+	})
+
+	// Set raw request/response if enabled
+	if providerUtils.ShouldSendBackRawRequest(ctx, provider.sendBackRawRequest) {
+		var rawRequest interface{}
+		if err := sonic.Unmarshal(jsonBody, &rawRequest); err != nil {
+			rawRequest = string(jsonBody)
+		}
+		bifrostResponse.ExtraFields.RawRequest = rawRequest
+	}
+	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
+		var rawResponse interface{}
+		if err := sonic.Unmarshal(responseBody, &rawResponse); err != nil {
+			rawResponse = string(responseBody)
+		}
+		bifrostResponse.ExtraFields.RawResponse = rawResponse
+	}
+
+	return bifrostResponse, nil
 }
 
 func (provider *HuggingFaceProvider) SpeechStream(ctx *schemas.BifrostContext, postHookRunner schemas.PostHookRunner, postHookSpanFinalizer func(context.Context), key schemas.Key, request *schemas.BifrostSpeechRequest) (chan *schemas.BifrostStreamChunk, *schemas.BifrostError) {
@@ -804,7 +2264,66 @@ func (provider *HuggingFaceProvider) Transcription(ctx *schemas.BifrostContext,
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	if windows := provider.transcriptionWindows(request); windows != nil {
+		return provider.transcriptionByWindows(ctx, key, request, windows)
+	}
+	return provider.transcription(ctx, key, request)
+}
+
+// transcriptionByWindows transcribes each window of a long recording and stitches the results.
+func (provider *HuggingFaceProvider) transcriptionByWindows(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostTranscriptionRequest, windows []transcriptionWindow) (*schemas.BifrostTranscriptionResponse, *schemas.BifrostError) {
+	merged := &schemas.BifrostTranscriptionResponse{
+		Duration: schemas.Ptr(windows[len(windows)-1].end),
+	}
+	texts := make([]string, 0, len(windows))
+
+	for i, window := range windows {
+		windowRequest := *request
+		windowRequest.Input = &schemas.TranscriptionInput{File: window.audio, Filename: request.Input.Filename}
+		windowResponse, err := provider.transcription(ctx, key, &windowRequest)
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, windowResponse.Text)
+
+		// Each stretch of audio is heard by two windows; keep timestamps from the one that heard
+		// it further from its edge, switching over halfway through the overlap
+		from, to := window.start, window.end
+		if i > 0 {
+			from = (window.start + windows[i-1].end) / 2
+		}
+		if i < len(windows)-1 {
+			to = (windows[i+1].start + window.end) / 2
+		}
+		for _, segment := range windowResponse.Segments {
+			segment.Start += window.start
+			segment.End += window.start
+			if segment.Start < from || segment.Start >= to {
+				continue
+			}
+			segment.ID = len(merged.Segments)
+			merged.Segments = append(merged.Segments, segment)
+		}
+
+		if merged.Language == nil {
+			merged.Language = windowResponse.Language
+		}
+		merged.ExtraFields.Latency += windowResponse.ExtraFields.Latency
+		merged.ExtraFields.ProviderResponseHeaders = windowResponse.ExtraFields.ProviderResponseHeaders
+		merged.ExtraFields.RateLimit = windowResponse.ExtraFields.RateLimit
+	}
+
+	merged.Text = stitchTranscripts(texts)
+	return merged, nil
+}
+
+func (provider *HuggingFaceProvider) transcription(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostTranscriptionRequest) (*schemas.BifrostTranscriptionResponse, *schemas.BifrostError) {
+	// The converters read the model off the request, so they get a copy naming the resolved one
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	resolvedRequest := *request
+	resolvedRequest.Model = resolvedModel
+	request = &resolvedRequest
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(resolvedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -840,7 +2359,7 @@ func (provider *HuggingFaceProvider) Transcription(ctx *schemas.BifrostContext,
 	responseBody, latency, providerResponseHeaders, err := provider.completeRequestWithModelAliasCache(
 		ctx,
 		jsonData,
-		key.Value.GetValue(),
+		key,
 		isHFInferenceAudioRequest,
 		false,
 		inferenceProvider,
@@ -885,6 +2404,7 @@ func (provider *HuggingFaceProvider) Transcription(ctx *schemas.BifrostContext,
 	// Set ExtraFields
 	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
 	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
 	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
 		bifrostResponse.ExtraFields.RawResponse = rawResponse
 	}
@@ -908,7 +2428,12 @@ func (provider *HuggingFaceProvider) ImageGeneration(ctx *schemas.BifrostContext
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	// The converters read the model off the request, so they get a copy naming the resolved one
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	resolvedRequest := *request
+	resolvedRequest.Model = resolvedModel
+	request = &resolvedRequest
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(resolvedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -919,6 +2444,10 @@ func (provider *HuggingFaceProvider) ImageGeneration(ctx *schemas.BifrostContext
 		}
 	}
 
+	if inferenceProvider == hfInference && request.Params != nil {
+		provider.warnShortWaitForModelTimeout(ctx, request.Params.ExtraParams, modelName)
+	}
+
 	jsonBody, err := providerUtils.CheckContextAndGetRequestBody(
 		ctx,
 		request,
@@ -933,7 +2462,7 @@ func (provider *HuggingFaceProvider) ImageGeneration(ctx *schemas.BifrostContext
 	responseBody, latency, providerResponseHeaders, err := provider.completeRequestWithModelAliasCache(
 		ctx,
 		jsonBody,
-		key.Value.GetValue(),
+		key,
 		false,
 		true,
 		inferenceProvider,
@@ -973,6 +2502,7 @@ func (provider *HuggingFaceProvider) ImageGeneration(ctx *schemas.BifrostContext
 	// Set ExtraFields
 	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
 	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
 
 	// Set raw response if enabled
 	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
@@ -994,7 +2524,12 @@ func (provider *HuggingFaceProvider) ImageGenerationStream(ctx *schemas.BifrostC
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	// The converters read the model off the request, so they get a copy naming the resolved one
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	resolvedRequest := *request
+	resolvedRequest.Model = resolvedModel
+	request = &resolvedRequest
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(resolvedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -1014,13 +2549,13 @@ func (provider *HuggingFaceProvider) ImageGenerationStream(ctx *schemas.BifrostC
 
 	// Set headers
 	headers := map[string]string{
-		"Content-Type":  "application/json",
+		"Content-Type":  provider.jsonContentType(),
 		"Accept":        "text/event-stream",
 		"Cache-Control": "no-cache",
 	}
 
-	if value := key.Value.GetValue(); value != "" {
-		headers["Authorization"] = "Bearer " + value
+	if value := bearerAuthHeader(key.Value.GetValue()); value != "" {
+		headers["Authorization"] = value
 	}
 
 	jsonBody, bifrostErr := providerUtils.CheckContextAndGetRequestBody(
@@ -1046,10 +2581,11 @@ func (provider *HuggingFaceProvider) ImageGenerationStream(ctx *schemas.BifrostC
 	// Setup request
 	req.Header.SetMethod(http.MethodPost)
 	req.SetRequestURI(url)
-	req.Header.SetContentType("application/json")
+	req.Header.SetContentType(provider.jsonContentType())
 
 	// Set any extra headers from network config
 	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
 
 	// Set headers
 	for key, value := range headers {
@@ -1061,7 +2597,7 @@ func (provider *HuggingFaceProvider) ImageGenerationStream(ctx *schemas.BifrostC
 	}
 
 	// Make the request
-	err := provider.streamingClient.Do(req, resp)
+	err := provider.clientsForKey(key).streamingClient.Do(req, resp)
 	if err != nil {
 		defer providerUtils.ReleaseStreamingResponse(resp)
 		if errors.Is(err, context.Canceled) {
@@ -1188,7 +2724,7 @@ func (provider *HuggingFaceProvider) ImageGenerationStream(ctx *schemas.BifrostC
 			// Parse fal-ai response
 			var response HuggingFaceFalAIImageStreamResponse
 			if err := sonic.UnmarshalString(jsonData, &response); err != nil {
-				provider.logger.Warn(fmt.Sprintf("Failed to parse fal-ai stream response: %v", err))
+				provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("Failed to parse fal-ai stream response: %v", err), resp))
 				continue
 			}
 			// Extract images from response (handles both Data.Images and top-level Images)
@@ -1277,7 +2813,12 @@ func (provider *HuggingFaceProvider) ImageEdit(ctx *schemas.BifrostContext, key
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	// The converters read the model off the request, so they get a copy naming the resolved one
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	resolvedRequest := *request
+	resolvedRequest.Model = resolvedModel
+	request = &resolvedRequest
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(resolvedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -1305,12 +2846,12 @@ func (provider *HuggingFaceProvider) ImageEdit(ctx *schemas.BifrostContext, key
 	}
 
 	// Build URL for image edit
-	url, urlErr := provider.getInferenceProviderRouteURL(ctx, inferenceProvider, modelName, schemas.ImageEditRequest)
+	url, urlErr := provider.getInferenceProviderRouteURL(ctx, inferenceProvider, modelName, schemas.ImageEditRequest, "")
 	if urlErr != nil {
 		return nil, providerUtils.NewUnsupportedOperationError(schemas.ImageEditRequest, provider.GetProviderKey())
 	}
 
-	responseBody, latency, providerResponseHeaders, err := provider.completeRequest(ctx, jsonBody, url, key.Value.GetValue(), false, true)
+	responseBody, latency, providerResponseHeaders, err := provider.completeRequest(ctx, jsonBody, url, modelDeployment("", inferenceProvider, modelName), key, false, true, false)
 	if providerResponseHeaders != nil {
 		ctx.SetValue(schemas.BifrostContextKeyProviderResponseHeaders, providerResponseHeaders)
 	}
@@ -1343,6 +2884,7 @@ func (provider *HuggingFaceProvider) ImageEdit(ctx *schemas.BifrostContext, key
 	// Set ExtraFields
 	bifrostResponse.ExtraFields.Latency = latency.Milliseconds()
 	bifrostResponse.ExtraFields.ProviderResponseHeaders = providerResponseHeaders
+	bifrostResponse.ExtraFields.RateLimit = parseRateLimitHeaders(providerResponseHeaders)
 
 	// Set raw response if enabled
 	if providerUtils.ShouldSendBackRawResponse(ctx, provider.sendBackRawResponse) {
@@ -1364,7 +2906,12 @@ func (provider *HuggingFaceProvider) ImageEditStream(ctx *schemas.BifrostContext
 		return nil, err
 	}
 
-	inferenceProvider, modelName, nameErr := splitIntoModelProvider(request.Model)
+	// The converters read the model off the request, so they get a copy naming the resolved one
+	resolvedModel := provider.resolveModelAlias(ctx, key, request.Model)
+	resolvedRequest := *request
+	resolvedRequest.Model = resolvedModel
+	request = &resolvedRequest
+	inferenceProvider, modelName, nameErr := splitIntoModelProvider(resolvedModel)
 	if nameErr != nil {
 		return nil, &schemas.BifrostError{
 			IsBifrostError: false,
@@ -1384,8 +2931,8 @@ func (provider *HuggingFaceProvider) ImageEditStream(ctx *schemas.BifrostContext
 
 	var authHeader map[string]string
 
-	if value := key.Value.GetValue(); value != "" {
-		authHeader = map[string]string{"Authorization": "Bearer " + value}
+	if value := bearerAuthHeader(key.Value.GetValue()); value != "" {
+		authHeader = map[string]string{"Authorization": value}
 	}
 
 	// Build streaming URL - append /stream to the fal-ai edit route, honoring path overrides
@@ -1394,7 +2941,7 @@ func (provider *HuggingFaceProvider) ImageEditStream(ctx *schemas.BifrostContext
 
 	// Set headers
 	headers := map[string]string{
-		"Content-Type":  "application/json",
+		"Content-Type":  provider.jsonContentType(),
 		"Accept":        "text/event-stream",
 		"Cache-Control": "no-cache",
 	}
@@ -1425,10 +2972,11 @@ func (provider *HuggingFaceProvider) ImageEditStream(ctx *schemas.BifrostContext
 	// Setup request
 	req.Header.SetMethod(http.MethodPost)
 	req.SetRequestURI(streamURL)
-	req.Header.SetContentType("application/json")
+	req.Header.SetContentType(provider.jsonContentType())
 
 	// Set any extra headers from network config
 	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
 
 	// Set headers
 	for key, value := range headers {
@@ -1440,7 +2988,7 @@ func (provider *HuggingFaceProvider) ImageEditStream(ctx *schemas.BifrostContext
 	}
 
 	// Make the request
-	err := provider.streamingClient.Do(req, resp)
+	err := provider.clientsForKey(key).streamingClient.Do(req, resp)
 	if err != nil {
 		defer providerUtils.ReleaseStreamingResponse(resp)
 		if errors.Is(err, context.Canceled) {
@@ -1567,7 +3115,7 @@ func (provider *HuggingFaceProvider) ImageEditStream(ctx *schemas.BifrostContext
 			// Parse fal-ai response
 			var response HuggingFaceFalAIImageStreamResponse
 			if err := sonic.UnmarshalString(jsonData, &response); err != nil {
-				provider.logger.Warn(fmt.Sprintf("Failed to parse fal-ai stream response: %v", err))
+				provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("Failed to parse fal-ai stream response: %v", err), resp))
 				continue
 			}
 			// Extract images from response (handles both Data.Images and top-level Images)
@@ -25,6 +25,7 @@ func TestHuggingface(t *testing.T) {
 
 	testConfig := llmtests.ComprehensiveTestConfig{
 		Provider:             schemas.HuggingFace,
+		TextModel:            "groq/meta-llama/Llama-3.3-70B-Instruct",
 		ChatModel:            "groq/meta-llama/Llama-3.3-70B-Instruct",
 		VisionModel:          "cohere/CohereLabs/aya-vision-32b",
 		EmbeddingModel:       "sambanova/intfloat/e5-mistral-7b-instruct",
@@ -37,48 +38,62 @@ func TestHuggingface(t *testing.T) {
 		ImageGenerationModel: "fal-ai/fal-ai/flux/dev",
 		ImageEditModel:       "fal-ai/fal-ai/flux-2/edit",
 		Scenarios: llmtests.TestScenarios{
-			TextCompletion:        false,
-			TextCompletionStream:  false,
-			SimpleChat:            true,
-			CompletionStream:      true,
-			MultiTurnConversation: true,
-			ToolCalls:             true,
-			ToolCallsStreaming:    true,
-			MultipleToolCalls:     false,
-			End2EndToolCalling:    true,
-			AutomaticFunctionCall: true,
-			ImageURL:              true,
-			ImageBase64:           true,
-			MultipleImages:        true,
-			CompleteEnd2End:       true,
-			Embedding:             false,
-			Transcription:         true,
-			TranscriptionStream:   false,
-			SpeechSynthesis:       true,
-			SpeechSynthesisStream: false,
-			Reasoning:             true,
-			ListModels:            true,
-			BatchCreate:           false,
-			BatchList:             false,
-			BatchRetrieve:         false,
-			BatchCancel:           false,
-			BatchResults:          false,
-			FileUpload:            false,
-			FileList:              false,
-			FileRetrieve:          false,
-			FileDelete:            false,
-			FileContent:           false,
-			FileBatchInput:        false,
-			ImageGeneration:       true,
-			ImageGenerationStream: true,
-			ImageEdit:             true,
-			ImageEditStream:       true,
+			TextCompletion:             true,
+			TextCompletionStream:       true,
+			SimpleChat:                 true,
+			CompletionStream:           true,
+			MultiTurnConversation:      true,
+			ToolCalls:                  true,
+			ToolCallsStreaming:         true,
+			MultipleToolCalls:          true,
+			MultipleToolCallsStreaming: true,
+			End2EndToolCalling:         true,
+			AutomaticFunctionCall:      true,
+			ImageURL:                   true,
+			ImageBase64:                true,
+			MultipleImages:             true,
+			CompleteEnd2End:            true,
+			Embedding:                  false,
+			Transcription:              true,
+			TranscriptionStream:        false,
+			SpeechSynthesis:            true,
+			SpeechSynthesisStream:      false,
+			Reasoning:                  true,
+			ListModels:                 true,
+			BatchCreate:                false,
+			BatchList:                  false,
+			BatchRetrieve:              false,
+			BatchCancel:                false,
+			BatchResults:               false,
+			FileUpload:                 false,
+			FileList:                   false,
+			FileRetrieve:               false,
+			FileDelete:                 false,
+			FileContent:                false,
+			FileBatchInput:             false,
+			ImageGeneration:            true,
+			ImageGenerationStream:      true,
+			ImageEdit:                  true,
+			ImageEditStream:            true,
 		},
 	}
 
 	t.Run("HuggingFaceTests", func(t *testing.T) {
 		llmtests.RunAllComprehensiveTests(t, client, ctx, testConfig)
 	})
+
+	// hf-inference serves text-to-speech as raw audio bytes rather than fal-ai's audio URL envelope
+	hfInferenceSpeechConfig := llmtests.ComprehensiveTestConfig{
+		Provider:             schemas.HuggingFace,
+		SpeechSynthesisModel: "hf-inference/facebook/mms-tts-eng",
+		Scenarios: llmtests.TestScenarios{
+			SpeechSynthesis: true,
+		},
+	}
+
+	t.Run("HuggingFaceHFInferenceSpeechTests", func(t *testing.T) {
+		llmtests.RunAllComprehensiveTests(t, client, ctx, hfInferenceSpeechConfig)
+	})
 }
 
 func TestUnmarshalHuggingFaceEmbeddingResponsePreservesPrecision(t *testing.T) {
@@ -3,6 +3,7 @@ package huggingface
 import (
 	"encoding/base64"
 	"fmt"
+	"maps"
 	"net/http"
 	"strconv"
 	"strings"
@@ -125,7 +126,44 @@ func ToHuggingFaceImageGenerationRequest(bifrostReq *schemas.BifrostImageGenerat
 			Inputs: bifrostReq.Input.Prompt,
 		}
 		if bifrostReq.Params != nil {
-			req.ExtraParams = bifrostReq.Params.ExtraParams
+			params := &HuggingFaceHFInferenceImageGenerationParameters{
+				NegativePrompt:    bifrostReq.Params.NegativePrompt,
+				NumInferenceSteps: bifrostReq.Params.NumInferenceSteps,
+				Seed:              bifrostReq.Params.Seed,
+			}
+			if bifrostReq.Params.Size != nil && strings.ToLower(*bifrostReq.Params.Size) != "auto" {
+				width, height, err := parseImageSize(*bifrostReq.Params.Size)
+				if err != nil {
+					return nil, err
+				}
+				params.TargetSize = &HuggingFaceImageTargetSize{Width: width, Height: height}
+			}
+
+			// The task parameters are nested under "parameters"; anything else stays top-level
+			if bifrostReq.Params.ExtraParams != nil {
+				req.ExtraParams = maps.Clone(bifrostReq.Params.ExtraParams)
+				if v, ok := schemas.SafeExtractIntPointer(req.ExtraParams["num_inference_steps"]); ok {
+					delete(req.ExtraParams, "num_inference_steps")
+					if params.NumInferenceSteps == nil {
+						params.NumInferenceSteps = v
+					}
+				}
+				if v, ok := schemas.SafeExtractFloat64Pointer(req.ExtraParams["guidance_scale"]); ok {
+					delete(req.ExtraParams, "guidance_scale")
+					params.GuidanceScale = v
+				}
+				if v, ok := schemas.SafeExtractStringPointer(req.ExtraParams["negative_prompt"]); ok {
+					delete(req.ExtraParams, "negative_prompt")
+					if params.NegativePrompt == nil {
+						params.NegativePrompt = v
+					}
+				}
+				req.Options = extractInferenceOptions(req.ExtraParams)
+			}
+
+			if *params != (HuggingFaceHFInferenceImageGenerationParameters{}) {
+				req.Parameters = params
+			}
 		}
 		return req, nil
 
@@ -375,8 +413,9 @@ func UnmarshalHuggingFaceImageGenerationResponse(data []byte, model string) (*sc
 
 	case hfInference:
 		// Handle raw byte data - encode to base64
-		b64Data := base64.StdEncoding.EncodeToString(data)
-		return &schemas.BifrostImageGenerationResponse{
+		binary := newHuggingFaceBinaryResponse(data, "")
+		b64Data := base64.StdEncoding.EncodeToString(binary.Data)
+		bifrostResponse := &schemas.BifrostImageGenerationResponse{
 			Model: model,
 			Data: []schemas.ImageData{
 				{
@@ -384,7 +423,13 @@ func UnmarshalHuggingFaceImageGenerationResponse(data []byte, model string) (*sc
 					Index:   0,
 				},
 			},
-		}, nil
+		}
+		if strings.HasPrefix(binary.ContentType, "image/") {
+			bifrostResponse.ImageGenerationResponseParameters = &schemas.ImageGenerationResponseParameters{
+				OutputFormat: binary.Format(),
+			}
+		}
+		return bifrostResponse, nil
 
 	case falAI:
 		// Handle fal-ai JSON response
@@ -435,6 +480,23 @@ func UnmarshalHuggingFaceImageGenerationResponse(data []byte, model string) (*sc
 }
 
 // imageBytesToBase64DataURL converts raw image bytes to base64 data URL format
+// parseImageSize reads a "WIDTHxHEIGHT" size such as "1024x768".
+func parseImageSize(size string) (int, int, error) {
+	dimensions := strings.Split(strings.ToLower(strings.TrimSpace(size)), "x")
+	if len(dimensions) != 2 {
+		return 0, 0, fmt.Errorf("invalid size format: expected 'WIDTHxHEIGHT', got %q", size)
+	}
+	width, err := strconv.Atoi(dimensions[0])
+	if err != nil || width <= 0 {
+		return 0, 0, fmt.Errorf("invalid width in size %q", size)
+	}
+	height, err := strconv.Atoi(dimensions[1])
+	if err != nil || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid height in size %q", size)
+	}
+	return width, height, nil
+}
+
 func imageBytesToBase64DataURL(imageBytes []byte) string {
 	mimeType := http.DetectContentType(imageBytes)
 	b64Data := base64.StdEncoding.EncodeToString(imageBytes)
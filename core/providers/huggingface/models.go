@@ -1,19 +1,147 @@
 package huggingface
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
 	schemas "github.com/maximhq/bifrost/core/schemas"
 )
 
 const (
+	// defaultModelFetchLimit and maxModelFetchLimit are used when HuggingFaceConfig leaves the
+	// matching limit unset; maxModelFetchLimit is also the most models the Hub serves per page.
 	defaultModelFetchLimit = 200
 	maxModelFetchLimit     = 1000
+
+	// maxModelHubURLLength keeps list requests under the URL length the Hub accepts
+	// before answering 414 URI Too Long.
+	maxModelHubURLLength = 8000
+)
+
+// DuplicateModelMode controls how a model served by several inference providers is listed.
+// Set via BifrostListModelsRequest.ExtraParams["duplicate_model_mode"].
+type DuplicateModelMode string
+
+const (
+	// DuplicateModelModeSeparate keeps one entry per inference provider, qualified as
+	// "{providerKey}/{inferenceProvider}/{modelID}" (default).
+	DuplicateModelModeSeparate DuplicateModelMode = "separate"
+	// DuplicateModelModeCollapse merges all entries for a model into a single
+	// "{providerKey}/{modelID}" entry listing every serving inference provider.
+	DuplicateModelModeCollapse DuplicateModelMode = "collapse"
+)
+
+// ModelListOrder is the order of the final model list, applied after every key's models are
+// merged, filtered and deduplicated. The Hub's own sort only decides which models are fetched.
+// Set via BifrostListModelsRequest.ExtraParams["order_by"].
+type ModelListOrder string
+
+const (
+	ModelListOrderID        ModelListOrder = "id"        // by model ID, as every provider lists them (default)
+	ModelListOrderName      ModelListOrder = "name"      // by model name, case-insensitively
+	ModelListOrderLikes     ModelListOrder = "likes"     // most liked first
+	ModelListOrderDownloads ModelListOrder = "downloads" // most downloaded first
+	ModelListOrderCreated   ModelListOrder = "created"   // most recently created first
+)
+
+// GatedModelMode controls whether ListModels checks the key's access to gated models, which
+// costs one Hub request per gated model listed. Set via
+// BifrostListModelsRequest.ExtraParams["gated_model_mode"].
+type GatedModelMode string
+
+const (
+	GatedModelModeOff    GatedModelMode = "off"    // list gated models without checking access (default)
+	GatedModelModeMark   GatedModelMode = "mark"   // set Accessible on every gated model
+	GatedModelModeFilter GatedModelMode = "filter" // drop gated models the key would get a 403 for
 )
 
+// maxConcurrentAccessChecks bounds the Hub requests made at once to check gated model access.
+const maxConcurrentAccessChecks = 8
+
+// listModelsControlParams are ExtraParams keys consumed by Bifrost that must not be
+// forwarded to the model hub as query parameters.
+var listModelsControlParams = map[string]struct{}{
+	"duplicate_model_mode": {},
+	"sort":                 {},
+	"direction":            {},
+	"pipeline_tag":         {},
+	"order_by":             {},
+	"gated_model_mode":     {},
+}
+
+// parseModelHubPipelineTag reads ListModels ExtraParams["pipeline_tag"], which narrows the Hub
+// listing to one pipeline so only the models for a given kind of request are fetched. Returns ""
+// when unset. Tags and the Bifrost request types they list:
+//
+//	conversational, text-generation          chat completion and responses (incl. streaming)
+//	feature-extraction                       embedding
+//	sentence-similarity                      embedding and rerank
+//	text-ranking                             rerank
+//	text-to-speech                           speech
+//	automatic-speech-recognition             transcription
+//	text-to-image                            image generation (incl. streaming)
+//
+// Any other Hub pipeline tag is forwarded as is.
+func parseModelHubPipelineTag(extraParams map[string]interface{}) (string, error) {
+	value, ok := extraParams["pipeline_tag"]
+	if !ok || value == nil {
+		return "", nil
+	}
+	tag, isString := value.(string)
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if !isString || tag == "" || strings.Trim(tag, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+		return "", fmt.Errorf("invalid pipeline_tag %v: expected a Hub pipeline tag such as text-generation or feature-extraction", value)
+	}
+	return tag, nil
+}
+
+// modelHubSortKeys maps the sort values accepted in ListModels ExtraParams["sort"] to the
+// Hub API's sort field.
+var modelHubSortKeys = map[string]string{
+	"likes":     "likes",
+	"downloads": "downloads",
+	"trending":  "trendingScore",
+	"created":   "createdAt",
+}
+
+const (
+	defaultModelHubSort      = "likes"
+	defaultModelHubDirection = "-1"
+)
+
+// parseModelHubSort reads ExtraParams["sort"] and ExtraParams["direction"], returning the Hub
+// sort field and direction ("-1" descending, "1" ascending). Missing values keep the defaults.
+func parseModelHubSort(extraParams map[string]interface{}) (string, string, error) {
+	sortKey := defaultModelHubSort
+	if value, ok := extraParams["sort"]; ok {
+		name, isString := value.(string)
+		hubKey, known := modelHubSortKeys[strings.ToLower(strings.TrimSpace(name))]
+		if !isString || !known {
+			return "", "", fmt.Errorf("unsupported sort %v: must be one of likes, downloads, trending, created", value)
+		}
+		sortKey = hubKey
+	}
+
+	direction := defaultModelHubDirection
+	if value, ok := extraParams["direction"]; ok {
+		switch strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", value))) {
+		case "-1", "desc", "descending":
+			direction = "-1"
+		case "1", "asc", "ascending":
+			direction = "1"
+		default:
+			return "", "", fmt.Errorf("unsupported direction %v: must be -1 (desc) or 1 (asc)", value)
+		}
+	}
+	return sortKey, direction, nil
+}
+
 func (response *HuggingFaceListModelsResponse) ToBifrostListModelsResponse(providerKey schemas.ModelProvider, inferenceProvider inferenceProvider, allowedModels schemas.WhiteList, blacklistedModels schemas.BlackList, aliases map[string]string, unfiltered bool) *schemas.BifrostListModelsResponse {
 	if response == nil {
 		return nil
@@ -52,14 +180,25 @@ func (response *HuggingFaceListModelsResponse) ToBifrostListModelsResponse(provi
 		for _, result := range pipeline.FilterModel(model.ModelID) {
 			newModel := schemas.Model{
 				// inferenceProvider stays in the compound ID; aliases rename only the model segment
-				ID:               fmt.Sprintf("%s/%s/%s", providerKey, inferenceProvider, result.ResolvedID),
-				Name:             schemas.Ptr(model.ModelID),
-				SupportedMethods: supported,
-				HuggingFaceID:    schemas.Ptr(model.ID),
+				ID:                  fmt.Sprintf("%s/%s/%s", providerKey, inferenceProvider, result.ResolvedID),
+				Name:                schemas.Ptr(model.ModelID),
+				SupportedMethods:    supported,
+				SupportedParameters: deriveSupportedParameters(model.CardData),
+				HuggingFaceID:       schemas.Ptr(model.ID),
+				Likes:               schemas.Ptr(model.Likes),
+				Downloads:           schemas.Ptr(model.Downloads),
+				Created:             parseHubCreatedAt(model.CreatedAt),
+				Gated:               hubGated(model.Gated),
+			}
+			if model.LibraryName != "" {
+				newModel.LibraryName = schemas.Ptr(model.LibraryName)
 			}
 			if result.AliasValue != "" {
 				newModel.Alias = schemas.Ptr(result.AliasValue)
 			}
+			if model.CardData != nil && strings.TrimSpace(model.CardData.Description) != "" {
+				newModel.Description = schemas.Ptr(strings.TrimSpace(model.CardData.Description))
+			}
 			bifrostResponse.Data = append(bifrostResponse.Data, newModel)
 			included[strings.ToLower(result.ResolvedID)] = true
 		}
@@ -77,6 +216,356 @@ func (response *HuggingFaceListModelsResponse) ToBifrostListModelsResponse(provi
 	return bifrostResponse
 }
 
+// ToBifrostListModelsResponse converts the router listing into one model per live deployment,
+// with the same "{providerKey}/{inferenceProvider}/{modelID}" IDs as the Hub listing. The router
+// only lists chat models. A non-empty onlyProvider keeps just that inference provider's deployments.
+func (response *HuggingFaceRouterModelsResponse) ToBifrostListModelsResponse(providerKey schemas.ModelProvider, onlyProvider inferenceProvider, allowedModels schemas.WhiteList, blacklistedModels schemas.BlackList, aliases map[string]string, unfiltered bool) *schemas.BifrostListModelsResponse {
+	if response == nil {
+		return nil
+	}
+
+	bifrostResponse := &schemas.BifrostListModelsResponse{
+		Data: make([]schemas.Model, 0, len(response.Data)),
+	}
+
+	pipeline := &providerUtils.ListModelsPipeline{
+		AllowedModels:     allowedModels,
+		BlacklistedModels: blacklistedModels,
+		Aliases:           aliases,
+		Unfiltered:        unfiltered,
+		ProviderKey:       providerKey,
+		MatchFns:          providerUtils.DefaultMatchFns(),
+	}
+	if pipeline.ShouldEarlyExit() {
+		return bifrostResponse
+	}
+
+	for _, model := range response.Data {
+		if model.ID == "" {
+			continue
+		}
+		for _, result := range pipeline.FilterModel(model.ID) {
+			for _, deployment := range model.Providers {
+				if deployment.Provider == "" || (deployment.Status != "" && deployment.Status != "live") {
+					continue
+				}
+				if onlyProvider != "" && inferenceProvider(deployment.Provider) != onlyProvider {
+					continue
+				}
+				newModel := schemas.Model{
+					ID:               fmt.Sprintf("%s/%s/%s", providerKey, deployment.Provider, result.ResolvedID),
+					Name:             schemas.Ptr(model.ID),
+					Created:          model.Created,
+					ContextLength:    deployment.ContextLength,
+					SupportedMethods: deriveSupportedMethods("conversational", nil),
+				}
+				if model.OwnedBy != "" {
+					newModel.OwnedBy = schemas.Ptr(model.OwnedBy)
+				}
+				if deployment.SupportsTools != nil && *deployment.SupportsTools {
+					newModel.SupportedParameters = append(newModel.SupportedParameters, "tools", "tool_choice")
+				}
+				if deployment.SupportsStructuredOutput != nil && *deployment.SupportsStructuredOutput {
+					newModel.SupportedParameters = append(newModel.SupportedParameters, "response_format")
+				}
+				if result.AliasValue != "" {
+					newModel.Alias = schemas.Ptr(result.AliasValue)
+				}
+				bifrostResponse.Data = append(bifrostResponse.Data, newModel)
+			}
+		}
+	}
+
+	return bifrostResponse
+}
+
+// parseModelListOrder reads ExtraParams["order_by"], defaulting to ModelListOrderID.
+func parseModelListOrder(extraParams map[string]interface{}) (ModelListOrder, error) {
+	value, ok := extraParams["order_by"]
+	if !ok {
+		return ModelListOrderID, nil
+	}
+	name, _ := value.(string)
+	switch order := ModelListOrder(strings.ToLower(strings.TrimSpace(name))); order {
+	case ModelListOrderID, ModelListOrderName, ModelListOrderLikes, ModelListOrderDownloads, ModelListOrderCreated:
+		return order, nil
+	}
+	return "", fmt.Errorf("unsupported order_by %v: must be one of id, name, likes, downloads, created", value)
+}
+
+// parseGatedModelMode reads ExtraParams["gated_model_mode"], defaulting to GatedModelModeOff.
+func parseGatedModelMode(extraParams map[string]interface{}) (GatedModelMode, error) {
+	value, ok := extraParams["gated_model_mode"]
+	if !ok {
+		return GatedModelModeOff, nil
+	}
+	name, _ := value.(string)
+	switch mode := GatedModelMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case GatedModelModeOff, GatedModelModeMark, GatedModelModeFilter:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unsupported gated_model_mode %v: must be one of off, mark, filter", value)
+}
+
+// applyGatedModelAccess checks the key's access to every gated model with hasAccess and marks
+// or drops the models according to mode. Models whose access could not be determined are kept
+// unmarked, so a Hub hiccup never hides a runnable model.
+func applyGatedModelAccess(models []schemas.Model, mode GatedModelMode, hasAccess func(modelName string) (accessible bool, known bool)) []schemas.Model {
+	if mode == GatedModelModeOff {
+		return models
+	}
+
+	// Collapsed and separate entries share a repo, so check each repo once
+	var gatedNames []string
+	seen := make(map[string]struct{})
+	for _, model := range models {
+		if model.Gated != nil && *model.Gated && model.Name != nil {
+			if _, ok := seen[*model.Name]; !ok {
+				seen[*model.Name] = struct{}{}
+				gatedNames = append(gatedNames, *model.Name)
+			}
+		}
+	}
+	results := make([]*bool, len(gatedNames))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentAccessChecks)
+	for i, modelName := range gatedNames {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, modelName string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if accessible, known := hasAccess(modelName); known {
+				results[i] = schemas.Ptr(accessible)
+			}
+		}(i, modelName)
+	}
+	wg.Wait()
+
+	access := make(map[string]*bool, len(gatedNames))
+	for i, modelName := range gatedNames {
+		access[modelName] = results[i]
+	}
+
+	kept := models[:0]
+	for _, model := range models {
+		if model.Gated != nil && *model.Gated && model.Name != nil {
+			if accessible := access[*model.Name]; accessible != nil {
+				if mode == GatedModelModeFilter && !*accessible {
+					continue
+				}
+				model.Accessible = schemas.Ptr(*accessible)
+			}
+		}
+		kept = append(kept, model)
+	}
+	return kept
+}
+
+// parseHubCreatedAt converts the Hub's RFC 3339 createdAt to Unix seconds, or nil when it is
+// missing or malformed.
+func parseHubCreatedAt(value string) *int64 {
+	createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return schemas.Ptr(createdAt.Unix())
+}
+
+// sortModelList orders models in place. Ties, and models without the sorted field, fall back to
+// ID order so the result (and hence pagination) is stable.
+func sortModelList(models []schemas.Model, order ModelListOrder) {
+	count := func(value *int) int {
+		if value == nil {
+			return -1
+		}
+		return *value
+	}
+	created := func(model schemas.Model) int64 {
+		if model.Created == nil {
+			return math.MinInt64
+		}
+		return *model.Created
+	}
+	name := func(model schemas.Model) string {
+		if model.Name != nil {
+			return strings.ToLower(*model.Name)
+		}
+		return strings.ToLower(model.ID)
+	}
+
+	slices.SortStableFunc(models, func(a, b schemas.Model) int {
+		var result int
+		switch order {
+		case ModelListOrderName:
+			result = strings.Compare(name(a), name(b))
+		case ModelListOrderLikes:
+			result = count(b.Likes) - count(a.Likes)
+		case ModelListOrderDownloads:
+			result = count(b.Downloads) - count(a.Downloads)
+		case ModelListOrderCreated:
+			result = cmp.Compare(created(b), created(a))
+		}
+		if result != 0 {
+			return result
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// ToBifrostModel converts a single-model Hub entry, filling the descriptive fields the list
+// conversion leaves empty.
+func (info *HuggingFaceModelInfo) ToBifrostModel(providerKey schemas.ModelProvider) *schemas.Model {
+	if info == nil {
+		return nil
+	}
+
+	model := &schemas.Model{
+		ID:                  fmt.Sprintf("%s/%s", providerKey, info.ModelID),
+		Name:                schemas.Ptr(info.ModelID),
+		SupportedMethods:    deriveSupportedMethods(info.PipelineTag, info.Tags),
+		SupportedParameters: deriveSupportedParameters(info.CardData),
+		Likes:               schemas.Ptr(info.Likes),
+		Downloads:           schemas.Ptr(info.Downloads),
+		Gated:               hubGated(info.Gated),
+	}
+	if info.ID != "" {
+		model.HuggingFaceID = schemas.Ptr(info.ID)
+	}
+	if info.Author != "" {
+		model.OwnedBy = schemas.Ptr(info.Author)
+	}
+	model.Created = parseHubCreatedAt(info.CreatedAt)
+	if info.LibraryName != "" {
+		model.LibraryName = schemas.Ptr(info.LibraryName)
+	}
+	if info.CardData != nil {
+		if description := strings.TrimSpace(info.CardData.Description); description != "" {
+			model.Description = schemas.Ptr(description)
+		}
+		if license := cardLicense(info.CardData.License); license != "" {
+			model.License = schemas.Ptr(license)
+		}
+	}
+	return model
+}
+
+// hubGated reads the Hub's gated field, which is false for open models and "auto" or "manual"
+// (true in older entries) for gated ones. Returns nil when the Hub left it out.
+func hubGated(gated interface{}) *bool {
+	switch typed := gated.(type) {
+	case bool:
+		return schemas.Ptr(typed)
+	case string:
+		value := strings.ToLower(strings.TrimSpace(typed))
+		return schemas.Ptr(value != "" && value != "false")
+	}
+	return nil
+}
+
+// cardLicense flattens a card's license, which is usually a single ID but may be a list.
+func cardLicense(license interface{}) string {
+	switch typed := license.(type) {
+	case string:
+		return strings.TrimSpace(typed)
+	case []interface{}:
+		licenses := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			if id, ok := entry.(string); ok && strings.TrimSpace(id) != "" {
+				licenses = append(licenses, strings.TrimSpace(id))
+			}
+		}
+		return strings.Join(licenses, ", ")
+	}
+	return ""
+}
+
+// collapseDuplicateModels merges entries that share a model ID across inference providers.
+// The merged entry drops the inference provider segment so HF's router picks a backend,
+// takes the union of supported methods, and records the serving providers in sorted order.
+func collapseDuplicateModels(models []schemas.Model, providerKey schemas.ModelProvider) []schemas.Model {
+	collapsed := make([]schemas.Model, 0, len(models))
+	indexByID := make(map[string]int, len(models))
+
+	for _, model := range models {
+		rest := strings.TrimPrefix(model.ID, string(providerKey)+"/")
+		infProvider, modelID, found := strings.Cut(rest, "/")
+		if !found {
+			collapsed = append(collapsed, model)
+			continue
+		}
+
+		idx, exists := indexByID[modelID]
+		if !exists {
+			model.ID = fmt.Sprintf("%s/%s", providerKey, modelID)
+			model.InferenceProviders = []string{infProvider}
+			indexByID[modelID] = len(collapsed)
+			collapsed = append(collapsed, model)
+			continue
+		}
+
+		existing := &collapsed[idx]
+		if !slices.Contains(existing.InferenceProviders, infProvider) {
+			existing.InferenceProviders = append(existing.InferenceProviders, infProvider)
+			slices.Sort(existing.InferenceProviders)
+		}
+		for _, method := range model.SupportedMethods {
+			if !slices.Contains(existing.SupportedMethods, method) {
+				existing.SupportedMethods = append(existing.SupportedMethods, method)
+			}
+		}
+		slices.Sort(existing.SupportedMethods)
+	}
+
+	return collapsed
+}
+
+// truncateModelDescriptions shortens descriptions longer than maxLength characters to exactly
+// maxLength, ending in an ellipsis. A non-positive maxLength leaves them untouched.
+func truncateModelDescriptions(models []schemas.Model, maxLength int) {
+	if maxLength <= 0 {
+		return
+	}
+	for i := range models {
+		if models[i].Description == nil {
+			continue
+		}
+		runes := []rune(*models[i].Description)
+		if len(runes) <= maxLength {
+			continue
+		}
+		models[i].Description = schemas.Ptr(string(runes[:maxLength-1]) + "…")
+	}
+}
+
+// cardParameterNames maps generation parameter names used in model cards to their
+// Bifrost (OpenAI-style) equivalents. Names not listed here are reported unchanged.
+var cardParameterNames = map[string]string{
+	"max_new_tokens": "max_tokens",
+	"stop_sequences": "stop",
+}
+
+// deriveSupportedParameters lists the generation parameters a model card declares under
+// `inference.parameters`. Returns nil when the card declares none.
+func deriveSupportedParameters(cardData *HuggingFaceModelCardData) []string {
+	if cardData == nil || cardData.Inference == nil || len(cardData.Inference.Parameters) == 0 {
+		return nil
+	}
+
+	params := make([]string, 0, len(cardData.Inference.Parameters))
+	for name := range cardData.Inference.Parameters {
+		if mapped, ok := cardParameterNames[name]; ok {
+			name = mapped
+		}
+		if !slices.Contains(params, name) {
+			params = append(params, name)
+		}
+	}
+
+	slices.Sort(params)
+	return params
+}
+
 func deriveSupportedMethods(pipeline string, tags []string) []string {
 	normalized := strings.TrimSpace(strings.ToLower(pipeline))
 
@@ -89,17 +578,23 @@ func deriveSupportedMethods(pipeline string, tags []string) []string {
 	}
 
 	switch normalized {
-	case "conversational", "chat-completion":
+	case "conversational", "chat-completion", "image-text-to-text":
 		addMethods(schemas.ChatCompletionRequest, schemas.ChatCompletionStreamRequest,
 			schemas.ResponsesRequest, schemas.ResponsesStreamRequest)
 	case "feature-extraction":
 		addMethods(schemas.EmbeddingRequest)
+	case "sentence-similarity":
+		addMethods(schemas.EmbeddingRequest, schemas.RerankRequest)
+	case "text-ranking":
+		addMethods(schemas.RerankRequest)
 	case "text-to-speech":
 		addMethods(schemas.SpeechRequest)
 	case "automatic-speech-recognition":
 		addMethods(schemas.TranscriptionRequest)
 	case "text-to-image":
 		addMethods(schemas.ImageGenerationRequest, schemas.ImageGenerationStreamRequest)
+	case "image-to-text":
+		addMethods(schemas.OCRRequest)
 	}
 
 	for _, tag := range tags {
@@ -109,9 +604,12 @@ func deriveSupportedMethods(pipeline string, tags []string) []string {
 			tagLower == "feature-extraction" || tagLower == "embeddings" ||
 			tagLower == "sentence-transformers" || strings.Contains(tagLower, "embedding"):
 			addMethods(schemas.EmbeddingRequest)
+		case tagLower == "text-ranking" || tagLower == "reranking" || tagLower == "reranker":
+			addMethods(schemas.RerankRequest)
 		case tagLower == "text-generation" || tagLower == "summarization" ||
 			tagLower == "conversational" || tagLower == "chat-completion" ||
 			tagLower == "text2text-generation" || tagLower == "question-answering" ||
+			tagLower == "image-text-to-text" ||
 			strings.Contains(tagLower, "chat") || strings.Contains(tagLower, "completion"):
 			addMethods(schemas.ChatCompletionRequest, schemas.ChatCompletionStreamRequest,
 				schemas.ResponsesRequest, schemas.ResponsesStreamRequest)
@@ -123,6 +621,8 @@ func deriveSupportedMethods(pipeline string, tags []string) []string {
 			addMethods(schemas.TranscriptionRequest)
 		case tagLower == "text-to-image" || strings.Contains(tagLower, "image-generation"):
 			addMethods(schemas.ImageGenerationRequest, schemas.ImageGenerationStreamRequest)
+		case tagLower == "image-to-text" || tagLower == "image-captioning":
+			addMethods(schemas.OCRRequest)
 		}
 	}
 
@@ -0,0 +1,712 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateModelMode(t *testing.T) {
+	hubResponse := &HuggingFaceListModelsResponse{
+		Models: []HuggingFaceModel{
+			{ID: "abc123", ModelID: "meta-llama/Llama-3.3-70B-Instruct", PipelineTag: "conversational"},
+		},
+	}
+
+	var separate []schemas.Model
+	for _, infProvider := range []inferenceProvider{groq, together} {
+		resp := hubResponse.ToBifrostListModelsResponse(schemas.HuggingFace, infProvider, nil, nil, nil, true)
+		require.NotNil(t, resp)
+		separate = append(separate, resp.Data...)
+	}
+
+	t.Run("separate", func(t *testing.T) {
+		require.Len(t, separate, 2)
+		assert.Equal(t, "huggingface/groq/meta-llama/Llama-3.3-70B-Instruct", separate[0].ID)
+		assert.Equal(t, "huggingface/together/meta-llama/Llama-3.3-70B-Instruct", separate[1].ID)
+		assert.Nil(t, separate[0].InferenceProviders)
+	})
+
+	t.Run("collapse", func(t *testing.T) {
+		collapsed := collapseDuplicateModels(separate, schemas.HuggingFace)
+		require.Len(t, collapsed, 1)
+		assert.Equal(t, "huggingface/meta-llama/Llama-3.3-70B-Instruct", collapsed[0].ID)
+		assert.Equal(t, []string{"groq", "together"}, collapsed[0].InferenceProviders)
+		assert.Equal(t, separate[0].SupportedMethods, collapsed[0].SupportedMethods)
+	})
+}
+
+func TestBuildModelHubURLSkipsControlParams(t *testing.T) {
+	provider := &HuggingFaceProvider{}
+	url, err := provider.buildModelHubURL(&schemas.BifrostListModelsRequest{
+		ExtraParams: map[string]interface{}{
+			"duplicate_model_mode": "collapse",
+			"author":               "meta-llama",
+		},
+	}, groq, "")
+	require.NoError(t, err)
+
+	assert.NotContains(t, url, "duplicate_model_mode")
+	assert.Contains(t, url, "author=meta-llama")
+}
+
+func TestBuildModelHubURLSortAndDirection(t *testing.T) {
+	provider := &HuggingFaceProvider{}
+
+	tests := []struct {
+		name          string
+		extraParams   map[string]interface{}
+		wantSort      string
+		wantDirection string
+		wantErr       string
+	}{
+		{name: "defaults", wantSort: "likes", wantDirection: "-1"},
+		{name: "downloads_ascending", extraParams: map[string]interface{}{"sort": "downloads", "direction": "asc"}, wantSort: "downloads", wantDirection: "1"},
+		{name: "trending_numeric_direction", extraParams: map[string]interface{}{"sort": "Trending", "direction": float64(-1)}, wantSort: "trendingScore", wantDirection: "-1"},
+		{name: "created", extraParams: map[string]interface{}{"sort": "created"}, wantSort: "createdAt", wantDirection: "-1"},
+		{name: "unknown_sort", extraParams: map[string]interface{}{"sort": "stars"}, wantErr: "unsupported sort stars"},
+		{name: "unknown_direction", extraParams: map[string]interface{}{"direction": "sideways"}, wantErr: "unsupported direction sideways"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hubURL, err := provider.buildModelHubURL(&schemas.BifrostListModelsRequest{ExtraParams: tt.extraParams}, groq, "")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			parsed, parseErr := url.Parse(hubURL)
+			require.NoError(t, parseErr)
+			assert.Equal(t, tt.wantSort, parsed.Query().Get("sort"))
+			assert.Equal(t, tt.wantDirection, parsed.Query().Get("direction"))
+		})
+	}
+}
+
+func TestBuildModelHubURLPipelineTag(t *testing.T) {
+	provider := &HuggingFaceProvider{}
+
+	hubURL, err := provider.buildModelHubURL(&schemas.BifrostListModelsRequest{
+		ExtraParams: map[string]interface{}{"pipeline_tag": " Feature-Extraction "},
+	}, hfInference, "")
+	require.NoError(t, err)
+	parsed, err := url.Parse(hubURL)
+	require.NoError(t, err)
+	assert.Equal(t, "feature-extraction", parsed.Query().Get("pipeline_tag"))
+
+	hubURL, err = provider.buildModelHubURL(&schemas.BifrostListModelsRequest{}, hfInference, "")
+	require.NoError(t, err)
+	assert.NotContains(t, hubURL, "pipeline_tag")
+
+	for _, invalid := range []interface{}{"text generation", "", 42} {
+		_, err = provider.buildModelHubURL(&schemas.BifrostListModelsRequest{
+			ExtraParams: map[string]interface{}{"pipeline_tag": invalid},
+		}, hfInference, "")
+		assert.Error(t, err, "pipeline_tag %v", invalid)
+	}
+}
+
+func TestBuildModelHubURLFetchLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultLimit int
+		maxLimit     int
+		pageSize     int
+		want         string
+	}{
+		{name: "builtin_default", want: "200"},
+		{name: "builtin_cap", pageSize: 5000, want: "1000"},
+		{name: "configured_default", defaultLimit: 50, want: "50"},
+		{name: "configured_cap", maxLimit: 100, pageSize: 500, want: "100"},
+		{name: "default_above_cap", defaultLimit: 300, maxLimit: 100, want: "100"},
+		{name: "cap_above_hub_maximum", maxLimit: 5000, pageSize: 5000, want: "1000"},
+		{name: "page_size_wins_over_default", defaultLimit: 50, pageSize: 75, want: "75"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &HuggingFaceProvider{huggingFaceConfig: schemas.HuggingFaceConfig{
+				DefaultModelFetchLimit: tt.defaultLimit,
+				MaxModelFetchLimit:     tt.maxLimit,
+			}}
+			hubURL, err := provider.buildModelHubURL(&schemas.BifrostListModelsRequest{PageSize: tt.pageSize}, groq, "")
+			require.NoError(t, err)
+			parsed, err := url.Parse(hubURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, parsed.Query().Get("limit"))
+		})
+	}
+}
+
+func TestSupportedParametersFromCardData(t *testing.T) {
+	var hubResponse HuggingFaceListModelsResponse
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"_id":"1","modelId":"org/declared","pipeline_tag":"conversational",
+		 "cardData":{"inference":{"parameters":{"temperature":0.7,"max_new_tokens":256,"top_p":0.9}}}},
+		{"_id":"2","modelId":"org/disabled","pipeline_tag":"conversational","cardData":{"inference":false}},
+		{"_id":"3","modelId":"org/undeclared","pipeline_tag":"conversational"}
+	]`), &hubResponse))
+
+	resp := hubResponse.ToBifrostListModelsResponse(schemas.HuggingFace, groq, nil, nil, nil, true)
+	require.NotNil(t, resp)
+	require.Len(t, resp.Data, 3)
+
+	assert.Equal(t, []string{"max_tokens", "temperature", "top_p"}, resp.Data[0].SupportedParameters)
+	assert.Nil(t, resp.Data[1].SupportedParameters)
+	assert.Nil(t, resp.Data[2].SupportedParameters)
+}
+
+func TestDeriveSupportedMethods(t *testing.T) {
+	chatMethods := []string{
+		string(schemas.ChatCompletionRequest), string(schemas.ChatCompletionStreamRequest),
+		string(schemas.ResponsesRequest), string(schemas.ResponsesStreamRequest),
+	}
+	slices.Sort(chatMethods)
+
+	tests := []struct {
+		name     string
+		pipeline string
+		tags     []string
+		want     []string
+	}{
+		{name: "vision_pipeline", pipeline: "image-text-to-text", want: chatMethods},
+		{name: "vision_tag", tags: []string{"image-text-to-text"}, want: chatMethods},
+		{name: "speech_recognition", pipeline: "automatic-speech-recognition", want: []string{string(schemas.TranscriptionRequest)}},
+		{name: "text_to_speech", pipeline: "text-to-speech", want: []string{string(schemas.SpeechRequest)}},
+		{name: "text_to_image", pipeline: "text-to-image", want: []string{string(schemas.ImageGenerationRequest), string(schemas.ImageGenerationStreamRequest)}},
+		{name: "sentence_similarity", pipeline: "sentence-similarity", want: []string{string(schemas.EmbeddingRequest), string(schemas.RerankRequest)}},
+		{name: "sentence_similarity_tag_only", pipeline: "feature-extraction", tags: []string{"sentence-similarity"}, want: []string{string(schemas.EmbeddingRequest)}},
+		{name: "text_ranking", pipeline: "text-ranking", want: []string{string(schemas.RerankRequest)}},
+		{name: "reranker_tag", pipeline: "text-classification", tags: []string{"reranker"}, want: []string{string(schemas.RerankRequest)}},
+		{name: "unsupported_task", pipeline: "object-detection", want: nil},
+		{name: "unsupported_task_with_unrelated_tags", pipeline: "image-segmentation", tags: []string{"vision", "transformers"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want != nil {
+				want = append([]string(nil), want...)
+				slices.Sort(want)
+			}
+			assert.Equal(t, want, deriveSupportedMethods(tt.pipeline, tt.tags))
+		})
+	}
+}
+
+func TestSortModelList(t *testing.T) {
+	models := func() []schemas.Model {
+		return []schemas.Model{
+			{ID: "huggingface/groq/org/beta", Name: schemas.Ptr("org/Beta"), Likes: schemas.Ptr(10), Downloads: schemas.Ptr(500), Created: schemas.Ptr(int64(1700000000))},
+			{ID: "huggingface/groq/org/alpha", Name: schemas.Ptr("org/alpha"), Likes: schemas.Ptr(10), Downloads: schemas.Ptr(9000), Created: schemas.Ptr(int64(1600000000))},
+			{ID: "huggingface/groq/org/gamma", Name: schemas.Ptr("org/gamma"), Likes: schemas.Ptr(300), Downloads: schemas.Ptr(20), Created: schemas.Ptr(int64(1750000000))},
+			{ID: "huggingface/groq/org/backfilled"},
+		}
+	}
+	ids := func(models []schemas.Model) []string {
+		out := make([]string, len(models))
+		for i, model := range models {
+			out[i] = strings.TrimPrefix(model.ID, "huggingface/groq/")
+		}
+		return out
+	}
+
+	tests := []struct {
+		order ModelListOrder
+		want  []string
+	}{
+		{order: ModelListOrderID, want: []string{"org/alpha", "org/backfilled", "org/beta", "org/gamma"}},
+		// Name order is case-insensitive; models without a name sort by their full ID
+		{order: ModelListOrderName, want: []string{"org/backfilled", "org/alpha", "org/beta", "org/gamma"}},
+		// Ties on likes fall back to ID; models without counts go last
+		{order: ModelListOrderLikes, want: []string{"org/gamma", "org/alpha", "org/beta", "org/backfilled"}},
+		{order: ModelListOrderDownloads, want: []string{"org/alpha", "org/beta", "org/gamma", "org/backfilled"}},
+		{order: ModelListOrderCreated, want: []string{"org/gamma", "org/beta", "org/alpha", "org/backfilled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			list := models()
+			sortModelList(list, tt.order)
+			assert.Equal(t, tt.want, ids(list))
+		})
+	}
+}
+
+func TestParseModelListOrder(t *testing.T) {
+	order, err := parseModelListOrder(nil)
+	require.NoError(t, err)
+	assert.Equal(t, ModelListOrderID, order)
+
+	order, err = parseModelListOrder(map[string]interface{}{"order_by": " Downloads "})
+	require.NoError(t, err)
+	assert.Equal(t, ModelListOrderDownloads, order)
+
+	_, err = parseModelListOrder(map[string]interface{}{"order_by": "trending"})
+	require.Error(t, err)
+	_, err = parseModelListOrder(map[string]interface{}{"order_by": 1})
+	require.Error(t, err)
+}
+
+func TestListedModelPopularity(t *testing.T) {
+	var hubResponse HuggingFaceListModelsResponse
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"_id":"1","modelId":"org/popular","pipeline_tag":"conversational","likes":4210,"downloads":1250000},
+		{"_id":"2","modelId":"org/new","pipeline_tag":"conversational"}
+	]`), &hubResponse))
+
+	resp := hubResponse.ToBifrostListModelsResponse(schemas.HuggingFace, groq, nil, nil, nil, true)
+	require.NotNil(t, resp)
+	require.Len(t, resp.Data, 2)
+
+	require.NotNil(t, resp.Data[0].Likes)
+	require.NotNil(t, resp.Data[0].Downloads)
+	assert.Equal(t, 4210, *resp.Data[0].Likes)
+	assert.Equal(t, 1250000, *resp.Data[0].Downloads)
+	require.NotNil(t, resp.Data[1].Likes)
+	assert.Equal(t, 0, *resp.Data[1].Likes)
+
+	collapsed := collapseDuplicateModels(resp.Data, schemas.HuggingFace)
+	require.NotNil(t, collapsed[0].Likes)
+	assert.Equal(t, 4210, *collapsed[0].Likes)
+}
+
+func TestListedModelLibraryAndCreated(t *testing.T) {
+	var hubResponse HuggingFaceListModelsResponse
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"_id":"1","modelId":"org/dated","pipeline_tag":"conversational","library_name":"transformers","createdAt":"2024-07-23T12:30:00.000Z"},
+		{"_id":"2","modelId":"org/undated","pipeline_tag":"conversational","createdAt":"last tuesday"}
+	]`), &hubResponse))
+
+	resp := hubResponse.ToBifrostListModelsResponse(schemas.HuggingFace, groq, nil, nil, nil, true)
+	require.NotNil(t, resp)
+	require.Len(t, resp.Data, 2)
+
+	require.NotNil(t, resp.Data[0].LibraryName)
+	assert.Equal(t, "transformers", *resp.Data[0].LibraryName)
+	require.NotNil(t, resp.Data[0].Created)
+	assert.Equal(t, time.Date(2024, 7, 23, 12, 30, 0, 0, time.UTC).Unix(), *resp.Data[0].Created)
+
+	assert.Nil(t, resp.Data[1].LibraryName)
+	assert.Nil(t, resp.Data[1].Created, "unparseable createdAt is left unset")
+}
+
+func TestTruncateModelDescriptions(t *testing.T) {
+	var hubResponse HuggingFaceListModelsResponse
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"_id":"1","modelId":"org/long","pipeline_tag":"conversational","cardData":{"description":"An instruction-tuned model for chat"}},
+		{"_id":"2","modelId":"org/short","pipeline_tag":"conversational","cardData":{"description":"Tiny"}},
+		{"_id":"3","modelId":"org/none","pipeline_tag":"conversational"}
+	]`), &hubResponse))
+
+	resp := hubResponse.ToBifrostListModelsResponse(schemas.HuggingFace, groq, nil, nil, nil, true)
+	require.NotNil(t, resp)
+	require.Len(t, resp.Data, 3)
+
+	t.Run("disabled", func(t *testing.T) {
+		models := append([]schemas.Model(nil), resp.Data...)
+		truncateModelDescriptions(models, 0)
+		require.NotNil(t, models[0].Description)
+		assert.Equal(t, "An instruction-tuned model for chat", *models[0].Description)
+	})
+
+	t.Run("configured length", func(t *testing.T) {
+		models := append([]schemas.Model(nil), resp.Data...)
+		truncateModelDescriptions(models, 10)
+		require.NotNil(t, models[0].Description)
+		assert.Equal(t, "An instru…", *models[0].Description)
+		assert.Len(t, []rune(*models[0].Description), 10)
+		assert.Equal(t, "Tiny", *models[1].Description)
+		assert.Nil(t, models[2].Description)
+	})
+}
+
+func TestListModelsRejectsOversizedExtraParams(t *testing.T) {
+	request := &schemas.BifrostListModelsRequest{
+		Provider: schemas.HuggingFace,
+		ExtraParams: map[string]interface{}{
+			"search": strings.Repeat("x", maxModelHubURLLength),
+		},
+	}
+
+	t.Run("build_url", func(t *testing.T) {
+		provider := &HuggingFaceProvider{}
+		_, err := provider.buildModelHubURL(request, groq, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeding")
+	})
+
+	t.Run("list_models_fails_before_sending", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, "http://127.0.0.1:1")
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		resp, bifrostErr := provider.listModelsByKey(ctx, schemas.Key{}, request)
+		assert.Nil(t, resp)
+		require.NotNil(t, bifrostErr)
+		require.NotNil(t, bifrostErr.Error)
+		assert.Equal(t, "invalid list models request", bifrostErr.Error.Message)
+	})
+}
+
+func TestFetchModelHubPageRawEntries(t *testing.T) {
+	const entries = `[{"id":"org/a","likes":3,"custom":{"nested":true}},{"id":"org/b","likes":1}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", `<https://huggingface.co/api/models?cursor=abc>; rel="next"`)
+		_, _ = io.WriteString(w, entries)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	page, bifrostErr := provider.fetchModelHubPage(ctx, schemas.Key{}, server.URL+"/api/models", true)
+	require.Nil(t, bifrostErr)
+	require.Len(t, page.response.Models, 2)
+	assert.Equal(t, "abc", page.nextCursor)
+	require.Len(t, page.rawHub, 2)
+	assert.JSONEq(t, `{"id":"org/a","likes":3,"custom":{"nested":true}}`, string(page.rawHub[0]))
+	assert.JSONEq(t, `{"id":"org/b","likes":1}`, string(page.rawHub[1]))
+
+	page, bifrostErr = provider.fetchModelHubPage(ctx, schemas.Key{}, server.URL+"/api/models", false)
+	require.Nil(t, bifrostErr)
+	assert.Nil(t, page.rawHub)
+}
+
+func TestHubGated(t *testing.T) {
+	tests := []struct {
+		name  string
+		gated interface{}
+		want  *bool
+	}{
+		{name: "missing", gated: nil, want: nil},
+		{name: "open", gated: false, want: schemas.Ptr(false)},
+		{name: "legacy_true", gated: true, want: schemas.Ptr(true)},
+		{name: "auto", gated: "auto", want: schemas.Ptr(true)},
+		{name: "manual", gated: "manual", want: schemas.Ptr(true)},
+		{name: "string_false", gated: "false", want: schemas.Ptr(false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hubGated(tt.gated))
+		})
+	}
+
+	var hubResponse HuggingFaceListModelsResponse
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"_id":"1","modelId":"org/gated","pipeline_tag":"text-generation","tags":["conversational"],"gated":"manual"},
+		{"_id":"2","modelId":"org/open","pipeline_tag":"text-generation","tags":["conversational"],"gated":false}
+	]`), &hubResponse))
+	listed := hubResponse.ToBifrostListModelsResponse(schemas.HuggingFace, groq, nil, nil, nil, true)
+	require.Len(t, listed.Data, 2)
+	require.NotNil(t, listed.Data[0].Gated)
+	assert.True(t, *listed.Data[0].Gated)
+	require.NotNil(t, listed.Data[1].Gated)
+	assert.False(t, *listed.Data[1].Gated)
+}
+
+func TestFetchModelInfo(t *testing.T) {
+	const modelInfo = `{
+		"_id":"66a1","id":"meta-llama/Llama-3.1-8B-Instruct","modelId":"meta-llama/Llama-3.1-8B-Instruct",
+		"author":"meta-llama","pipeline_tag":"text-generation","tags":["conversational"],
+		"createdAt":"2024-07-18T08:56:00.000Z","gated":"manual",
+		"cardData":{"license":"llama3.1","description":"Multilingual instruction-tuned model",
+			"inference":{"parameters":{"temperature":0.6}}}
+	}`
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/models/meta-llama/Llama-3.1-8B-Instruct":
+			_, _ = io.WriteString(w, modelInfo)
+		case "/api/models/org/private":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"error":"Invalid credentials in Authorization header"}`)
+		case "/api/models/org/gated":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"error":"Access to model org/gated is restricted"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":"Repository not found"}`)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	key := schemas.Key{Value: *schemas.NewEnvVar("hf_test")}
+
+	t.Run("card_metadata", func(t *testing.T) {
+		model, bifrostErr := provider.fetchModelInfo(ctx, key, "meta-llama/Llama-3.1-8B-Instruct", server.URL+"/api/models/meta-llama/Llama-3.1-8B-Instruct")
+		require.Nil(t, bifrostErr)
+		require.NotNil(t, model)
+		assert.Equal(t, "Bearer hf_test", gotAuth)
+		assert.Equal(t, "huggingface/meta-llama/Llama-3.1-8B-Instruct", model.ID)
+		require.NotNil(t, model.Name)
+		assert.Equal(t, "meta-llama/Llama-3.1-8B-Instruct", *model.Name)
+		require.NotNil(t, model.OwnedBy)
+		assert.Equal(t, "meta-llama", *model.OwnedBy)
+		require.NotNil(t, model.Description)
+		assert.Equal(t, "Multilingual instruction-tuned model", *model.Description)
+		require.NotNil(t, model.License)
+		assert.Equal(t, "llama3.1", *model.License)
+		require.NotNil(t, model.Created)
+		assert.Equal(t, int64(1721292960), *model.Created)
+		assert.Equal(t, []string{"temperature"}, model.SupportedParameters)
+		assert.NotEmpty(t, model.SupportedMethods)
+		require.NotNil(t, model.Gated)
+		assert.True(t, *model.Gated)
+	})
+
+	for _, tc := range []struct {
+		name       string
+		model      string
+		wantStatus int
+	}{
+		{name: "private", model: "org/private", wantStatus: http.StatusUnauthorized},
+		{name: "gated", model: "org/gated", wantStatus: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model, bifrostErr := provider.fetchModelInfo(ctx, key, tc.model, server.URL+"/api/models/"+tc.model)
+			assert.Nil(t, model)
+			require.NotNil(t, bifrostErr)
+			require.NotNil(t, bifrostErr.StatusCode)
+			assert.Equal(t, tc.wantStatus, *bifrostErr.StatusCode)
+			assert.Contains(t, bifrostErr.Error.Message, "model "+tc.model+" is gated or private")
+		})
+	}
+
+	t.Run("not_found", func(t *testing.T) {
+		_, bifrostErr := provider.fetchModelInfo(ctx, key, "org/missing", server.URL+"/api/models/org/missing")
+		require.NotNil(t, bifrostErr)
+		assert.Equal(t, "Repository not found", bifrostErr.Error.Message)
+	})
+}
+
+func TestParseGatedModelMode(t *testing.T) {
+	mode, err := parseGatedModelMode(nil)
+	require.NoError(t, err)
+	assert.Equal(t, GatedModelModeOff, mode)
+
+	mode, err = parseGatedModelMode(map[string]interface{}{"gated_model_mode": " Filter "})
+	require.NoError(t, err)
+	assert.Equal(t, GatedModelModeFilter, mode)
+
+	_, err = parseGatedModelMode(map[string]interface{}{"gated_model_mode": "hide"})
+	require.Error(t, err)
+	_, err = parseGatedModelMode(map[string]interface{}{"gated_model_mode": true})
+	require.Error(t, err)
+}
+
+func TestApplyGatedModelAccess(t *testing.T) {
+	newModels := func() []schemas.Model {
+		return []schemas.Model{
+			{ID: "huggingface/groq/org/open", Name: schemas.Ptr("org/open"), Gated: schemas.Ptr(false)},
+			{ID: "huggingface/groq/org/accepted", Name: schemas.Ptr("org/accepted"), Gated: schemas.Ptr(true)},
+			{ID: "huggingface/together/org/accepted", Name: schemas.Ptr("org/accepted"), Gated: schemas.Ptr(true)},
+			{ID: "huggingface/groq/org/denied", Name: schemas.Ptr("org/denied"), Gated: schemas.Ptr(true)},
+			{ID: "huggingface/groq/org/unknown", Name: schemas.Ptr("org/unknown"), Gated: schemas.Ptr(true)},
+		}
+	}
+	var mu sync.Mutex
+	var checked []string
+	hasAccess := func(modelName string) (bool, bool) {
+		mu.Lock()
+		checked = append(checked, modelName)
+		mu.Unlock()
+		switch modelName {
+		case "org/accepted":
+			return true, true
+		case "org/denied":
+			return false, true
+		}
+		return false, false
+	}
+	ids := func(models []schemas.Model) []string {
+		result := make([]string, 0, len(models))
+		for _, model := range models {
+			result = append(result, model.ID)
+		}
+		return result
+	}
+
+	t.Run("off_skips_checks", func(t *testing.T) {
+		checked = nil
+		models := applyGatedModelAccess(newModels(), GatedModelModeOff, hasAccess)
+		assert.Len(t, models, 5)
+		assert.Empty(t, checked)
+		for _, model := range models {
+			assert.Nil(t, model.Accessible)
+		}
+	})
+
+	t.Run("mark", func(t *testing.T) {
+		checked = nil
+		models := applyGatedModelAccess(newModels(), GatedModelModeMark, hasAccess)
+		require.Len(t, models, 5)
+		assert.ElementsMatch(t, []string{"org/accepted", "org/denied", "org/unknown"}, checked, "each gated repo is checked once")
+		assert.Nil(t, models[0].Accessible, "open models are not checked")
+		require.NotNil(t, models[1].Accessible)
+		assert.True(t, *models[1].Accessible)
+		require.NotNil(t, models[2].Accessible)
+		assert.True(t, *models[2].Accessible)
+		require.NotNil(t, models[3].Accessible)
+		assert.False(t, *models[3].Accessible)
+		assert.Nil(t, models[4].Accessible, "unknown access is left unmarked")
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		checked = nil
+		models := applyGatedModelAccess(newModels(), GatedModelModeFilter, hasAccess)
+		assert.Equal(t, []string{
+			"huggingface/groq/org/open",
+			"huggingface/groq/org/accepted",
+			"huggingface/together/org/accepted",
+			"huggingface/groq/org/unknown",
+		}, ids(models))
+	})
+}
+
+func TestCheckModelAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer hf_test", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/models/org/accepted/auth-check":
+			_, _ = io.WriteString(w, `{}`)
+		case "/api/models/org/gated/auth-check":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"error":"Access to model org/gated is restricted and you are not in the authorized list"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = io.WriteString(w, `{"error":"Internal Error"}`)
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	key := schemas.Key{Value: *schemas.NewEnvVar("hf_test")}
+	authCheckURL := func(modelName string) string {
+		return server.URL + "/api/models/" + modelName + "/auth-check"
+	}
+
+	accessible, known := provider.checkModelAccess(ctx, key, "org/accepted", authCheckURL("org/accepted"))
+	assert.True(t, known)
+	assert.True(t, accessible)
+
+	accessible, known = provider.checkModelAccess(ctx, key, "org/gated", authCheckURL("org/gated"))
+	assert.True(t, known)
+	assert.False(t, accessible)
+
+	_, known = provider.checkModelAccess(ctx, key, "org/broken", authCheckURL("org/broken"))
+	assert.False(t, known)
+
+	// Without a key no gated model is runnable, and the Hub is not asked
+	accessible, known = provider.checkModelAccess(ctx, schemas.Key{}, "org/accepted", "http://127.0.0.1:0/unreachable")
+	assert.True(t, known)
+	assert.False(t, accessible)
+}
+
+func TestListModels_FromRouter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/models", r.URL.Path)
+		require.Equal(t, "Bearer hf_test", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"object":"list","data":[
+			{"id":"meta-llama/Llama-3.3-70B-Instruct","object":"model","created":1733356800,"owned_by":"meta-llama","providers":[
+				{"provider":"groq","status":"live","context_length":131072,"supports_tools":true,"supports_structured_output":false},
+				{"provider":"novita","status":"staging","context_length":131072}
+			]},
+			{"id":"Qwen/Qwen3-32B","object":"model","owned_by":"Qwen","providers":[
+				{"provider":"cerebras","status":"live","supports_structured_output":true},
+				{"provider":"groq","status":"live"}
+			]}
+		]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.ListModelsFromRouter = true
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	request := &schemas.BifrostListModelsRequest{Provider: schemas.HuggingFace}
+	key := schemas.Key{Value: *schemas.NewEnvVar("hf_test"), Models: schemas.WhiteList{"*"}}
+
+	t.Run("every live deployment", func(t *testing.T) {
+		resp, bifrostErr := provider.listModelsByKey(ctx, key, request)
+		require.Nil(t, bifrostErr)
+
+		ids := make([]string, 0, len(resp.Data))
+		for _, model := range resp.Data {
+			ids = append(ids, model.ID)
+		}
+		assert.ElementsMatch(t, []string{
+			"huggingface/groq/meta-llama/Llama-3.3-70B-Instruct",
+			"huggingface/cerebras/Qwen/Qwen3-32B",
+			"huggingface/groq/Qwen/Qwen3-32B",
+		}, ids)
+
+		llamaIndex := slices.IndexFunc(resp.Data, func(model schemas.Model) bool {
+			return model.ID == "huggingface/groq/meta-llama/Llama-3.3-70B-Instruct"
+		})
+		require.GreaterOrEqual(t, llamaIndex, 0)
+		llama := resp.Data[llamaIndex]
+		require.NotNil(t, llama.ContextLength)
+		assert.Equal(t, 131072, *llama.ContextLength)
+		require.NotNil(t, llama.Created)
+		assert.EqualValues(t, 1733356800, *llama.Created)
+		require.NotNil(t, llama.OwnedBy)
+		assert.Equal(t, "meta-llama", *llama.OwnedBy)
+		assert.Equal(t, []string{"tools", "tool_choice"}, llama.SupportedParameters)
+		assert.Contains(t, llama.SupportedMethods, string(schemas.ChatCompletionRequest))
+	})
+
+	t.Run("key preferring an inference provider", func(t *testing.T) {
+		preferringKey := key
+		preferringKey.HuggingFaceKeyConfig = &schemas.HuggingFaceKeyConfig{InferenceProvider: "cerebras"}
+		resp, bifrostErr := provider.listModelsByKey(ctx, preferringKey, request)
+		require.Nil(t, bifrostErr)
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, "huggingface/cerebras/Qwen/Qwen3-32B", resp.Data[0].ID)
+		assert.Equal(t, []string{"response_format"}, resp.Data[0].SupportedParameters)
+	})
+
+	t.Run("gated model mode is rejected", func(t *testing.T) {
+		_, bifrostErr := provider.listModelsByKey(ctx, key, &schemas.BifrostListModelsRequest{
+			Provider:    schemas.HuggingFace,
+			ExtraParams: map[string]interface{}{"gated_model_mode": "filter"},
+		})
+		require.NotNil(t, bifrostErr)
+		assert.Contains(t, bifrostErr.Error.Error.Error(), "list_models_from_router")
+	})
+
+	t.Run("raw router entries", func(t *testing.T) {
+		rawCtx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		rawCtx.SetValue(RawModelListContextKey, true)
+		resp, bifrostErr := provider.listModelsByKey(rawCtx, key, request)
+		require.Nil(t, bifrostErr)
+		raw, ok := resp.ExtraFields.RawResponse.(map[string]interface{})
+		require.True(t, ok)
+		entries, ok := raw["router_models"].([]json.RawMessage)
+		require.True(t, ok)
+		require.Len(t, entries, 2)
+		assert.Contains(t, string(entries[1]), `"Qwen/Qwen3-32B"`)
+	})
+}
@@ -0,0 +1,63 @@
+package huggingface
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// ocrImageURL returns the image of an OCR request: its image_url, or its document_url when
+// that is all it sets.
+func ocrImageURL(bifrostReq *schemas.BifrostOCRRequest) (string, error) {
+	document := bifrostReq.Document
+	if document.ImageURL != nil && *document.ImageURL != "" {
+		return *document.ImageURL, nil
+	}
+	if document.DocumentURL != nil && *document.DocumentURL != "" {
+		return *document.DocumentURL, nil
+	}
+	return "", fmt.Errorf("image-to-text needs an image: set document.image_url to a URL or base64 data URL")
+}
+
+// ToHuggingFaceImageToTextRequest converts a Bifrost OCR request and its image bytes into the
+// hf-inference image-to-text payload.
+func ToHuggingFaceImageToTextRequest(bifrostReq *schemas.BifrostOCRRequest, image []byte) *HuggingFaceImageToTextRequest {
+	if bifrostReq == nil {
+		return nil
+	}
+	req := &HuggingFaceImageToTextRequest{
+		Inputs: base64.StdEncoding.EncodeToString(image),
+	}
+	if bifrostReq.Params != nil {
+		req.Options = extractInferenceOptions(bifrostReq.Params.ExtraParams)
+		req.ExtraParams = bifrostReq.Params.ExtraParams
+	}
+	return req
+}
+
+// UnmarshalHuggingFaceImageToTextResponse decodes image-to-text output into a single-page OCR
+// response whose markdown is the generated caption. Captioning pipelines answer with a list,
+// some custom handlers with a bare object.
+func UnmarshalHuggingFaceImageToTextResponse(data []byte, imageSize int) (*schemas.BifrostOCRResponse, error) {
+	var results []HuggingFaceImageToTextResult
+	if err := sonic.Unmarshal(data, &results); err != nil {
+		var result HuggingFaceImageToTextResult
+		if objErr := sonic.Unmarshal(data, &result); objErr != nil {
+			return nil, fmt.Errorf("failed to unmarshal image-to-text response: %w", err)
+		}
+		results = []HuggingFaceImageToTextResult{result}
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("image-to-text response has no generated text")
+	}
+
+	return &schemas.BifrostOCRResponse{
+		Pages: []schemas.OCRPage{{Index: 0, Markdown: results[0].GeneratedText}},
+		UsageInfo: &schemas.OCRUsageInfo{
+			PagesProcessed: 1,
+			DocSizeBytes:   imageSize,
+		},
+	}, nil
+}
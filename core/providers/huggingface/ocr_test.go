@@ -0,0 +1,103 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCR_ImageToText(t *testing.T) {
+	t.Parallel()
+
+	const model = "Salesforce/blip-image-captioning-large"
+	image := []byte("\x89PNG\r\n\x1a\nfake image bytes")
+	var capturedPath string
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cat.png" {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(image)
+			return
+		}
+		capturedPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"generated_text":"a cat sitting on a couch"}]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "image-to-text", ProviderModelID: model},
+	})
+
+	inputs := map[string]string{
+		"url":        server.URL + "/cat.png",
+		"data_url":   "data:image/png;base64," + base64.StdEncoding.EncodeToString(image),
+		"raw_base64": base64.StdEncoding.EncodeToString(image),
+	}
+	for name, imageURL := range inputs {
+		t.Run(name, func(t *testing.T) {
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.OCR(ctx, schemas.Key{}, &schemas.BifrostOCRRequest{
+				Provider: schemas.HuggingFace,
+				Model:    model,
+				Document: schemas.OCRDocument{Type: schemas.OCRDocumentTypeImageURL, ImageURL: schemas.Ptr(imageURL)},
+			})
+			require.Nil(t, bifrostErr)
+			assert.Equal(t, "/hf-inference/models/"+model, capturedPath)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(image), captured["inputs"])
+
+			require.Len(t, resp.Pages, 1)
+			assert.Equal(t, "a cat sitting on a couch", resp.Pages[0].Markdown)
+			require.NotNil(t, resp.UsageInfo)
+			assert.Equal(t, len(image), resp.UsageInfo.DocSizeBytes)
+			assert.Equal(t, model, resp.Model)
+		})
+	}
+}
+
+func TestOCR_ModelLoadingStaysRetryable(t *testing.T) {
+	t.Parallel()
+
+	const model = "Salesforce/blip-image-captioning-large"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":"Model Salesforce/blip-image-captioning-large is currently loading","estimated_time":20.1}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "image-to-text", ProviderModelID: model},
+	})
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.OCR(ctx, schemas.Key{}, &schemas.BifrostOCRRequest{
+		Provider: schemas.HuggingFace,
+		Model:    model,
+		Document: schemas.OCRDocument{Type: schemas.OCRDocumentTypeImageURL, ImageURL: schemas.Ptr(base64.StdEncoding.EncodeToString([]byte("img")))},
+	})
+	require.NotNil(t, bifrostErr)
+	require.NotNil(t, bifrostErr.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, *bifrostErr.StatusCode)
+}
+
+func TestOCR_RequiresImage(t *testing.T) {
+	provider := newTestHuggingFaceProvider(t, "http://127.0.0.1:0")
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.OCR(ctx, schemas.Key{}, &schemas.BifrostOCRRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "Salesforce/blip-image-captioning-large",
+		Document: schemas.OCRDocument{Type: schemas.OCRDocumentTypeImageURL},
+	})
+	require.NotNil(t, bifrostErr)
+}
@@ -0,0 +1,123 @@
+package huggingface
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+)
+
+// ModelReadiness is a best-effort hint about whether a model is loaded and ready to serve.
+type ModelReadiness string
+
+const (
+	ModelReadinessWarm    ModelReadiness = "warm"
+	ModelReadinessCold    ModelReadiness = "cold"
+	ModelReadinessUnknown ModelReadiness = "unknown"
+)
+
+// modelReadinessTTL is how long a response keeps saying whether its model is warm. Serverless
+// hf-inference unloads models that sit idle, so an older observation says little.
+const modelReadinessTTL = 5 * time.Minute
+
+// modelReadinessObservation is the readiness read off the last informative response from a
+// deployment.
+type modelReadinessObservation struct {
+	readiness  ModelReadiness
+	observedAt time.Time
+}
+
+// EstimateModelReadiness reports whether model is likely loaded and ready to serve on key, so
+// schedulers can route latency-sensitive traffic to warm models. A response from the model's
+// deployment within the last modelReadinessTTL decides it; failing that, dedicated endpoints and
+// third-party inference providers, which keep their models deployed, count as warm, while
+// serverless hf-inference models are unknown. Streamed responses are not observed.
+func (provider *HuggingFaceProvider) EstimateModelReadiness(key schemas.Key, model string) ModelReadiness {
+	endpointURL, err := dedicatedEndpointURL(key, model)
+	if err != nil {
+		return ModelReadinessUnknown
+	}
+	inferenceProvider, modelName, err := splitIntoModelProvider(applyKeyInferenceProvider(key, model, ""))
+	if err != nil {
+		return ModelReadinessUnknown
+	}
+	deployment := modelDeployment(endpointURL, inferenceProvider, modelName)
+
+	if value, ok := provider.modelReadiness.Load(deployment); ok {
+		if observation := value.(modelReadinessObservation); time.Since(observation.observedAt) < modelReadinessTTL {
+			return observation.readiness
+		}
+	}
+	if isAlwaysDeployed(deployment) {
+		return ModelReadinessWarm
+	}
+	return ModelReadinessUnknown
+}
+
+// recordModelReadiness remembers what a response says about its deployment's readiness. Responses
+// that say nothing either way leave the previous observation in place.
+func (provider *HuggingFaceProvider) recordModelReadiness(deployment string, statusCode int, headers map[string]string, body []byte) {
+	if deployment == "" {
+		return
+	}
+	readiness := classifyModelReadiness(isAlwaysDeployed(deployment), statusCode, headers, body)
+	if readiness == ModelReadinessUnknown {
+		return
+	}
+	provider.modelReadiness.Store(deployment, modelReadinessObservation{readiness: readiness, observedAt: time.Now()})
+}
+
+// modelDeployment names what serves modelName: its dedicated endpoint, or the model on its
+// router backend.
+func modelDeployment(endpointURL string, inferenceProvider inferenceProvider, modelName string) string {
+	if endpointURL != "" {
+		return endpointURL
+	}
+	if inferenceProvider == "" {
+		return modelName
+	}
+	return string(inferenceProvider) + "/" + modelName
+}
+
+// isAlwaysDeployed reports whether deployment keeps its model loaded between requests, as
+// dedicated endpoints and third-party inference providers do, unlike serverless hf-inference.
+func isAlwaysDeployed(deployment string) bool {
+	if strings.Contains(deployment, "://") {
+		return true
+	}
+	inferenceProvider, _, err := splitIntoModelProvider(deployment)
+	return err == nil && inferenceProvider != "" && inferenceProvider != hfInference && inferenceProvider != auto
+}
+
+// classifyModelReadiness reads a model's readiness off one response. A 503 carrying
+// "estimated_time" or a loading message means the model is being spun up. Otherwise a successful
+// response means an always-deployed model is warm, while serverless hf-inference only says so
+// through a cache hit or a compute-time header.
+func classifyModelReadiness(alwaysDeployed bool, statusCode int, headers map[string]string, body []byte) ModelReadiness {
+	if statusCode == fasthttp.StatusServiceUnavailable {
+		if bytes.Contains(body, []byte("estimated_time")) || bytes.Contains(bytes.ToLower(body), []byte("is currently loading")) {
+			return ModelReadinessCold
+		}
+		return ModelReadinessUnknown
+	}
+
+	if alwaysDeployed {
+		if statusCode == fasthttp.StatusOK {
+			return ModelReadinessWarm
+		}
+		return ModelReadinessUnknown
+	}
+
+	if computeType, ok := getHeaderValue(headers, "x-compute-type"); ok && strings.EqualFold(computeType, "cache") {
+		return ModelReadinessWarm
+	}
+	if statusCode == fasthttp.StatusOK {
+		if _, ok := getHeaderValue(headers, "x-compute-time"); ok {
+			return ModelReadinessWarm
+		}
+	}
+
+	return ModelReadinessUnknown
+}
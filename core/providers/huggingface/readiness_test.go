@@ -0,0 +1,146 @@
+package huggingface
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyModelReadiness(t *testing.T) {
+	tests := []struct {
+		name           string
+		alwaysDeployed bool
+		statusCode     int
+		headers        map[string]string
+		body           string
+		want           ModelReadiness
+	}{
+		{
+			name:       "hf_inference_loading_with_estimated_time",
+			statusCode: 503,
+			body:       `{"error":"Model is currently loading","estimated_time":20.5}`,
+			want:       ModelReadinessCold,
+		},
+		{
+			name:       "hf_inference_loading_message_only",
+			statusCode: 503,
+			body:       `{"error":"Model foo/bar is currently loading"}`,
+			want:       ModelReadinessCold,
+		},
+		{
+			name:       "hf_inference_cache_hit",
+			statusCode: 200,
+			headers:    map[string]string{"X-Compute-Type": "cache"},
+			want:       ModelReadinessWarm,
+		},
+		{
+			name:       "hf_inference_computed",
+			statusCode: 200,
+			headers:    map[string]string{"x-compute-time": "0.041"},
+			want:       ModelReadinessWarm,
+		},
+		{
+			name:       "hf_inference_no_signal",
+			statusCode: 200,
+			want:       ModelReadinessUnknown,
+		},
+		{
+			name:           "always_deployed_ok",
+			alwaysDeployed: true,
+			statusCode:     200,
+			want:           ModelReadinessWarm,
+		},
+		{
+			name:           "always_deployed_scaled_to_zero",
+			alwaysDeployed: true,
+			statusCode:     503,
+			body:           `{"error":"Service Unavailable","estimated_time":120}`,
+			want:           ModelReadinessCold,
+		},
+		{
+			name:           "generic_unavailable",
+			alwaysDeployed: true,
+			statusCode:     503,
+			body:           `{"error":"upstream overloaded"}`,
+			want:           ModelReadinessUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyModelReadiness(tt.alwaysDeployed, tt.statusCode, tt.headers, []byte(tt.body))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsAlwaysDeployed(t *testing.T) {
+	assert.True(t, isAlwaysDeployed("https://abc123.us-east-1.aws.endpoints.huggingface.cloud"))
+	assert.True(t, isAlwaysDeployed("groq/meta-llama/Llama-3.3-70B-Instruct"))
+	assert.False(t, isAlwaysDeployed("hf-inference/BAAI/bge-m3"))
+	assert.False(t, isAlwaysDeployed("auto/BAAI/bge-m3"))
+	assert.False(t, isAlwaysDeployed("BAAI/bge-m3"))
+}
+
+func TestEstimateModelReadiness(t *testing.T) {
+	const modelName = "BAAI/bge-m3"
+
+	var loading atomic.Bool
+	loading.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if loading.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, `{"error":"Model BAAI/bge-m3 is currently loading","estimated_time":20.0}`)
+			return
+		}
+		w.Header().Set("x-compute-type", "cache")
+		_, _ = io.WriteString(w, `[[0.1, 0.2]]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: modelName},
+	})
+	key := schemas.Key{}
+	model := "hf-inference/" + modelName
+	embed := func() *schemas.BifrostError {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		_, bifrostErr := provider.Embedding(ctx, key, &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    model,
+			Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+		})
+		return bifrostErr
+	}
+
+	assert.Equal(t, ModelReadinessUnknown, provider.EstimateModelReadiness(key, model), "nothing seen yet")
+
+	require.NotNil(t, embed())
+	assert.Equal(t, ModelReadinessCold, provider.EstimateModelReadiness(key, model))
+
+	loading.Store(false)
+	require.Nil(t, embed())
+	assert.Equal(t, ModelReadinessWarm, provider.EstimateModelReadiness(key, model))
+
+	// Serverless models unload when idle, so an old observation no longer counts
+	provider.modelReadiness.Store(model, modelReadinessObservation{readiness: ModelReadinessWarm, observedAt: time.Now().Add(-2 * modelReadinessTTL)})
+	assert.Equal(t, ModelReadinessUnknown, provider.EstimateModelReadiness(key, model))
+
+	t.Run("always deployed", func(t *testing.T) {
+		assert.Equal(t, ModelReadinessWarm, provider.EstimateModelReadiness(key, "groq/meta-llama/Llama-3.3-70B-Instruct"))
+		endpointKey := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			Endpoints: map[string]string{modelName: "https://abc123.us-east-1.aws.endpoints.huggingface.cloud"},
+		}}
+		assert.Equal(t, ModelReadinessWarm, provider.EstimateModelReadiness(endpointKey, modelName))
+	})
+}
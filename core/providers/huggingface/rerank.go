@@ -0,0 +1,135 @@
+package huggingface
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bytedance/sonic"
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// ToHuggingFaceRerankRequest converts a Bifrost rerank request into the payload for task:
+// "text-ranking" for cross-encoders, "sentence-similarity" for bi-encoders.
+func ToHuggingFaceRerankRequest(bifrostReq *schemas.BifrostRerankRequest, task string) (providerUtils.RequestBodyWithExtraParams, error) {
+	if bifrostReq == nil {
+		return nil, nil
+	}
+	if len(bifrostReq.Documents) == 0 {
+		return nil, fmt.Errorf("documents are required for rerank request")
+	}
+	var extraParams map[string]interface{}
+	if bifrostReq.Params != nil {
+		extraParams = bifrostReq.Params.ExtraParams
+	}
+
+	switch task {
+	case "text-ranking":
+		req := &HuggingFaceTextRankingRequest{
+			Query:       bifrostReq.Query,
+			Texts:       make([]string, len(bifrostReq.Documents)),
+			Options:     extractInferenceOptions(extraParams),
+			ExtraParams: extraParams,
+		}
+		for i, doc := range bifrostReq.Documents {
+			req.Texts[i] = doc.Text
+		}
+		return req, nil
+	case "sentence-similarity":
+		return ToHuggingFaceSentenceSimilarityRequest(bifrostReq)
+	default:
+		return nil, fmt.Errorf("unsupported rerank task %q", task)
+	}
+}
+
+// ToHuggingFaceSentenceSimilarityRequest converts a Bifrost rerank request into the hf-inference
+// sentence-similarity payload: the query is the source sentence and the documents the candidates.
+func ToHuggingFaceSentenceSimilarityRequest(bifrostReq *schemas.BifrostRerankRequest) (*HuggingFaceSentenceSimilarityRequest, error) {
+	if bifrostReq == nil {
+		return nil, nil
+	}
+	if len(bifrostReq.Documents) == 0 {
+		return nil, fmt.Errorf("documents are required for rerank request")
+	}
+
+	req := &HuggingFaceSentenceSimilarityRequest{
+		Inputs: HuggingFaceSentenceSimilarityInputs{
+			SourceSentence: bifrostReq.Query,
+			Sentences:      make([]string, len(bifrostReq.Documents)),
+		},
+	}
+	for i, doc := range bifrostReq.Documents {
+		req.Inputs.Sentences[i] = doc.Text
+	}
+	if bifrostReq.Params != nil {
+		req.Options = extractInferenceOptions(bifrostReq.Params.ExtraParams)
+		req.ExtraParams = bifrostReq.Params.ExtraParams
+	}
+	return req, nil
+}
+
+// UnmarshalHuggingFaceRerankResponse decodes the response of task into rerank results ordered
+// by descending score. topN (when positive) keeps only the best results; returnDocuments
+// attaches each document.
+func UnmarshalHuggingFaceRerankResponse(data []byte, task string, documents []schemas.RerankDocument, topN *int, returnDocuments bool) (*schemas.BifrostRerankResponse, error) {
+	if task == "sentence-similarity" {
+		return UnmarshalHuggingFaceSentenceSimilarityResponse(data, documents, topN, returnDocuments)
+	}
+
+	var ranked []HuggingFaceTextRankingResult
+	if err := sonic.Unmarshal(data, &ranked); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal text-ranking response: %w", err)
+	}
+	seen := make(map[int]struct{}, len(ranked))
+	results := make([]schemas.RerankResult, 0, len(ranked))
+	for _, item := range ranked {
+		if item.Index < 0 || item.Index >= len(documents) {
+			return nil, fmt.Errorf("text-ranking result index %d out of range", item.Index)
+		}
+		if _, dup := seen[item.Index]; dup {
+			return nil, fmt.Errorf("text-ranking result index %d repeated", item.Index)
+		}
+		seen[item.Index] = struct{}{}
+		results = append(results, schemas.RerankResult{Index: item.Index, RelevanceScore: item.Score})
+	}
+	return &schemas.BifrostRerankResponse{Results: rankRerankResults(results, documents, topN, returnDocuments)}, nil
+}
+
+// UnmarshalHuggingFaceSentenceSimilarityResponse converts the sentence-similarity score array,
+// one score per document in request order, into rerank results ordered by descending score.
+func UnmarshalHuggingFaceSentenceSimilarityResponse(data []byte, documents []schemas.RerankDocument, topN *int, returnDocuments bool) (*schemas.BifrostRerankResponse, error) {
+	var scores []float64
+	if err := sonic.Unmarshal(data, &scores); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sentence-similarity response: %w", err)
+	}
+	if len(scores) != len(documents) {
+		return nil, fmt.Errorf("expected %d sentence-similarity scores, got %d", len(documents), len(scores))
+	}
+
+	results := make([]schemas.RerankResult, len(scores))
+	for i, score := range scores {
+		results[i] = schemas.RerankResult{Index: i, RelevanceScore: score}
+	}
+	return &schemas.BifrostRerankResponse{Results: rankRerankResults(results, documents, topN, returnDocuments)}, nil
+}
+
+// rankRerankResults orders results by descending score (ties keep document order), keeps the
+// topN best when topN is positive, and attaches documents when asked to.
+func rankRerankResults(results []schemas.RerankResult, documents []schemas.RerankDocument, topN *int, returnDocuments bool) []schemas.RerankResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].RelevanceScore == results[j].RelevanceScore {
+			return results[i].Index < results[j].Index
+		}
+		return results[i].RelevanceScore > results[j].RelevanceScore
+	})
+	if topN != nil && *topN > 0 && *topN < len(results) {
+		results = results[:*topN]
+	}
+	if returnDocuments {
+		for i := range results {
+			doc := documents[results[i].Index]
+			results[i].Document = &doc
+		}
+	}
+	return results
+}
@@ -0,0 +1,197 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRerank_SentenceSimilarity(t *testing.T) {
+	t.Parallel()
+
+	const model = "sentence-transformers/all-MiniLM-L6-v2"
+	var capturedPath string
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[0.12, 0.87, 0.45]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "sentence-similarity", ProviderModelID: model},
+	})
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	request := &schemas.BifrostRerankRequest{
+		Provider: schemas.HuggingFace,
+		Model:    model,
+		Query:    "How do I bake bread?",
+		Documents: []schemas.RerankDocument{
+			{Text: "The stock market closed higher."},
+			{Text: "Knead the dough and let it rise before baking."},
+			{Text: "Ovens should be preheated."},
+		},
+		Params: &schemas.RerankParameters{TopN: schemas.Ptr(2), ReturnDocuments: schemas.Ptr(true)},
+	}
+
+	resp, bifrostErr := provider.Rerank(ctx, schemas.Key{}, request)
+	require.Nil(t, bifrostErr)
+	assert.Equal(t, "/hf-inference/models/"+model+"/pipeline/sentence-similarity", capturedPath)
+	assert.Equal(t, map[string]interface{}{
+		"inputs": map[string]interface{}{
+			"source_sentence": "How do I bake bread?",
+			"sentences": []interface{}{
+				"The stock market closed higher.",
+				"Knead the dough and let it rise before baking.",
+				"Ovens should be preheated.",
+			},
+		},
+	}, captured)
+
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, 1, resp.Results[0].Index)
+	assert.Equal(t, 0.87, resp.Results[0].RelevanceScore)
+	require.NotNil(t, resp.Results[0].Document)
+	assert.Equal(t, "Knead the dough and let it rise before baking.", resp.Results[0].Document.Text)
+	assert.Equal(t, 2, resp.Results[1].Index)
+	assert.Equal(t, model, resp.Model)
+}
+
+func TestRerank_RejectsOtherInferenceProviders(t *testing.T) {
+	t.Parallel()
+
+	provider := newTestHuggingFaceProvider(t, "http://127.0.0.1:1")
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.Rerank(ctx, schemas.Key{}, &schemas.BifrostRerankRequest{
+		Model:     "together/BAAI/bge-reranker-v2-m3",
+		Query:     "q",
+		Documents: []schemas.RerankDocument{{Text: "d"}},
+	})
+	require.NotNil(t, bifrostErr)
+}
+
+func TestUnmarshalHuggingFaceSentenceSimilarityResponse(t *testing.T) {
+	documents := []schemas.RerankDocument{{Text: "a"}, {Text: "b"}}
+
+	t.Run("score_count_mismatch", func(t *testing.T) {
+		_, err := UnmarshalHuggingFaceSentenceSimilarityResponse([]byte(`[0.5]`), documents, nil, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("all_results_without_documents", func(t *testing.T) {
+		resp, err := UnmarshalHuggingFaceSentenceSimilarityResponse([]byte(`[0.5, 0.5]`), documents, nil, false)
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, 0, resp.Results[0].Index, "ties keep request order")
+		assert.Nil(t, resp.Results[0].Document)
+	})
+}
+
+func TestRerank_CrossEncoder(t *testing.T) {
+	t.Parallel()
+
+	const model = "BAAI/bge-reranker-v2-m3"
+	var capturedPath string
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"index":2,"score":0.98},{"index":0,"score":0.02},{"index":1,"score":0.0004}]`)
+	}))
+	defer server.Close()
+
+	documents := []schemas.RerankDocument{{Text: "Paris is in France."}, {Text: "Bananas are yellow."}, {Text: "The capital of France is Paris."}}
+
+	t.Run("serverless_text_ranking", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+			hfInference: {ProviderTask: "text-ranking", ProviderModelID: model},
+		})
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+		resp, bifrostErr := provider.Rerank(ctx, schemas.Key{}, &schemas.BifrostRerankRequest{
+			Model:     model,
+			Query:     "What is the capital of France?",
+			Documents: documents,
+		})
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, "/hf-inference/models/"+model+"/pipeline/text-ranking", capturedPath)
+		assert.Equal(t, "What is the capital of France?", captured["query"])
+		assert.Equal(t, []interface{}{"Paris is in France.", "Bananas are yellow.", "The capital of France is Paris."}, captured["texts"])
+
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, []int{2, 0, 1}, []int{resp.Results[0].Index, resp.Results[1].Index, resp.Results[2].Index})
+		assert.Equal(t, 0.98, resp.Results[0].RelevanceScore)
+		assert.Nil(t, resp.Results[0].Document)
+	})
+
+	t.Run("dedicated_endpoint", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, "http://127.0.0.1:1")
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		key := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{Endpoints: map[string]string{model: server.URL + "/"}}}
+
+		resp, bifrostErr := provider.Rerank(ctx, key, &schemas.BifrostRerankRequest{
+			Model:     model,
+			Query:     "What is the capital of France?",
+			Documents: documents,
+			Params:    &schemas.RerankParameters{TopN: schemas.Ptr(1), ReturnDocuments: schemas.Ptr(true)},
+		})
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, "/rerank", capturedPath)
+		require.Len(t, resp.Results, 1)
+		require.NotNil(t, resp.Results[0].Document)
+		assert.Equal(t, "The capital of France is Paris.", resp.Results[0].Document.Text)
+	})
+
+	t.Run("mapping_without_rerank_task", func(t *testing.T) {
+		provider := newTestHuggingFaceProvider(t, server.URL)
+		provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+			hfInference: {ProviderTask: "feature-extraction", ProviderModelID: model},
+		})
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+		_, bifrostErr := provider.Rerank(ctx, schemas.Key{}, &schemas.BifrostRerankRequest{Model: model, Query: "q", Documents: documents})
+		require.NotNil(t, bifrostErr)
+	})
+}
+
+func TestUnmarshalHuggingFaceRerankResponse_TextRankingValidation(t *testing.T) {
+	documents := []schemas.RerankDocument{{Text: "a"}, {Text: "b"}}
+
+	_, err := UnmarshalHuggingFaceRerankResponse([]byte(`[{"index":5,"score":0.5}]`), "text-ranking", documents, nil, false)
+	assert.Error(t, err)
+
+	_, err = UnmarshalHuggingFaceRerankResponse([]byte(`[{"index":0,"score":0.5},{"index":0,"score":0.4}]`), "text-ranking", documents, nil, false)
+	assert.Error(t, err)
+}
+
+func TestToHuggingFaceRerankRequest_InferenceOptions(t *testing.T) {
+	for _, task := range []string{"text-ranking", "sentence-similarity"} {
+		t.Run(task, func(t *testing.T) {
+			req, err := ToHuggingFaceRerankRequest(&schemas.BifrostRerankRequest{
+				Query:     "q",
+				Documents: []schemas.RerankDocument{{Text: "a"}},
+				Params: &schemas.RerankParameters{
+					ExtraParams: map[string]interface{}{"wait_for_model": true},
+				},
+			}, task)
+			require.NoError(t, err)
+			assert.Empty(t, req.GetExtraParams())
+
+			body, marshalErr := json.Marshal(req)
+			require.NoError(t, marshalErr)
+			assert.Contains(t, string(body), `"options":{"wait_for_model":true}`)
+		})
+	}
+}
@@ -29,6 +29,35 @@ func ToHuggingFaceResponsesRequest(bifrostReq *schemas.BifrostResponsesRequest)
 	return hfReq, nil
 }
 
+// prepareResponsesRequest returns a shallow copy of the Responses request with Model rewritten to
+// the deployment the key's aliases and preferred inference provider resolve it to, leaving the
+// caller's request untouched.
+func (provider *HuggingFaceProvider) prepareResponsesRequest(ctx *schemas.BifrostContext, key schemas.Key, request *schemas.BifrostResponsesRequest) *schemas.BifrostResponsesRequest {
+	prepared := *request
+	prepared.Model = applyKeyInferenceProvider(key, provider.resolveModelAlias(ctx, key, request.Model), "")
+	return &prepared
+}
+
+// responsesStreamPostHookRunner wraps postHookRunner so every streamed Responses chunk reports the
+// deployment that served it. Core fills ResolvedModelUsed from the key's aliases alone, which misses
+// the provider's alias namespaces and the key's preferred inference provider, and the chat stream
+// post-converter is not applied on the Responses fallback path.
+func (provider *HuggingFaceProvider) responsesStreamPostHookRunner(postHookRunner schemas.PostHookRunner, deployment string) schemas.PostHookRunner {
+	var hubURL string
+	if _, modelName, err := splitIntoModelProvider(deployment); err == nil {
+		hubURL = provider.modelHubURL(modelName)
+	}
+	return func(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, err *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError) {
+		result, err = postHookRunner(ctx, result, err)
+		if result == nil || result.ResponsesStreamResponse == nil {
+			return result, err
+		}
+		result.ResponsesStreamResponse.ExtraFields.ResolvedModelUsed = deployment
+		result.ResponsesStreamResponse.ExtraFields.ModelHubURL = hubURL
+		return result, err
+	}
+}
+
 // ToBifrostResponsesResponseFromHuggingFace converts a Bifrost chat response into the
 // Bifrost Responses response shape, preserving provider metadata.
 func ToBifrostResponsesResponseFromHuggingFace(resp *schemas.BifrostChatResponse, requestedModel string) (*schemas.BifrostResponsesResponse, error) {
@@ -0,0 +1,226 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHuggingFaceResponsesRequest(model string) *schemas.BifrostResponsesRequest {
+	return &schemas.BifrostResponsesRequest{
+		Provider: schemas.HuggingFace,
+		Model:    model,
+		Input: []schemas.ResponsesMessage{
+			{
+				Type:    schemas.Ptr(schemas.ResponsesMessageTypeMessage),
+				Role:    schemas.Ptr(schemas.ResponsesInputMessageRoleUser),
+				Content: &schemas.ResponsesMessageContent{ContentStr: schemas.Ptr("hello")},
+			},
+		},
+	}
+}
+
+func TestResponses_SendsResolvedAliasModel(t *testing.T) {
+	var sentModel string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		node, _ := sonic.Get(body, "model")
+		sentModel, _ = node.String()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	key := schemas.Key{
+		Aliases:              schemas.KeyAliases{"fast": "meta-llama/Llama-3.1-8B-Instruct"},
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: "groq"},
+	}
+	// Core has already resolved "fast" when the provider runs
+	request := testHuggingFaceResponsesRequest("meta-llama/Llama-3.1-8B-Instruct")
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Responses(ctx, key, request)
+	require.Nil(t, bifrostErr)
+	require.NotNil(t, resp)
+
+	assert.Equal(t, "meta-llama/Llama-3.1-8B-Instruct:groq", sentModel)
+	assert.Equal(t, "meta-llama/Llama-3.1-8B-Instruct", request.Model, "caller's request must not be rewritten")
+}
+
+func TestResponses_LogsAliasResolutionOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	logger := &debugRecordingLogger{}
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.logger = logger
+	provider.huggingFaceConfig.LogAliasResolution = true
+	key := schemas.Key{Aliases: schemas.KeyAliases{"quick*": "groq/meta-llama/Llama-3.1-8B-Instruct"}}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.Responses(ctx, key, testHuggingFaceResponsesRequest("quick-8b"))
+	require.Nil(t, bifrostErr)
+
+	var aliasLogs int
+	for _, message := range logger.messages {
+		if strings.Contains(message, "model alias resolved") {
+			aliasLogs++
+		}
+	}
+	assert.Equal(t, 1, aliasLogs)
+}
+
+func TestPrepareResponsesRequest_ClonesRequest(t *testing.T) {
+	provider := newTestHuggingFaceProvider(t, "http://unused")
+	key := schemas.Key{
+		Aliases:              schemas.KeyAliases{"fast": "org/model"},
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: "hf-inference"},
+	}
+	request := testHuggingFaceResponsesRequest("org/model")
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	prepared := provider.prepareResponsesRequest(ctx, key, request)
+	assert.Equal(t, "hf-inference/org/model", prepared.Model)
+	assert.Equal(t, "org/model", request.Model)
+	assert.Equal(t, request.Input, prepared.Input)
+}
+
+func TestResponsesStream_DecoratesDeploymentMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"hi\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.IncludeModelHubURL = true
+	key := schemas.Key{
+		Aliases:              schemas.KeyAliases{"fast": "meta-llama/Llama-3.1-8B-Instruct"},
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: "groq"},
+	}
+
+	// A post-hook runner that drops some chunks must not trip the decoration
+	var calls int
+	postHookRunner := func(ctx *schemas.BifrostContext, result *schemas.BifrostResponse, err *schemas.BifrostError) (*schemas.BifrostResponse, *schemas.BifrostError) {
+		calls++
+		if calls == 1 {
+			return nil, nil
+		}
+		return result, err
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.ResponsesStream(ctx, postHookRunner, nil, key, testHuggingFaceResponsesRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.Nil(t, bifrostErr)
+
+	var chunks []*schemas.BifrostResponsesStreamResponse
+	timeout := time.After(5 * time.Second)
+collect:
+	for {
+		select {
+		case chunk, ok := <-stream:
+			if !ok {
+				break collect
+			}
+			if chunk == nil {
+				continue
+			}
+			require.Nil(t, chunk.BifrostError)
+			if chunk.BifrostResponsesStreamResponse != nil {
+				chunks = append(chunks, chunk.BifrostResponsesStreamResponse)
+			}
+		case <-timeout:
+			t.Fatal("stream did not close")
+		}
+	}
+
+	require.NotEmpty(t, chunks)
+	for _, chunk := range chunks {
+		assert.Equal(t, "groq/meta-llama/Llama-3.1-8B-Instruct", chunk.ExtraFields.ResolvedModelUsed)
+		assert.Equal(t, "https://huggingface.co/meta-llama/Llama-3.1-8B-Instruct", chunk.ExtraFields.ModelHubURL)
+	}
+}
+
+func TestResponsesStream_StreamFallbackKeepsDeploymentMetadata(t *testing.T) {
+	server := newStreamUnsupportedServer(t)
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.huggingFaceConfig.StreamFallback = true
+	provider.huggingFaceConfig.IncludeModelHubURL = true
+	key := schemas.Key{
+		Aliases:              schemas.KeyAliases{"fast": "meta-llama/Llama-3.1-8B-Instruct"},
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: "groq"},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.ResponsesStream(ctx, noopPostHookRunner, nil, key, testHuggingFaceResponsesRequest("meta-llama/Llama-3.1-8B-Instruct"))
+	require.Nil(t, bifrostErr)
+
+	var chunks []*schemas.BifrostResponsesStreamResponse
+	timeout := time.After(5 * time.Second)
+collect:
+	for {
+		select {
+		case chunk, ok := <-stream:
+			if !ok {
+				break collect
+			}
+			require.Nil(t, chunk.BifrostError)
+			if chunk.BifrostResponsesStreamResponse != nil {
+				chunks = append(chunks, chunk.BifrostResponsesStreamResponse)
+			}
+		case <-timeout:
+			t.Fatal("stream did not close")
+		}
+	}
+
+	require.NotEmpty(t, chunks)
+	assert.Equal(t, schemas.ResponsesStreamResponseTypeCompleted, chunks[len(chunks)-1].Type)
+	for _, chunk := range chunks {
+		assert.Equal(t, "groq/meta-llama/Llama-3.1-8B-Instruct", chunk.ExtraFields.ResolvedModelUsed)
+		assert.Equal(t, "https://huggingface.co/meta-llama/Llama-3.1-8B-Instruct", chunk.ExtraFields.ModelHubURL)
+	}
+}
+
+func TestResponses_ForwardsMetadata(t *testing.T) {
+	var sentMetadata map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = sonic.ConfigDefault.NewDecoder(r.Body).Decode(&body)
+		sentMetadata, _ = body["metadata"].(map[string]interface{})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	key := schemas.Key{Aliases: schemas.KeyAliases{"fast": "groq/meta-llama/Llama-3.1-8B-Instruct"}}
+	metadata := map[string]any{"trace_id": "abc-123", "team": "search"}
+	request := testHuggingFaceResponsesRequest("groq/meta-llama/Llama-3.1-8B-Instruct")
+	request.Params = &schemas.ResponsesParameters{Metadata: &metadata}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.Responses(ctx, key, request)
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, map[string]interface{}{"trace_id": "abc-123", "team": "search"}, sentMetadata)
+	assert.Equal(t, "groq/meta-llama/Llama-3.1-8B-Instruct", request.Model)
+	require.NotNil(t, request.Params.Metadata)
+	assert.Equal(t, map[string]any{"trace_id": "abc-123", "team": "search"}, *request.Params.Metadata)
+}
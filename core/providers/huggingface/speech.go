@@ -107,12 +107,27 @@ func ToHuggingFaceSpeechRequest(request *schemas.BifrostSpeechRequest) (*Hugging
 
 			hfRequest.Parameters.GenerationParameters = genParams
 		}
+		hfRequest.ExtraParams = request.Params.ExtraParams
 	}
-	hfRequest.ExtraParams = request.Params.ExtraParams
 
 	return hfRequest, nil
 }
 
+// ToHuggingFaceInferenceSpeechRequest builds the hf-inference Text To Speech payload, reusing
+// the parameter mapping of ToHuggingFaceSpeechRequest.
+func ToHuggingFaceInferenceSpeechRequest(request *schemas.BifrostSpeechRequest) (*HuggingFaceInferenceSpeechRequest, error) {
+	hfRequest, err := ToHuggingFaceSpeechRequest(request)
+	if err != nil || hfRequest == nil {
+		return nil, err
+	}
+
+	return &HuggingFaceInferenceSpeechRequest{
+		Inputs:      hfRequest.Text,
+		Parameters:  hfRequest.Parameters,
+		ExtraParams: hfRequest.ExtraParams,
+	}, nil
+}
+
 func (response *HuggingFaceSpeechResponse) ToBifrostSpeechResponse(requestedModel string, audioData []byte) (*schemas.BifrostSpeechResponse, error) {
 	if response == nil {
 		return nil, nil
@@ -0,0 +1,100 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeech_HFInferenceReturnsRawAudio(t *testing.T) {
+	audio := []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	var gotPath string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &gotBody))
+		w.Header().Set("Content-Type", "audio/flac")
+		_, _ = w.Write(audio)
+	}))
+	defer server.Close()
+
+	const modelName = "facebook/mms-tts-eng"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "text-to-speech", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Speech(ctx, schemas.Key{}, &schemas.BifrostSpeechRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.SpeechInput{Input: "hello there"},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "/hf-inference/models/"+modelName, gotPath)
+	assert.Equal(t, map[string]interface{}{"inputs": "hello there"}, gotBody)
+	assert.Equal(t, audio, resp.Audio)
+}
+
+func TestSpeech_AliasLeavesRequestModel(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "audio/flac")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	const modelName = "facebook/mms-tts-eng"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "text-to-speech", ProviderModelID: modelName},
+	})
+	key := schemas.Key{Aliases: schemas.KeyAliases{"tts-*": "hf-inference/" + modelName}}
+	request := &schemas.BifrostSpeechRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "tts-english",
+		Input:    &schemas.SpeechInput{Input: "hello there"},
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	_, bifrostErr := provider.Speech(ctx, key, request)
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "/hf-inference/models/"+modelName, gotPath)
+	assert.Equal(t, "tts-english", request.Model, "caller's request must not be rewritten")
+}
+
+func TestSpeech_HFInferenceErrorObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"error":"Model facebook/mms-tts-eng is currently loading","error_type":"overloaded"}`))
+	}))
+	defer server.Close()
+
+	const modelName = "facebook/mms-tts-eng"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "text-to-speech", ProviderModelID: modelName},
+	})
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Speech(ctx, schemas.Key{}, &schemas.BifrostSpeechRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.SpeechInput{Input: "hello there"},
+	})
+	assert.Nil(t, resp)
+	require.NotNil(t, bifrostErr)
+	assert.Contains(t, bifrostErr.Error.Message, "currently loading")
+}
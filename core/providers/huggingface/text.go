@@ -0,0 +1,206 @@
+package huggingface
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+)
+
+// defaultFamilyStopSequences are the end-of-turn markers base models emit but never stop on by
+// themselves. Instruct chat templates add these automatically; raw-prompt text completion does not.
+var defaultFamilyStopSequences = map[string][]string{
+	"llama-3":  {"<|eot_id|>", "<|end_of_text|>"},
+	"llama-2":  {"</s>"},
+	"mistral":  {"</s>"},
+	"mixtral":  {"</s>"},
+	"qwen":     {"<|im_end|>", "<|endoftext|>"},
+	"gemma":    {"<end_of_turn>", "<eos>"},
+	"phi-3":    {"<|end|>", "<|endoftext|>"},
+	"deepseek": {"<｜end▁of▁sentence｜>"},
+}
+
+// familyStopSequences returns the default stop sequences for the model's family. Configured
+// families replace built-in ones of the same name, and the longest matching family wins so that
+// e.g. "llama-3" is chosen over "llama". Returns nil when no family matches.
+func (provider *HuggingFaceProvider) familyStopSequences(modelName string) []string {
+	families := make(map[string][]string, len(defaultFamilyStopSequences)+len(provider.huggingFaceConfig.FamilyStopSequences))
+	for family, stops := range defaultFamilyStopSequences {
+		families[family] = stops
+	}
+	for family, stops := range provider.huggingFaceConfig.FamilyStopSequences {
+		families[strings.ToLower(family)] = stops
+	}
+
+	keys := make([]string, 0, len(families))
+	for family := range families {
+		keys = append(keys, family)
+	}
+	// Longest first, then lexical so ties are deterministic
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	model := strings.ToLower(modelName)
+	for _, family := range keys {
+		if family != "" && strings.Contains(model, family) {
+			return families[family]
+		}
+	}
+	return nil
+}
+
+// applyFamilyStopSequences injects the family default stop sequences for raw-prompt text
+// completion when the caller provided none. Caller-provided stops always win.
+func (provider *HuggingFaceProvider) applyFamilyStopSequences(modelName string, stop []string) []string {
+	if len(stop) > 0 {
+		return stop
+	}
+	defaults := provider.familyStopSequences(modelName)
+	if len(defaults) == 0 {
+		return stop
+	}
+	return append([]string(nil), defaults...)
+}
+
+// ToHuggingFaceTextGenerationRequest converts a Bifrost text completion request into the
+// hf-inference text-generation payload. stop replaces the request's own stop sequences, so the
+// caller can add family defaults. The pipeline takes one prompt per call.
+func ToHuggingFaceTextGenerationRequest(bifrostReq *schemas.BifrostTextCompletionRequest, stop []string) (*HuggingFaceTextGenerationRequest, error) {
+	if bifrostReq == nil {
+		return nil, nil
+	}
+	var prompt string
+	switch {
+	case bifrostReq.Input == nil:
+		return nil, fmt.Errorf("text-generation needs a prompt")
+	case bifrostReq.Input.PromptStr != nil:
+		prompt = *bifrostReq.Input.PromptStr
+	case len(bifrostReq.Input.PromptArray) == 1:
+		prompt = bifrostReq.Input.PromptArray[0]
+	default:
+		return nil, fmt.Errorf("text-generation takes a single prompt, got %d", len(bifrostReq.Input.PromptArray))
+	}
+
+	req := &HuggingFaceTextGenerationRequest{
+		Inputs: prompt,
+		Parameters: &HuggingFaceTextGenerationParameters{
+			Stop:    stop,
+			Details: true,
+		},
+	}
+	if params := bifrostReq.Params; params != nil {
+		req.Parameters.MaxNewTokens = params.MaxTokens
+		req.Parameters.Temperature = params.Temperature
+		req.Parameters.TopP = params.TopP
+		req.Parameters.Seed = params.Seed
+		if params.Echo != nil {
+			req.Parameters.ReturnFullText = *params.Echo
+		}
+		if err := extractSamplingParameters(params.ExtraParams, req.Parameters); err != nil {
+			return nil, err
+		}
+		req.Options = extractInferenceOptions(params.ExtraParams)
+		req.ExtraParams = params.ExtraParams
+	}
+	return req, nil
+}
+
+// extractSamplingParameters moves the HF sampling settings with no OpenAI equivalent
+// (repetition_penalty, top_k, do_sample) from extra params into parameters, rejecting values
+// outside their valid range. Unset ones are left out.
+func extractSamplingParameters(extraParams map[string]interface{}, parameters *HuggingFaceTextGenerationParameters) error {
+	if raw, ok := extraParams["repetition_penalty"]; ok {
+		delete(extraParams, "repetition_penalty")
+		penalty, ok := schemas.SafeExtractFloat64(raw)
+		if !ok || penalty <= 0 {
+			return fmt.Errorf("invalid repetition_penalty %v: must be a number greater than 0", raw)
+		}
+		parameters.RepetitionPenalty = &penalty
+	}
+	if raw, ok := extraParams["top_k"]; ok {
+		delete(extraParams, "top_k")
+		topK, ok := schemas.SafeExtractInt(raw)
+		if !ok || topK < 1 {
+			return fmt.Errorf("invalid top_k %v: must be an integer of at least 1", raw)
+		}
+		parameters.TopK = &topK
+	}
+	if raw, ok := extraParams["do_sample"]; ok {
+		delete(extraParams, "do_sample")
+		doSample, ok := schemas.SafeExtractBool(raw)
+		if !ok {
+			return fmt.Errorf("invalid do_sample %v: must be a boolean", raw)
+		}
+		parameters.DoSample = &doSample
+	}
+	return nil
+}
+
+// UnmarshalHuggingFaceTextGenerationResponse decodes text-generation output into a single-choice
+// text completion response, cutting the text at the first stop sequence since the pipeline keeps
+// the one it matched. echoedPrompt is the prompt when the request asked for it back (echo); it is
+// kept whole, so stop sequences it contains (e.g. a raw chat template's end-of-turn markers) only
+// end the text generated after it. The pipeline answers with a list, TGI endpoints with a bare object.
+func UnmarshalHuggingFaceTextGenerationResponse(data []byte, stop []string, echoedPrompt string) (*schemas.BifrostTextCompletionResponse, error) {
+	var results []HuggingFaceTextGenerationResult
+	if err := sonic.Unmarshal(data, &results); err != nil {
+		var result HuggingFaceTextGenerationResult
+		if objErr := sonic.Unmarshal(data, &result); objErr != nil {
+			return nil, fmt.Errorf("failed to unmarshal text-generation response: %w", err)
+		}
+		results = []HuggingFaceTextGenerationResult{result}
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("text-generation response has no generated text")
+	}
+
+	result := results[0]
+	text := result.GeneratedText
+	var finishReason *string
+	var usage *schemas.BifrostLLMUsage
+	if result.Details != nil {
+		if result.Details.FinishReason == "length" {
+			finishReason = schemas.Ptr(string(schemas.BifrostFinishReasonLength))
+		} else if result.Details.FinishReason != "" {
+			finishReason = schemas.Ptr(string(schemas.BifrostFinishReasonStop))
+		}
+		usage = &schemas.BifrostLLMUsage{
+			CompletionTokens: result.Details.GeneratedTokens,
+			TotalTokens:      result.Details.GeneratedTokens,
+		}
+	}
+	searchFrom := 0
+	if echoedPrompt != "" && strings.HasPrefix(text, echoedPrompt) {
+		searchFrom = len(echoedPrompt)
+	}
+	cut := -1
+	for _, sequence := range stop {
+		if sequence == "" {
+			continue
+		}
+		if idx := strings.Index(text[searchFrom:], sequence); idx >= 0 && (cut < 0 || searchFrom+idx < cut) {
+			cut = searchFrom + idx
+		}
+	}
+	if cut >= 0 {
+		text = text[:cut]
+		finishReason = schemas.Ptr(string(schemas.BifrostFinishReasonStop))
+	}
+
+	return &schemas.BifrostTextCompletionResponse{
+		Object: "text_completion",
+		Choices: []schemas.BifrostResponseChoice{
+			{
+				Index:                        0,
+				TextCompletionResponseChoice: &schemas.TextCompletionResponseChoice{Text: &text},
+				FinishReason:                 finishReason,
+			},
+		},
+		Usage: usage,
+	}, nil
+}
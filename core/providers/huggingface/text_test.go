@@ -0,0 +1,427 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFamilyStopSequences(t *testing.T) {
+	provider := NewHuggingFaceProvider(&schemas.ProviderConfig{
+		HuggingFaceConfig: &schemas.HuggingFaceConfig{
+			FamilyStopSequences: map[string][]string{
+				"Llama-2":        {"<custom>"},
+				"llama":          {"<llama>"},
+				"my-org/base-lm": {"###"},
+			},
+		},
+	}, noopLogger{})
+
+	tests := []struct {
+		name  string
+		model string
+		stop  []string
+		want  []string
+	}{
+		{"built-in family injected", "meta-llama/Meta-Llama-3-8B", nil, []string{"<|eot_id|>", "<|end_of_text|>"}},
+		{"configured family injected", "my-org/Base-LM-7b", nil, []string{"###"}},
+		{"configured family overrides built-in", "meta-llama/Llama-2-7b-hf", nil, []string{"<custom>"}},
+		{"longest family wins", "meta-llama/Llama-4-Scout-17B", nil, []string{"<llama>"}},
+		{"caller stops win", "mistralai/Mistral-7B-v0.1", []string{"\n\n"}, []string{"\n\n"}},
+		{"unknown family left alone", "openai-community/gpt2", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, provider.applyFamilyStopSequences(tt.model, tt.stop))
+		})
+	}
+}
+
+func TestTextCompletion_ChatByDefault(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"Hello!"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	model := "meta-llama/Llama-3.1-8B-Instruct"
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "conversational", ProviderModelID: model},
+	})
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	for _, routedModel := range []string{model, "hf-inference/" + model} {
+		resp, bifrostErr := provider.TextCompletion(ctx, schemas.Key{}, &schemas.BifrostTextCompletionRequest{
+			Provider: schemas.HuggingFace,
+			Model:    routedModel,
+			Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hi")},
+		})
+		require.Nil(t, bifrostErr)
+		assert.Equal(t, "/v1/chat/completions", path)
+		require.Len(t, resp.Choices, 1)
+		require.NotNil(t, resp.Choices[0].Text)
+		assert.Equal(t, "Hello!", *resp.Choices[0].Text)
+		assert.Equal(t, "text_completion", resp.Object)
+	}
+}
+
+func TestTextCompletion_NativeTextGeneration(t *testing.T) {
+	t.Parallel()
+
+	model := "meta-llama/Meta-Llama-3-8B"
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hf-inference/models/"+model+"/pipeline/text-generation", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"generated_text":" Paris.<|eot_id|>The capital of Spain","details":{"finish_reason":"stop_sequence","generated_tokens":4}}]`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "text-generation", ProviderModelID: model},
+	})
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	resp, bifrostErr := provider.TextCompletion(ctx, schemas.Key{}, &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + model,
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("The capital of France is")},
+		Params: &schemas.TextCompletionParameters{
+			MaxTokens:   schemas.Ptr(16),
+			Temperature: schemas.Ptr(0.5),
+			Seed:        schemas.Ptr(7),
+		},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "The capital of France is", captured["inputs"])
+	parameters, ok := captured["parameters"].(map[string]interface{})
+	require.True(t, ok)
+	assert.EqualValues(t, 16, parameters["max_new_tokens"])
+	assert.EqualValues(t, 0.5, parameters["temperature"])
+	assert.EqualValues(t, 7, parameters["seed"])
+	assert.Equal(t, false, parameters["return_full_text"])
+	assert.Equal(t, true, parameters["details"])
+	// Raw prompts get the family's end-of-turn markers as stop sequences
+	assert.Equal(t, []interface{}{"<|eot_id|>", "<|end_of_text|>"}, parameters["stop"])
+
+	require.Len(t, resp.Choices, 1)
+	require.NotNil(t, resp.Choices[0].Text)
+	assert.Equal(t, " Paris.", *resp.Choices[0].Text)
+	require.NotNil(t, resp.Choices[0].FinishReason)
+	assert.Equal(t, "stop", *resp.Choices[0].FinishReason)
+	require.NotNil(t, resp.Usage)
+	assert.Equal(t, 4, resp.Usage.CompletionTokens)
+	assert.Equal(t, "hf-inference/"+model, resp.Model)
+	assert.Equal(t, "text_completion", resp.Object)
+}
+
+func TestTextCompletion_NativeTextGenerationReturnFullText(t *testing.T) {
+	t.Parallel()
+
+	model := "meta-llama/Meta-Llama-3-8B"
+	prompt := "<|start_header_id|>user<|end_header_id|>\n\nName a colour.<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n"
+	// Like the pipeline, echo the prompt unless return_full_text is false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body HuggingFaceTextGenerationRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		generated := "Blue.<|eot_id|>"
+		if body.Parameters == nil || body.Parameters.ReturnFullText {
+			generated = body.Inputs + generated
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"generated_text":%q,"details":{"finish_reason":"stop_sequence","generated_tokens":3}}]`, generated)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "text-generation", ProviderModelID: model},
+	})
+
+	tests := []struct {
+		name     string
+		echo     *bool
+		wantText string
+	}{
+		{"default", nil, "Blue."},
+		{"echo_false", schemas.Ptr(false), "Blue."},
+		// The prompt's own <|eot_id|> must not end the echoed text
+		{"echo_true", schemas.Ptr(true), prompt + "Blue."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			resp, bifrostErr := provider.TextCompletion(ctx, schemas.Key{}, &schemas.BifrostTextCompletionRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + model,
+				Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr(prompt)},
+				Params:   &schemas.TextCompletionParameters{Echo: tt.echo},
+			})
+			require.Nil(t, bifrostErr)
+			require.Len(t, resp.Choices, 1)
+			require.NotNil(t, resp.Choices[0].Text)
+			assert.Equal(t, tt.wantText, *resp.Choices[0].Text)
+			assert.LessOrEqual(t, strings.Count(*resp.Choices[0].Text, prompt), 1)
+		})
+	}
+}
+
+func TestUnmarshalHuggingFaceTextGenerationResponse(t *testing.T) {
+	t.Run("bare_object_hits_length", func(t *testing.T) {
+		resp, err := UnmarshalHuggingFaceTextGenerationResponse([]byte(`{"generated_text":"once upon a","details":{"finish_reason":"length","generated_tokens":3}}`), nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, "once upon a", *resp.Choices[0].Text)
+		assert.Equal(t, "length", *resp.Choices[0].FinishReason)
+	})
+
+	t.Run("without_details", func(t *testing.T) {
+		resp, err := UnmarshalHuggingFaceTextGenerationResponse([]byte(`[{"generated_text":"hi"}]`), nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, "hi", *resp.Choices[0].Text)
+		assert.Nil(t, resp.Choices[0].FinishReason)
+		assert.Nil(t, resp.Usage)
+	})
+
+	t.Run("echoed_prompt_keeps_its_stop_sequences", func(t *testing.T) {
+		resp, err := UnmarshalHuggingFaceTextGenerationResponse([]byte(`[{"generated_text":"Q</s>A</s>more"}]`), []string{"</s>"}, "Q</s>")
+		require.NoError(t, err)
+		assert.Equal(t, "Q</s>A", *resp.Choices[0].Text)
+		assert.Equal(t, "stop", *resp.Choices[0].FinishReason)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := UnmarshalHuggingFaceTextGenerationResponse([]byte(`[]`), nil, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestToHuggingFaceTextGenerationRequest_SinglePrompt(t *testing.T) {
+	req, err := ToHuggingFaceTextGenerationRequest(&schemas.BifrostTextCompletionRequest{
+		Input:  &schemas.TextCompletionInput{PromptArray: []string{"only prompt"}},
+		Params: &schemas.TextCompletionParameters{Echo: schemas.Ptr(true)},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "only prompt", req.Inputs)
+	assert.True(t, req.Parameters.ReturnFullText)
+
+	_, err = ToHuggingFaceTextGenerationRequest(&schemas.BifrostTextCompletionRequest{
+		Input: &schemas.TextCompletionInput{PromptArray: []string{"one", "two"}},
+	}, nil)
+	assert.Error(t, err)
+}
+
+func TestToHuggingFaceTextGenerationRequest_SamplingParameters(t *testing.T) {
+	newRequest := func(extraParams map[string]interface{}) *schemas.BifrostTextCompletionRequest {
+		return &schemas.BifrostTextCompletionRequest{
+			Input:  &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Once upon a time")},
+			Params: &schemas.TextCompletionParameters{ExtraParams: extraParams},
+		}
+	}
+
+	t.Run("mapped", func(t *testing.T) {
+		req, err := ToHuggingFaceTextGenerationRequest(newRequest(map[string]interface{}{
+			"repetition_penalty": 1.2,
+			"top_k":              float64(40),
+			"do_sample":          "true",
+		}), nil)
+		require.NoError(t, err)
+		require.NotNil(t, req.Parameters.RepetitionPenalty)
+		assert.Equal(t, 1.2, *req.Parameters.RepetitionPenalty)
+		require.NotNil(t, req.Parameters.TopK)
+		assert.Equal(t, 40, *req.Parameters.TopK)
+		require.NotNil(t, req.Parameters.DoSample)
+		assert.True(t, *req.Parameters.DoSample)
+		assert.Empty(t, req.ExtraParams)
+
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"repetition_penalty":1.2`)
+		assert.Contains(t, string(body), `"top_k":40`)
+		assert.Contains(t, string(body), `"do_sample":true`)
+	})
+
+	t.Run("unset_omitted", func(t *testing.T) {
+		req, err := ToHuggingFaceTextGenerationRequest(newRequest(nil), nil)
+		require.NoError(t, err)
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "repetition_penalty")
+		assert.NotContains(t, string(body), "top_k")
+		assert.NotContains(t, string(body), "do_sample")
+	})
+
+	for name, extraParams := range map[string]map[string]interface{}{
+		"repetition_penalty": {"repetition_penalty": 0.0},
+		"top_k":              {"top_k": 0},
+		"do_sample":          {"do_sample": "sometimes"},
+	} {
+		t.Run("invalid_"+name, func(t *testing.T) {
+			_, err := ToHuggingFaceTextGenerationRequest(newRequest(extraParams), nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), name)
+		})
+	}
+}
+
+func TestTextCompletionStream_ThroughChat(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "text/event-stream")
+		// Only the first delta names the model
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"meta-llama/Llama-3.3-70B-Instruct\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"choices\":[],\"usage\":{\"prompt_tokens\":4,\"completion_tokens\":2,\"total_tokens\":6}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.TextCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "groq/meta-llama/Llama-3.3-70B-Instruct",
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hello")},
+	})
+	require.Nil(t, bifrostErr)
+
+	var chunks []*schemas.BifrostTextCompletionResponse
+	var text strings.Builder
+	for chunk := range stream {
+		require.Nil(t, chunk.BifrostError)
+		assert.Nil(t, chunk.BifrostChatResponse)
+		require.NotNil(t, chunk.BifrostTextCompletionResponse)
+		chunks = append(chunks, chunk.BifrostTextCompletionResponse)
+		for _, choice := range chunk.BifrostTextCompletionResponse.Choices {
+			if choice.TextCompletionResponseChoice != nil && choice.Text != nil {
+				text.WriteString(*choice.Text)
+			}
+		}
+	}
+	assert.Equal(t, "/v1/chat/completions", path)
+	assert.Equal(t, "Hello", text.String())
+
+	require.NotEmpty(t, chunks)
+	for i, chunk := range chunks {
+		assert.NotEmpty(t, chunk.Model, "chunk %d", i)
+		assert.Equal(t, "text_completion", chunk.Object, "chunk %d", i)
+		assert.Equal(t, schemas.TextCompletionStreamRequest, chunk.ExtraFields.RequestType, "chunk %d", i)
+	}
+	last := chunks[len(chunks)-1]
+	require.NotNil(t, last.Usage, "the final chunk carries the stream's usage")
+	assert.Equal(t, 6, last.Usage.TotalTokens)
+}
+
+func TestTextCompletionStream_RejectsTextGenerationPipeline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	model := "meta-llama/Meta-Llama-3-8B"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.modelProviderMappingCache.Store(model, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "text-generation", ProviderModelID: model},
+	})
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+	stream, bifrostErr := provider.TextCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, &schemas.BifrostTextCompletionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + model,
+		Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("The capital of France is")},
+	})
+	assert.Nil(t, stream)
+	require.NotNil(t, bifrostErr)
+	require.NotNil(t, bifrostErr.StatusCode)
+	assert.Equal(t, http.StatusBadRequest, *bifrostErr.StatusCode)
+	assert.Contains(t, bifrostErr.Error.Message, "text-generation pipeline")
+}
+
+func TestTextCompletionStream_MatchesNonStreamedResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		if stream, _ := payload["stream"].(bool); stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"created\":1700000000,\"model\":\"meta-llama/Llama-3.3-70B-Instruct\",\"system_fingerprint\":\"fp_abc\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hel\"}}]}\n\n")
+			fmt.Fprint(w, "data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"created\":1700000000,\"model\":\"meta-llama/Llama-3.3-70B-Instruct\",\"system_fingerprint\":\"fp_abc\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n")
+			fmt.Fprint(w, "data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"created\":1700000000,\"model\":\"meta-llama/Llama-3.3-70B-Instruct\",\"system_fingerprint\":\"fp_abc\",\"choices\":[],\"usage\":{\"prompt_tokens\":4,\"completion_tokens\":2,\"total_tokens\":6}}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"cmpl-1","object":"chat.completion","created":1700000000,"model":"meta-llama/Llama-3.3-70B-Instruct","system_fingerprint":"fp_abc","choices":[{"index":0,"message":{"role":"assistant","content":"Hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":4,"completion_tokens":2,"total_tokens":6}}`)
+	}))
+	defer server.Close()
+
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	newRequest := func() *schemas.BifrostTextCompletionRequest {
+		return &schemas.BifrostTextCompletionRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "groq/meta-llama/Llama-3.3-70B-Instruct",
+			Input:    &schemas.TextCompletionInput{PromptStr: schemas.Ptr("Say hello")},
+		}
+	}
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	want, bifrostErr := provider.TextCompletion(ctx, schemas.Key{}, newRequest())
+	require.Nil(t, bifrostErr)
+
+	ctx = schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	stream, bifrostErr := provider.TextCompletionStream(ctx, noopPostHookRunner, nil, schemas.Key{}, newRequest())
+	require.Nil(t, bifrostErr)
+	var chunks []*schemas.BifrostTextCompletionResponse
+	var text strings.Builder
+	var finishReason *string
+	for chunk := range stream {
+		require.Nil(t, chunk.BifrostError)
+		require.NotNil(t, chunk.BifrostTextCompletionResponse)
+		chunks = append(chunks, chunk.BifrostTextCompletionResponse)
+		for _, choice := range chunk.BifrostTextCompletionResponse.Choices {
+			if choice.TextCompletionResponseChoice != nil && choice.Text != nil {
+				text.WriteString(*choice.Text)
+			}
+			if choice.FinishReason != nil {
+				finishReason = choice.FinishReason
+			}
+		}
+	}
+	require.NotEmpty(t, chunks)
+
+	require.Len(t, want.Choices, 1)
+	require.NotNil(t, want.Choices[0].Text)
+	assert.Equal(t, *want.Choices[0].Text, text.String())
+	assert.Equal(t, want.Choices[0].FinishReason, finishReason)
+	for i, chunk := range chunks {
+		assert.Equal(t, want.ID, chunk.ID, "chunk %d", i)
+		assert.Equal(t, want.Object, chunk.Object, "chunk %d", i)
+		assert.Equal(t, want.Model, chunk.Model, "chunk %d", i)
+		assert.Equal(t, want.SystemFingerprint, chunk.SystemFingerprint, "chunk %d", i)
+		assert.Equal(t, want.ExtraFields.Provider, chunk.ExtraFields.Provider, "chunk %d", i)
+	}
+	last := chunks[len(chunks)-1]
+	assert.Equal(t, want.Usage, last.Usage)
+	assert.Equal(t, want.ExtraFields.UsageAccuracy, last.ExtraFields.UsageAccuracy)
+}
@@ -0,0 +1,710 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// tokenizerRetryInterval is how long a tokenizer.json the Hub couldn't serve, or that
+	// couldn't be parsed, is remembered before the next request for that model tries again.
+	tokenizerRetryInterval = 10 * time.Minute
+	maxTokenizerRedirects  = 5
+	// tokenizerFetchTimeout bounds a background tokenizer.json download, redirects included.
+	tokenizerFetchTimeout = 30 * time.Second
+	// maxTokenizerBytes caps a tokenizer.json download; the largest common vocabularies are a
+	// few tens of MiB.
+	maxTokenizerBytes = 64 << 20
+	// maxCachedTokenizers bounds how many models' tokenizers are kept; the least recently
+	// loaded is dropped first.
+	maxCachedTokenizers = 64
+)
+
+// errTokenizerFetchInterrupted marks fetch failures that say nothing about the tokenizer itself
+// (network errors, timeouts, throttling and server errors), which are not remembered so the next
+// request tries again.
+var errTokenizerFetchInterrupted = errors.New("tokenizer fetch interrupted")
+
+// UsageEstimatorContextKey selects, per request, how usage is estimated when HF reports none.
+// The value is a UsageEstimator (or its string form); unset uses the model's tokenizer when
+// tokenizer fetching is enabled.
+const UsageEstimatorContextKey schemas.BifrostContextKey = "huggingface-usage-estimator"
+
+// UsageEstimator trades estimate accuracy against cost.
+type UsageEstimator string
+
+const (
+	UsageEstimatorHeuristic UsageEstimator = "heuristic" // length-based approximation, never touches the network
+	UsageEstimatorTokenizer UsageEstimator = "tokenizer" // the model's tokenizer.json, fetched in the background on first use and cached
+)
+
+// tokenCounter counts the tokens in a piece of text.
+type tokenCounter interface {
+	countTokens(text string) int
+}
+
+// heuristicTokenCounter approximates token counts from text length.
+type heuristicTokenCounter struct{}
+
+func (heuristicTokenCounter) countTokens(text string) int {
+	return estimateTokens(text)
+}
+
+// tokenizerCacheEntry is a cached tokenizer.json load. tokenizer is nil while the download is in
+// flight (loading) or after it failed.
+type tokenizerCacheEntry struct {
+	tokenizer *hubTokenizer
+	loading   bool
+	loadedAt  time.Time
+}
+
+// getTokenCounter returns a counter backed by the model's tokenizer.json once it is cached. The
+// first request for a model starts the download in the background, detached from the request,
+// and like any request made while it is in flight or after it failed, counts with the length
+// heuristic. Fetching is on unless DisableTokenizerFetch is set.
+func (provider *HuggingFaceProvider) getTokenCounter(ctx context.Context, key schemas.Key, modelName string) tokenCounter {
+	if provider.huggingFaceConfig.DisableTokenizerFetch || provider.tokenizerCache == nil {
+		return heuristicTokenCounter{}
+	}
+
+	loading := tokenizerCacheEntry{loading: true, loadedAt: time.Now()}
+	if cached, ok := provider.tokenizerCache.Load(modelName); ok {
+		entry, _ := cached.(tokenizerCacheEntry)
+		if entry.tokenizer != nil {
+			return entry.tokenizer
+		}
+		if entry.loading || time.Since(entry.loadedAt) < tokenizerRetryInterval {
+			return heuristicTokenCounter{}
+		}
+		// Retry the expired failure, unless a concurrent request already did
+		if !provider.tokenizerCache.CompareAndSwap(modelName, cached, loading) {
+			return heuristicTokenCounter{}
+		}
+	} else if _, loaded := provider.tokenizerCache.LoadOrStore(modelName, loading); loaded {
+		return heuristicTokenCounter{}
+	}
+	provider.evictTokenizers()
+
+	// The request is built here because the headers come from the request's context, which the
+	// download must not hold on to
+	req := fasthttp.AcquireRequest()
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+	req.SetRequestURI(fmt.Sprintf("%s/%s/resolve/main/tokenizer.json", modelHubBaseURL, modelName))
+	req.Header.SetMethod(http.MethodGet)
+	if authHeader := bearerAuthHeader(key.Value.GetValue()); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	go func() {
+		defer fasthttp.ReleaseRequest(req)
+		fetchCtx, cancel := context.WithTimeout(context.Background(), tokenizerFetchTimeout)
+		defer cancel()
+
+		tokenizer, err := provider.fetchTokenizer(fetchCtx, key, req)
+		if err != nil {
+			provider.logger.Debug(fmt.Sprintf("huggingface: falling back to heuristic token counts for %s: %v", modelName, err))
+			if errors.Is(err, errTokenizerFetchInterrupted) {
+				provider.tokenizerCache.CompareAndDelete(modelName, loading)
+				return
+			}
+		}
+		provider.tokenizerCache.Store(modelName, tokenizerCacheEntry{tokenizer: tokenizer, loadedAt: time.Now()})
+	}()
+	return heuristicTokenCounter{}
+}
+
+// evictTokenizers drops the least recently loaded tokenizers beyond maxCachedTokenizers.
+func (provider *HuggingFaceProvider) evictTokenizers() {
+	for {
+		count := 0
+		var oldestModel any
+		var oldestLoadedAt time.Time
+		provider.tokenizerCache.Range(func(model, cached any) bool {
+			count++
+			if entry, _ := cached.(tokenizerCacheEntry); !entry.loading && (oldestModel == nil || entry.loadedAt.Before(oldestLoadedAt)) {
+				oldestModel, oldestLoadedAt = model, entry.loadedAt
+			}
+			return true
+		})
+		if count <= maxCachedTokenizers || oldestModel == nil {
+			return
+		}
+		provider.tokenizerCache.Delete(oldestModel)
+	}
+}
+
+// requestTokenCounter returns the counter for the estimator the request selected through
+// UsageEstimatorContextKey. Selecting the tokenizer cannot override DisableTokenizerFetch.
+func (provider *HuggingFaceProvider) requestTokenCounter(ctx context.Context, key schemas.Key, modelName string) tokenCounter {
+	var estimator UsageEstimator
+	switch value := ctx.Value(UsageEstimatorContextKey).(type) {
+	case UsageEstimator:
+		estimator = value
+	case string:
+		estimator = UsageEstimator(value)
+	}
+	if UsageEstimator(strings.ToLower(string(estimator))) == UsageEstimatorHeuristic {
+		return heuristicTokenCounter{}
+	}
+	return provider.getTokenCounter(ctx, key, modelName)
+}
+
+// fetchTokenizer sends req for a tokenizer.json and parses the response, following the Hub's
+// redirects to its file CDN. Failures that say nothing about the tokenizer itself wrap
+// errTokenizerFetchInterrupted.
+func (provider *HuggingFaceProvider) fetchTokenizer(ctx context.Context, key schemas.Key, req *fasthttp.Request) (*hubTokenizer, error) {
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	for redirects := 0; ; redirects++ {
+		_, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.clientsForKey(key).tokenizerClient, req, resp)
+		wait()
+		if bifrostErr != nil {
+			err := fmt.Errorf("tokenizer request failed")
+			if bifrostErr.Error != nil && bifrostErr.Error.Error != nil {
+				err = bifrostErr.Error.Error
+			}
+			if errors.Is(err, fasthttp.ErrBodyTooLarge) {
+				return nil, fmt.Errorf("tokenizer is larger than %d bytes", maxTokenizerBytes)
+			}
+			return nil, fmt.Errorf("%w: %w", errTokenizerFetchInterrupted, err)
+		}
+
+		location := resp.Header.Peek("Location")
+		if !fasthttp.StatusCodeIsRedirect(resp.StatusCode()) || len(location) == 0 {
+			break
+		}
+		if redirects == maxTokenizerRedirects {
+			return nil, fmt.Errorf("too many redirects fetching tokenizer")
+		}
+		previousHost := string(req.URI().Host())
+		req.URI().UpdateBytes(location)
+		// Credentials are only meant for the Hub, not the CDN it redirects to
+		if string(req.URI().Host()) != previousHost {
+			req.Header.Del("Authorization")
+		}
+		resp.Reset()
+	}
+
+	if status := resp.StatusCode(); status == fasthttp.StatusTooManyRequests || status >= fasthttp.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: tokenizer request returned status %d", errTokenizerFetchInterrupted, status)
+	} else if status != fasthttp.StatusOK {
+		return nil, fmt.Errorf("tokenizer request returned status %d", status)
+	}
+
+	body, err := providerUtils.CheckAndDecodeBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	return parseHubTokenizer(body)
+}
+
+// hubTokenizer is a count-only implementation of the Hugging Face `tokenizers` pipeline,
+// built from a model's tokenizer.json. It covers the WordPiece, BPE and Unigram models
+// together with the normalizers, pre-tokenizers and post-processors they commonly ship
+// with. Rarer components are ignored, so counts may drift slightly for exotic tokenizers.
+type hubTokenizer struct {
+	modelType string // "WordPiece", "BPE" or "Unigram"
+
+	vocab       map[string]int     // WordPiece and BPE
+	mergeRanks  map[string]int     // BPE merges keyed by "left right"
+	pieceScores map[string]float64 // Unigram log probabilities
+	maxPieceLen int                // longest Unigram piece, in bytes
+	unkScore    float64            // Unigram score assigned to unknown characters
+
+	continuingSubwordPrefix string
+	maxInputCharsPerWord    int
+	byteFallback            bool
+	ignoreMerges            bool
+
+	lowercase        bool
+	stripAccents     bool
+	splitCJK         bool
+	splitPunctuation bool
+	byteLevel        bool
+	metaspace        string // replacement for spaces, e.g. "▁"; empty when not used
+	addPrefixSpace   bool
+
+	specialTokensPerSequence int // tokens added around each input by the post-processor
+}
+
+type tokenizerFile struct {
+	Normalizer    interface{} `json:"normalizer"`
+	PreTokenizer  interface{} `json:"pre_tokenizer"`
+	PostProcessor interface{} `json:"post_processor"`
+	Model         struct {
+		Type                    string          `json:"type"`
+		Vocab                   json.RawMessage `json:"vocab"`
+		Merges                  []interface{}   `json:"merges"`
+		ContinuingSubwordPrefix *string         `json:"continuing_subword_prefix"`
+		MaxInputCharsPerWord    int             `json:"max_input_chars_per_word"`
+		ByteFallback            bool            `json:"byte_fallback"`
+		IgnoreMerges            bool            `json:"ignore_merges"`
+	} `json:"model"`
+}
+
+// parseHubTokenizer builds a hubTokenizer from the contents of a tokenizer.json file.
+func parseHubTokenizer(data []byte) (*hubTokenizer, error) {
+	var file tokenizerFile
+	if err := sonic.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tokenizer.json: %w", err)
+	}
+
+	t := &hubTokenizer{
+		modelType:            file.Model.Type,
+		maxInputCharsPerWord: file.Model.MaxInputCharsPerWord,
+		byteFallback:         file.Model.ByteFallback,
+		ignoreMerges:         file.Model.IgnoreMerges,
+	}
+	if t.modelType == "" {
+		// Older tokenizer.json files omit the model type
+		switch {
+		case len(file.Model.Merges) > 0:
+			t.modelType = "BPE"
+		case len(file.Model.Vocab) > 0 && file.Model.Vocab[0] == '[':
+			t.modelType = "Unigram"
+		default:
+			t.modelType = "WordPiece"
+		}
+	}
+
+	switch t.modelType {
+	case "WordPiece", "BPE":
+		if err := sonic.Unmarshal(file.Model.Vocab, &t.vocab); err != nil {
+			return nil, fmt.Errorf("failed to parse %s vocab: %w", t.modelType, err)
+		}
+		if t.modelType == "WordPiece" {
+			t.continuingSubwordPrefix = "##"
+			if file.Model.ContinuingSubwordPrefix != nil {
+				t.continuingSubwordPrefix = *file.Model.ContinuingSubwordPrefix
+			}
+			if t.maxInputCharsPerWord <= 0 {
+				t.maxInputCharsPerWord = 100
+			}
+		} else {
+			t.mergeRanks = make(map[string]int, len(file.Model.Merges))
+			for rank, merge := range file.Model.Merges {
+				switch typed := merge.(type) {
+				case string:
+					t.mergeRanks[typed] = rank
+				case []interface{}:
+					if len(typed) == 2 {
+						left, _ := typed[0].(string)
+						right, _ := typed[1].(string)
+						t.mergeRanks[left+" "+right] = rank
+					}
+				}
+			}
+		}
+	case "Unigram":
+		var pieces [][]interface{}
+		if err := sonic.Unmarshal(file.Model.Vocab, &pieces); err != nil {
+			return nil, fmt.Errorf("failed to parse Unigram vocab: %w", err)
+		}
+		t.pieceScores = make(map[string]float64, len(pieces))
+		minScore := 0.0
+		for _, entry := range pieces {
+			if len(entry) != 2 {
+				continue
+			}
+			piece, _ := entry[0].(string)
+			score, _ := entry[1].(float64)
+			t.pieceScores[piece] = score
+			minScore = math.Min(minScore, score)
+			t.maxPieceLen = max(t.maxPieceLen, len(piece))
+		}
+		t.unkScore = minScore - 10
+	default:
+		return nil, fmt.Errorf("unsupported tokenizer model type %q", t.modelType)
+	}
+
+	walkTokenizerComponents(file.Normalizer, "normalizers", t.applyNormalizer)
+	walkTokenizerComponents(file.PreTokenizer, "pretokenizers", t.applyPreTokenizer)
+	walkTokenizerComponents(file.PostProcessor, "processors", func(component map[string]interface{}) {
+		t.specialTokensPerSequence += countPostProcessorSpecialTokens(component)
+	})
+
+	return t, nil
+}
+
+// walkTokenizerComponents visits a component and, for Sequence components, each nested one.
+func walkTokenizerComponents(raw interface{}, listKey string, visit func(map[string]interface{})) {
+	component, ok := raw.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if nested, ok := component[listKey].([]interface{}); ok {
+		for _, item := range nested {
+			walkTokenizerComponents(item, listKey, visit)
+		}
+		return
+	}
+	visit(component)
+}
+
+func (t *hubTokenizer) applyNormalizer(component map[string]interface{}) {
+	switch component["type"] {
+	case "BertNormalizer":
+		t.lowercase = boolOrDefault(component["lowercase"], true)
+		t.stripAccents = boolOrDefault(component["strip_accents"], t.lowercase)
+		t.splitCJK = boolOrDefault(component["handle_chinese_chars"], true)
+	case "Lowercase":
+		t.lowercase = true
+	case "StripAccents":
+		t.stripAccents = true
+	case "Replace":
+		// SentencePiece-style BPE (e.g. Llama 2) swaps spaces for "▁" in the normalizer
+		if pattern, ok := component["pattern"].(map[string]interface{}); ok && pattern["String"] == " " {
+			if content, ok := component["content"].(string); ok {
+				t.metaspace = content
+			}
+		}
+	case "Prepend":
+		t.addPrefixSpace = true
+	}
+}
+
+func (t *hubTokenizer) applyPreTokenizer(component map[string]interface{}) {
+	switch component["type"] {
+	case "BertPreTokenizer", "Whitespace":
+		t.splitPunctuation = true
+	case "ByteLevel":
+		t.byteLevel = true
+		t.addPrefixSpace = boolOrDefault(component["add_prefix_space"], false)
+	case "Metaspace":
+		t.metaspace = "▁"
+		if replacement, ok := component["replacement"].(string); ok && replacement != "" {
+			t.metaspace = replacement
+		}
+		if scheme, ok := component["prepend_scheme"].(string); ok {
+			t.addPrefixSpace = scheme != "never"
+		} else {
+			t.addPrefixSpace = boolOrDefault(component["add_prefix_space"], true)
+		}
+	}
+}
+
+func countPostProcessorSpecialTokens(component map[string]interface{}) int {
+	switch component["type"] {
+	case "BertProcessing", "RobertaProcessing":
+		return 2
+	case "TemplateProcessing":
+		single, _ := component["single"].([]interface{})
+		count := 0
+		for _, item := range single {
+			if piece, ok := item.(map[string]interface{}); ok {
+				if _, ok := piece["SpecialToken"]; ok {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	return 0
+}
+
+func boolOrDefault(value interface{}, fallback bool) bool {
+	if b, ok := value.(bool); ok {
+		return b
+	}
+	return fallback
+}
+
+// countTokens returns the number of tokens the tokenizer produces for text as a single sequence.
+func (t *hubTokenizer) countTokens(text string) int {
+	count := t.specialTokensPerSequence
+	for _, word := range t.preTokenize(t.normalize(text)) {
+		switch t.modelType {
+		case "WordPiece":
+			count += t.countWordPiece(word)
+		case "BPE":
+			count += t.countBPE(word)
+		case "Unigram":
+			count += t.countUnigram(word)
+		}
+	}
+	return count
+}
+
+func (t *hubTokenizer) normalize(text string) string {
+	if t.lowercase {
+		text = strings.ToLower(text)
+	}
+	if t.stripAccents {
+		text = strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Mn, r) {
+				return -1
+			}
+			return r
+		}, norm.NFD.String(text))
+	}
+	return text
+}
+
+// preTokenize splits normalized text into the words the model tokenizes independently.
+func (t *hubTokenizer) preTokenize(text string) []string {
+	switch {
+	case t.byteLevel:
+		if t.addPrefixSpace && !strings.HasPrefix(text, " ") {
+			text = " " + text
+		}
+		words := splitByteLevelWords(text)
+		for i, word := range words {
+			words[i] = toByteLevelAlphabet(word)
+		}
+		return words
+	case t.metaspace != "":
+		text = strings.ReplaceAll(text, " ", t.metaspace)
+		if t.addPrefixSpace && !strings.HasPrefix(text, t.metaspace) {
+			text = t.metaspace + text
+		}
+		return splitBeforeMarker(text, t.metaspace)
+	default:
+		var words []string
+		for _, field := range strings.Fields(text) {
+			if !t.splitPunctuation && !t.splitCJK {
+				words = append(words, field)
+				continue
+			}
+			start := 0
+			for i, r := range field {
+				if (t.splitPunctuation && (unicode.IsPunct(r) || unicode.IsSymbol(r))) || (t.splitCJK && isCJK(r)) {
+					if start < i {
+						words = append(words, field[start:i])
+					}
+					words = append(words, string(r))
+					start = i + utf8.RuneLen(r)
+				}
+			}
+			if start < len(field) {
+				words = append(words, field[start:])
+			}
+		}
+		return words
+	}
+}
+
+// splitBeforeMarker splits text at each occurrence of marker, keeping the marker at the
+// start of the word that follows it ("▁a▁b" -> "▁a", "▁b").
+func splitBeforeMarker(text, marker string) []string {
+	var words []string
+	for text != "" {
+		skip := 0
+		if strings.HasPrefix(text, marker) {
+			skip = len(marker)
+		}
+		next := strings.Index(text[skip:], marker)
+		if next < 0 {
+			words = append(words, text)
+			break
+		}
+		words = append(words, text[:skip+next])
+		text = text[skip+next:]
+	}
+	return words
+}
+
+// splitByteLevelWords approximates the GPT-2 pre-tokenization pattern: contractions, runs of
+// letters, runs of digits and runs of other symbols, each optionally preceded by one space.
+func splitByteLevelWords(text string) []string {
+	runes := []rune(text)
+	var words []string
+	for i := 0; i < len(runes); {
+		start := i
+		r := runes[i]
+
+		if r == '\'' && i+1 < len(runes) {
+			if n := contractionLength(runes[i+1:]); n > 0 {
+				i += 1 + n
+				words = append(words, string(runes[start:i]))
+				continue
+			}
+		}
+
+		if unicode.IsSpace(r) {
+			for i < len(runes) && unicode.IsSpace(runes[i]) {
+				i++
+			}
+			// A single trailing space belongs to the next word
+			if i < len(runes) && runes[i-1] == ' ' {
+				i--
+			}
+			if i > start {
+				words = append(words, string(runes[start:i]))
+				continue
+			}
+			i++ // the space prefixes the word that follows
+		}
+
+		class := byteLevelClass(runes[i])
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && byteLevelClass(runes[i]) == class {
+			i++
+		}
+		words = append(words, string(runes[start:i]))
+	}
+	return words
+}
+
+func contractionLength(rest []rune) int {
+	for _, suffix := range []string{"re", "ve", "ll", "s", "t", "m", "d"} {
+		if len(rest) >= len(suffix) && string(rest[:len(suffix)]) == suffix {
+			return len(suffix)
+		}
+	}
+	return 0
+}
+
+func byteLevelClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r):
+		return 1
+	case unicode.IsNumber(r):
+		return 2
+	default:
+		return 3
+	}
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// byteLevelAlphabet maps each byte to the printable rune GPT-2 style byte-level BPE uses for it.
+var byteLevelAlphabet = func() [256]rune {
+	var alphabet [256]rune
+	next := rune(256)
+	for b := 0; b < 256; b++ {
+		if (b >= '!' && b <= '~') || (b >= 0xA1 && b <= 0xAC) || (b >= 0xAE && b <= 0xFF) {
+			alphabet[b] = rune(b)
+		} else {
+			alphabet[b] = next
+			next++
+		}
+	}
+	return alphabet
+}()
+
+func toByteLevelAlphabet(word string) string {
+	var builder strings.Builder
+	for i := 0; i < len(word); i++ {
+		builder.WriteRune(byteLevelAlphabet[word[i]])
+	}
+	return builder.String()
+}
+
+func (t *hubTokenizer) countWordPiece(word string) int {
+	runes := []rune(word)
+	if len(runes) > t.maxInputCharsPerWord {
+		return 1
+	}
+	count := 0
+	for start := 0; start < len(runes); {
+		end := len(runes)
+		for ; end > start; end-- {
+			piece := string(runes[start:end])
+			if start > 0 {
+				piece = t.continuingSubwordPrefix + piece
+			}
+			if _, ok := t.vocab[piece]; ok {
+				break
+			}
+		}
+		if end == start {
+			// The whole word becomes a single unknown token
+			return 1
+		}
+		count++
+		start = end
+	}
+	return count
+}
+
+func (t *hubTokenizer) countBPE(word string) int {
+	if t.ignoreMerges {
+		if _, ok := t.vocab[word]; ok {
+			return 1
+		}
+	}
+
+	symbols := make([]string, 0, len(word))
+	for _, r := range word {
+		symbols = append(symbols, string(r))
+	}
+
+	for len(symbols) > 1 {
+		bestRank, bestIdx := math.MaxInt, -1
+		for i := 0; i < len(symbols)-1; i++ {
+			if rank, ok := t.mergeRanks[symbols[i]+" "+symbols[i+1]]; ok && rank < bestRank {
+				bestRank, bestIdx = rank, i
+			}
+		}
+		if bestIdx < 0 {
+			break
+		}
+		symbols[bestIdx] += symbols[bestIdx+1]
+		symbols = append(symbols[:bestIdx+1], symbols[bestIdx+2:]...)
+	}
+
+	count := 0
+	for _, symbol := range symbols {
+		if _, ok := t.vocab[symbol]; !ok && t.byteFallback {
+			count += len(symbol) // one <0xXX> token per byte
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// countUnigram finds the most likely segmentation of word (Viterbi) and returns its length.
+func (t *hubTokenizer) countUnigram(word string) int {
+	n := len(word)
+	best := make([]float64, n+1)
+	counts := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		best[i] = math.Inf(-1)
+	}
+
+	for start := 0; start < n; {
+		_, runeSize := utf8.DecodeRuneInString(word[start:])
+		if !math.IsInf(best[start], -1) {
+			for end := start + runeSize; end <= n && end-start <= max(t.maxPieceLen, runeSize); {
+				score, ok := t.pieceScores[word[start:end]]
+				tokens := 1
+				if !ok && end == start+runeSize {
+					score = t.unkScore
+					if t.byteFallback {
+						tokens = runeSize
+					}
+					ok = true
+				}
+				if ok && best[start]+score > best[end] {
+					best[end] = best[start] + score
+					counts[end] = counts[start] + tokens
+				}
+				if end == n {
+					break
+				}
+				_, size := utf8.DecodeRuneInString(word[end:])
+				end += size
+			}
+		}
+		start += runeSize
+	}
+	return counts[n]
+}
@@ -0,0 +1,200 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+const wordPieceTokenizerJSON = `{
+	"normalizer": {"type": "BertNormalizer", "lowercase": true},
+	"pre_tokenizer": {"type": "BertPreTokenizer"},
+	"post_processor": {"type": "TemplateProcessing", "single": [
+		{"SpecialToken": {"id": "[CLS]", "type_id": 0}},
+		{"Sequence": {"id": "A", "type_id": 0}},
+		{"SpecialToken": {"id": "[SEP]", "type_id": 0}}
+	]},
+	"model": {"type": "WordPiece", "unk_token": "[UNK]", "continuing_subword_prefix": "##",
+		"vocab": {"[UNK]": 0, "[CLS]": 1, "[SEP]": 2, "hello": 3, "world": 4, "un": 5, "##aff": 6, "##able": 7, ",": 8, "cafe": 9}}
+}`
+
+const byteLevelBPETokenizerJSON = `{
+	"pre_tokenizer": {"type": "ByteLevel", "add_prefix_space": false},
+	"post_processor": {"type": "ByteLevel"},
+	"model": {"type": "BPE",
+		"vocab": {"h": 0, "e": 1, "l": 2, "o": 3, "Ġ": 4, "w": 5, "r": 6, "d": 7, "he": 8, "ll": 9, "hell": 10, "hello": 11, "Ġw": 12},
+		"merges": ["h e", "l l", "he ll", "hell o", ["Ġ", "w"]]}
+}`
+
+const unigramTokenizerJSON = `{
+	"pre_tokenizer": {"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always"},
+	"post_processor": {"type": "TemplateProcessing", "single": [
+		{"Sequence": {"id": "A", "type_id": 0}},
+		{"SpecialToken": {"id": "</s>", "type_id": 0}}
+	]},
+	"model": {"type": "Unigram", "unk_id": 0,
+		"vocab": [["<unk>", 0.0], ["▁hello", -1.0], ["▁", -2.0], ["wor", -2.0], ["ld", -2.0], ["▁world", -8.0], ["w", -3.0]]}
+}`
+
+func TestHubTokenizerCountTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer string
+		text      string
+		want      int
+	}{
+		{name: "wordpiece_subwords", tokenizer: wordPieceTokenizerJSON, text: "Hello, unaffable world", want: 8},
+		{name: "wordpiece_unknown_word", tokenizer: wordPieceTokenizerJSON, text: "xyz", want: 3},
+		{name: "wordpiece_strips_accents", tokenizer: wordPieceTokenizerJSON, text: "Café", want: 3},
+		{name: "byte_level_bpe", tokenizer: byteLevelBPETokenizerJSON, text: "hello world", want: 6},
+		{name: "unigram_best_segmentation", tokenizer: unigramTokenizerJSON, text: "hello world", want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenizer, err := parseHubTokenizer([]byte(tt.tokenizer))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tokenizer.countTokens(tt.text))
+		})
+	}
+
+	t.Run("unsupported_model", func(t *testing.T) {
+		_, err := parseHubTokenizer([]byte(`{"model": {"type": "WordLevel", "vocab": {}}}`))
+		require.Error(t, err)
+	})
+}
+
+func TestSplitByteLevelWords(t *testing.T) {
+	assert.Equal(t,
+		[]string{"Hello", " world", "'s", " ", " test", "!!", "\n"},
+		splitByteLevelWords("Hello world's  test!!\n"))
+}
+
+func TestGetTokenCounter(t *testing.T) {
+	ctx := context.Background()
+	tokenizer, err := parseHubTokenizer([]byte(wordPieceTokenizerJSON))
+	require.NoError(t, err)
+
+	t.Run("uses_cached_tokenizer", func(t *testing.T) {
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+		provider.tokenizerCache.Store("org/model", tokenizerCacheEntry{tokenizer: tokenizer, loadedAt: time.Now()})
+		assert.Same(t, tokenizer, provider.getTokenCounter(ctx, schemas.Key{}, "org/model"))
+	})
+
+	t.Run("recent_failure_falls_back_without_refetch", func(t *testing.T) {
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+		provider.tokenizerCache.Store("org/model", tokenizerCacheEntry{loadedAt: time.Now()})
+		assert.Equal(t, heuristicTokenCounter{}, provider.getTokenCounter(ctx, schemas.Key{}, "org/model"))
+	})
+
+	t.Run("fetch_in_flight_is_not_repeated", func(t *testing.T) {
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+		loading := tokenizerCacheEntry{loading: true, loadedAt: time.Now().Add(-time.Hour)}
+		provider.tokenizerCache.Store("org/model", loading)
+		assert.Equal(t, heuristicTokenCounter{}, provider.getTokenCounter(ctx, schemas.Key{}, "org/model"))
+		cached, _ := provider.tokenizerCache.Load("org/model")
+		assert.Equal(t, loading, cached)
+	})
+
+	t.Run("fetch_disabled", func(t *testing.T) {
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{
+			HuggingFaceConfig: &schemas.HuggingFaceConfig{DisableTokenizerFetch: true},
+		}, noopLogger{})
+		provider.tokenizerCache.Store("org/model", tokenizerCacheEntry{tokenizer: tokenizer, loadedAt: time.Now()})
+		assert.Equal(t, heuristicTokenCounter{}, provider.getTokenCounter(ctx, schemas.Key{}, "org/model"))
+	})
+}
+
+func TestFetchTokenizer(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantErr         bool
+		wantInterrupted bool
+	}{
+		{name: "ok", status: http.StatusOK, body: wordPieceTokenizerJSON},
+		{name: "not_found_is_remembered", status: http.StatusNotFound, body: `{"error":"Entry not found"}`, wantErr: true},
+		{name: "parse_failure_is_remembered", status: http.StatusOK, body: `{"model":`, wantErr: true},
+		{name: "throttled_is_retried", status: http.StatusTooManyRequests, wantErr: true, wantInterrupted: true},
+		{name: "server_error_is_retried", status: http.StatusBadGateway, wantErr: true, wantInterrupted: true},
+		{name: "oversized_is_remembered", status: http.StatusOK, body: strings.Repeat(" ", 2048) + wordPieceTokenizerJSON, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+			provider.tokenizerClient.MaxResponseBodySize = 2048
+			req := fasthttp.AcquireRequest()
+			defer fasthttp.ReleaseRequest(req)
+			req.SetRequestURI(server.URL + "/org/model/resolve/main/tokenizer.json")
+
+			tokenizer, err := provider.fetchTokenizer(context.Background(), schemas.Key{}, req)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.NotNil(t, tokenizer)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantInterrupted, errors.Is(err, errTokenizerFetchInterrupted))
+		})
+	}
+
+	t.Run("timeout_is_retried", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+		req := fasthttp.AcquireRequest()
+		defer fasthttp.ReleaseRequest(req)
+		req.SetRequestURI(server.URL + "/org/model/resolve/main/tokenizer.json")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := provider.fetchTokenizer(ctx, schemas.Key{}, req)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTokenizerFetchInterrupted)
+	})
+}
+
+func TestEvictTokenizers(t *testing.T) {
+	provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+	start := time.Now()
+	for i := range maxCachedTokenizers + 2 {
+		provider.tokenizerCache.Store(fmt.Sprintf("org/model-%d", i), tokenizerCacheEntry{loadedAt: start.Add(time.Duration(i) * time.Second)})
+	}
+	// An in-flight download is never the one dropped, however old
+	provider.tokenizerCache.Store("org/loading", tokenizerCacheEntry{loading: true, loadedAt: start.Add(-time.Hour)})
+
+	provider.evictTokenizers()
+
+	count := 0
+	provider.tokenizerCache.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, maxCachedTokenizers, count)
+	for _, evicted := range []string{"org/model-0", "org/model-1", "org/model-2"} {
+		_, ok := provider.tokenizerCache.Load(evicted)
+		assert.False(t, ok, evicted)
+	}
+	_, ok := provider.tokenizerCache.Load("org/loading")
+	assert.True(t, ok)
+}
@@ -3,6 +3,8 @@ package huggingface
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/maximhq/bifrost/core/providers/utils"
 	schemas "github.com/maximhq/bifrost/core/schemas"
@@ -168,3 +170,81 @@ func (response *HuggingFaceTranscriptionResponse) ToBifrostTranscriptionResponse
 
 	return bifrostResponse, nil
 }
+
+// defaultTranscriptionSegmentOverlapSeconds is the overlap between transcription windows when
+// HuggingFaceConfig.TranscriptionSegmentOverlapSeconds is unset.
+const defaultTranscriptionSegmentOverlapSeconds = 2.0
+
+// maxStitchOverlapWords bounds the search for words repeated across a window boundary.
+const maxStitchOverlapWords = 64
+
+// transcriptionWindow is one slice of a long recording, with its position in seconds.
+type transcriptionWindow struct {
+	audio      []byte
+	start, end float64
+}
+
+// transcriptionWindows cuts the request audio into overlapping windows when segmentation is
+// enabled and the audio is a PCM WAV longer than one window. Returns nil when the audio should
+// be sent whole, including for formats that cannot be cut without decoding them.
+func (provider *HuggingFaceProvider) transcriptionWindows(request *schemas.BifrostTranscriptionRequest) []transcriptionWindow {
+	length := provider.huggingFaceConfig.TranscriptionSegmentSeconds
+	if length <= 0 || request.Input == nil {
+		return nil
+	}
+	wav, ok := parsePCMWAV(request.Input.File)
+	if !ok || wav.duration() <= length {
+		return nil
+	}
+
+	overlap := provider.huggingFaceConfig.TranscriptionSegmentOverlapSeconds
+	if overlap <= 0 {
+		overlap = defaultTranscriptionSegmentOverlapSeconds
+	}
+	overlap = min(overlap, length/4)
+
+	var windows []transcriptionWindow
+	total := wav.duration()
+	for start := 0.0; ; start += length - overlap {
+		end := min(start+length, total)
+		windows = append(windows, transcriptionWindow{audio: wav.window(start, end), start: start, end: end})
+		if end >= total {
+			return windows
+		}
+	}
+}
+
+// stitchTranscripts joins the transcripts of consecutive overlapping windows. Words heard in both
+// windows of an overlap appear at the end of one transcript and the start of the next; the longest
+// such run (compared ignoring case and punctuation) is kept only once.
+func stitchTranscripts(texts []string) string {
+	var words []string
+	for _, text := range texts {
+		next := strings.Fields(text)
+		limit := min(len(words), len(next), maxStitchOverlapWords)
+		for overlap := limit; overlap > 0; overlap-- {
+			if sameWords(words[len(words)-overlap:], next[:overlap]) {
+				next = next[overlap:]
+				break
+			}
+		}
+		words = append(words, next...)
+	}
+	return strings.Join(words, " ")
+}
+
+// sameWords compares two word runs ignoring case and surrounding punctuation, since a window
+// cut mid-sentence often capitalizes or punctuates the words at its edges differently.
+func sameWords(a, b []string) bool {
+	normalize := func(word string) string {
+		return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}))
+	}
+	for i := range a {
+		if normalize(a[i]) != normalize(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,214 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTranscriptionProvider(t *testing.T, serverURL string, modelName string) *HuggingFaceProvider {
+	t.Helper()
+	provider := newTestHuggingFaceProvider(t, serverURL)
+	provider.modelProviderMappingCache.Store(modelName, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "automatic-speech-recognition", ProviderModelID: modelName},
+	})
+	return provider
+}
+
+func TestTranscription_HFInferenceUploadsAudio(t *testing.T) {
+	audio := []byte("ID3\x03\x00\x00\x00\x00\x00\x00")
+	var gotPath, gotContentType string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"text":"hello world"}`))
+	}))
+	defer server.Close()
+
+	const modelName = "openai/whisper-large-v3"
+	provider := newTestTranscriptionProvider(t, server.URL, modelName)
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Transcription(ctx, schemas.Key{}, &schemas.BifrostTranscriptionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.TranscriptionInput{File: audio},
+	})
+	require.Nil(t, bifrostErr)
+
+	assert.Equal(t, "/hf-inference/models/"+modelName, gotPath)
+	assert.Equal(t, "audio/mpeg", gotContentType)
+	assert.Equal(t, audio, gotBody)
+	assert.Equal(t, "hello world", resp.Text)
+}
+
+func TestTranscription_ModelLoadingIsRetriable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"Model openai/whisper-large-v3 is currently loading","estimated_time":20.5}`))
+	}))
+	defer server.Close()
+
+	const modelName = "openai/whisper-large-v3"
+	provider := newTestTranscriptionProvider(t, server.URL, modelName)
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Transcription(ctx, schemas.Key{}, &schemas.BifrostTranscriptionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.TranscriptionInput{File: []byte("ID3\x03")},
+	})
+	assert.Nil(t, resp)
+	require.NotNil(t, bifrostErr)
+	require.NotNil(t, bifrostErr.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, *bifrostErr.StatusCode)
+	require.NotNil(t, bifrostErr.Type)
+	assert.Equal(t, modelLoadingErrorType, *bifrostErr.Type)
+	assert.Contains(t, bifrostErr.Error.Message, "currently loading")
+	assert.Contains(t, bifrostErr.Error.Message, "estimated time until ready: 20s")
+}
+
+func TestTranscription_ModelLoadingWithoutEstimatedTimeUsesDefaultWait(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"Model openai/whisper-large-v3 is currently loading"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"text":"hello world"}`))
+	}))
+	defer server.Close()
+
+	const modelName = "openai/whisper-large-v3"
+	provider := newTestTranscriptionProvider(t, server.URL, modelName)
+	provider.huggingFaceConfig.ModelLoadingRetries = 1
+	provider.huggingFaceConfig.DefaultModelLoadingWaitSeconds = 0.2
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	start := time.Now()
+	resp, bifrostErr := provider.Transcription(ctx, schemas.Key{}, &schemas.BifrostTranscriptionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.TranscriptionInput{File: []byte("ID3\x03")},
+	})
+	elapsed := time.Since(start)
+
+	require.Nil(t, bifrostErr)
+	require.NotNil(t, resp)
+	assert.Equal(t, "hello world", resp.Text)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+	assert.Less(t, elapsed, time.Duration(defaultModelLoadingWaitSeconds*float64(time.Second)))
+}
+
+// syntheticWAV builds a 16-bit mono PCM WAV whose every sample holds the second it falls in, so
+// a server can tell which stretch of the recording a window covers.
+func syntheticWAV(seconds, sampleRate int) []byte {
+	samples := make([]int16, seconds*sampleRate)
+	for i := range samples {
+		samples[i] = int16(i / sampleRate)
+	}
+	var data bytes.Buffer
+	_ = binary.Write(&data, binary.LittleEndian, samples)
+
+	format := make([]byte, 16)
+	binary.LittleEndian.PutUint16(format[0:2], wavFormatPCM)
+	binary.LittleEndian.PutUint16(format[2:4], 1)
+	binary.LittleEndian.PutUint32(format[4:8], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(format[8:12], uint32(sampleRate*2))
+	binary.LittleEndian.PutUint16(format[12:14], 2)
+	binary.LittleEndian.PutUint16(format[14:16], 16)
+
+	wav := &pcmWAV{format: format, byteRate: sampleRate * 2, blockAlign: 2, data: data.Bytes()}
+	return wav.window(0, float64(seconds))
+}
+
+func TestTranscription_SegmentsLongAudio(t *testing.T) {
+	const sampleRate = 100
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		wav, ok := parsePCMWAV(body)
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Speak one word per second of audio, with timestamps relative to the window
+		var samples []int16
+		for i := 0; i+1 < len(wav.data); i += 2 * sampleRate {
+			samples = append(samples, int16(binary.LittleEndian.Uint16(wav.data[i:])))
+		}
+		response := HuggingFaceTranscriptionResponse{}
+		var words []string
+		for i, second := range samples {
+			word := fmt.Sprintf("word%d", second)
+			words = append(words, word)
+			response.Chunks = append(response.Chunks, HuggingFaceTranscriptionResponseChunk{Text: word, Timestamp: []float64{float64(i), float64(i + 1)}})
+		}
+		// Models tend to capitalize the first word they hear
+		words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+		response.Text = strings.Join(words, " ")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	const modelName = "openai/whisper-large-v3"
+	provider := newTestTranscriptionProvider(t, server.URL, modelName)
+	provider.huggingFaceConfig.TranscriptionSegmentSeconds = 4
+	provider.huggingFaceConfig.TranscriptionSegmentOverlapSeconds = 1
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	resp, bifrostErr := provider.Transcription(ctx, schemas.Key{}, &schemas.BifrostTranscriptionRequest{
+		Provider: schemas.HuggingFace,
+		Model:    "hf-inference/" + modelName,
+		Input:    &schemas.TranscriptionInput{File: syntheticWAV(10, sampleRate)},
+	})
+	require.Nil(t, bifrostErr)
+
+	// Windows 0-4s, 3-7s and 6-10s
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, "Word0 word1 word2 word3 word4 word5 word6 word7 word8 word9", resp.Text)
+	require.Len(t, resp.Segments, 10)
+	for i, segment := range resp.Segments {
+		assert.Equal(t, i, segment.ID)
+		assert.Equal(t, fmt.Sprintf("word%d", i), segment.Text)
+		assert.Equal(t, float64(i), segment.Start)
+	}
+	require.NotNil(t, resp.Duration)
+	assert.Equal(t, 10.0, *resp.Duration)
+}
+
+func TestTranscription_ShortOrCompressedAudioIsSentWhole(t *testing.T) {
+	provider := newTestHuggingFaceProvider(t, "http://unused")
+	provider.huggingFaceConfig.TranscriptionSegmentSeconds = 4
+
+	assert.Nil(t, provider.transcriptionWindows(&schemas.BifrostTranscriptionRequest{Input: &schemas.TranscriptionInput{File: syntheticWAV(3, 100)}}))
+	assert.Nil(t, provider.transcriptionWindows(&schemas.BifrostTranscriptionRequest{Input: &schemas.TranscriptionInput{File: []byte("ID3\x03\x00\x00\x00\x00\x00\x00")}}))
+}
+
+func TestStitchTranscripts(t *testing.T) {
+	assert.Equal(t, "the quick brown fox jumps over", stitchTranscripts([]string{"the quick brown", "Brown fox jumps", "jumps, over"}))
+	assert.Equal(t, "hello there general kenobi", stitchTranscripts([]string{"hello there", "general kenobi"}))
+	assert.Equal(t, "a b", stitchTranscripts([]string{"a", "", "b"}))
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/bytedance/sonic"
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
@@ -24,6 +26,44 @@ type HuggingFaceModel struct {
 	PipelineTag   string   `json:"pipeline_tag"`
 	LibraryName   string   `json:"library_name"`
 	CreatedAt     string   `json:"createdAt"`
+
+	Gated    interface{}               `json:"gated,omitempty"` // false, or "auto"/"manual" when users must request access
+	CardData *HuggingFaceModelCardData `json:"cardData,omitempty"`
+}
+
+// HuggingFaceModelCardData is the subset of a model card's YAML front matter that Bifrost reads.
+type HuggingFaceModelCardData struct {
+	Description string                         `json:"description,omitempty"` // free-form summary some authors put in the card's front matter
+	License     interface{}                    `json:"license,omitempty"`     // license ID such as "apache-2.0", occasionally a list of IDs
+	Inference   *HuggingFaceModelCardInference `json:"inference,omitempty"`
+}
+
+// HuggingFaceModelInfo is the Hub's entry for a single model (GET /api/models/{id}), which carries
+// more metadata than the list endpoint returns.
+type HuggingFaceModelInfo struct {
+	HuggingFaceModel
+	Author string `json:"author,omitempty"`
+}
+
+// HuggingFaceModelCardInference holds the card's `inference` section. Cards may also set
+// `inference: false` to disable the widget, which decodes to an empty section.
+type HuggingFaceModelCardInference struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"` // default generation parameters declared by the model author
+}
+
+func (i *HuggingFaceModelCardInference) UnmarshalJSON(data []byte) error {
+	var enabled bool
+	if err := sonic.Unmarshal(data, &enabled); err == nil {
+		*i = HuggingFaceModelCardInference{}
+		return nil
+	}
+	type inferenceAlias HuggingFaceModelCardInference
+	var alias inferenceAlias
+	if err := sonic.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*i = HuggingFaceModelCardInference(alias)
+	return nil
 }
 
 type HuggingFaceListModelsResponse struct {
@@ -52,6 +92,28 @@ func (r *HuggingFaceListModelsResponse) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("failed to unmarshal HuggingFaceListModelsResponse: unexpected JSON structure")
 }
 
+// HuggingFaceRouterModelsResponse is the router's OpenAI-compatible /v1/models listing.
+type HuggingFaceRouterModelsResponse struct {
+	Data []HuggingFaceRouterModel `json:"data"`
+}
+
+// HuggingFaceRouterModel is one model of the router listing, with the inference providers serving it.
+type HuggingFaceRouterModel struct {
+	ID        string                           `json:"id"`
+	Created   *int64                           `json:"created,omitempty"`
+	OwnedBy   string                           `json:"owned_by,omitempty"`
+	Providers []HuggingFaceRouterModelProvider `json:"providers,omitempty"`
+}
+
+// HuggingFaceRouterModelProvider is an inference provider's deployment of a router-listed model.
+type HuggingFaceRouterModelProvider struct {
+	Provider                 string `json:"provider"`
+	Status                   string `json:"status,omitempty"` // "live" or "staging"
+	ContextLength            *int   `json:"context_length,omitempty"`
+	SupportsTools            *bool  `json:"supports_tools,omitempty"`
+	SupportsStructuredOutput *bool  `json:"supports_structured_output,omitempty"`
+}
+
 type HuggingFaceInferenceProviderMappingResponse struct {
 	ID                       string                                      `json:"_id"`
 	ModelID                  string                                      `json:"id"`
@@ -75,24 +137,28 @@ type HuggingFaceInferenceProviderMapping struct {
 
 // Flexible/chat request types for HuggingFace-like chat completion payloads.
 type HuggingFaceChatRequest struct {
-	FrequencyPenalty *float64                   `json:"frequency_penalty,omitempty"`
-	Logprobs         *bool                      `json:"logprobs,omitempty"`
-	MaxTokens        *int                       `json:"max_tokens,omitempty"`
-	Messages         []schemas.ChatMessage      `json:"messages"`
-	Model            string                     `json:"model" validate:"required"`
-	PresencePenalty  *float64                   `json:"presence_penalty,omitempty"`
-	ResponseFormat   *HuggingFaceResponseFormat `json:"response_format,omitempty"`
-	Seed             *int                       `json:"seed,omitempty"`
-	Stop             []string                   `json:"stop,omitempty"`
-	Stream           *bool                      `json:"stream,omitempty"`
-	StreamOptions    *schemas.ChatStreamOptions `json:"stream_options,omitempty"`
-	Temperature      *float64                   `json:"temperature,omitempty"`
-	ToolChoice       *HuggingFaceToolChoice     `json:"tool_choice,omitempty"`
-	ToolPrompt       *string                    `json:"tool_prompt,omitempty"`
-	Tools            []schemas.ChatTool         `json:"tools,omitempty"`
-	TopLogprobs      *int                       `json:"top_logprobs,omitempty"`
-	TopP             *float64                   `json:"top_p,omitempty"`
-	ExtraParams      map[string]interface{}     `json:"-"`
+	FrequencyPenalty  *float64                   `json:"frequency_penalty,omitempty"`
+	Logprobs          *bool                      `json:"logprobs,omitempty"`
+	MaxTokens         *int                       `json:"max_tokens,omitempty"`
+	Messages          []schemas.ChatMessage      `json:"messages"`
+	Metadata          map[string]any             `json:"metadata,omitempty"`
+	Model             string                     `json:"model" validate:"required"`
+	ParallelToolCalls *bool                      `json:"parallel_tool_calls,omitempty"`
+	Prediction        *schemas.ChatPrediction    `json:"prediction,omitempty"` // Predicted output, only kept for PredictionInferenceProviders
+	PresencePenalty   *float64                   `json:"presence_penalty,omitempty"`
+	ResponseFormat    *HuggingFaceResponseFormat `json:"response_format,omitempty"`
+	Seed              *int                       `json:"seed,omitempty"`
+	Stop              []string                   `json:"stop,omitempty"`
+	Stream            *bool                      `json:"stream,omitempty"`
+	StreamOptions     *schemas.ChatStreamOptions `json:"stream_options,omitempty"`
+	Temperature       *float64                   `json:"temperature,omitempty"`
+	ToolChoice        *HuggingFaceToolChoice     `json:"tool_choice,omitempty"`
+	ToolPrompt        *string                    `json:"tool_prompt,omitempty"`
+	Tools             []schemas.ChatTool         `json:"tools,omitempty"`
+	TopLogprobs       *int                       `json:"top_logprobs,omitempty"`
+	TopP              *float64                   `json:"top_p,omitempty"`
+	User              *string                    `json:"user,omitempty"`
+	ExtraParams       map[string]interface{}     `json:"-"`
 }
 
 func (req *HuggingFaceChatRequest) GetExtraParams() map[string]interface{} {
@@ -154,11 +220,33 @@ type HuggingFaceHubError struct {
 	Message string `json:"message"`
 }
 
+// UnmarshalJSON accepts error and message sent as a string, a list of messages or an object
+// (see errorMessageText), and a detail field in their place.
+func (e *HuggingFaceHubError) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Error   json.RawMessage `json:"error"`
+		Message json.RawMessage `json:"message"`
+		Detail  json.RawMessage `json:"detail"`
+	}
+	if err := sonic.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Error = errorMessageText(raw.Error)
+	e.Message = errorMessageText(raw.Message)
+	if e.Message == "" {
+		e.Message = errorMessageText(raw.Detail)
+	}
+	return nil
+}
+
 type HuggingFaceResponseError struct {
-	Error   string                   `json:"error"`
-	Type    string                   `json:"type"`
-	Message string                   `json:"message"`
-	Detail  []HuggingFaceErrorDetail `json:"detail,omitempty"` // FastAPI validation errors
+	Error     string                   `json:"error"`
+	ErrorType string                   `json:"error_type,omitempty"` // TGI streamed errors, e.g. "generation"
+	Type      string                   `json:"type"`
+	Message   string                   `json:"message"`
+	Detail    []HuggingFaceErrorDetail `json:"detail,omitempty"` // FastAPI validation errors
+
+	EstimatedTime *float64 `json:"estimated_time,omitempty"` // Seconds until a cold hf-inference model is loaded (sent with 503)
 }
 
 type HuggingFaceErrorDetail struct {
@@ -168,28 +256,157 @@ type HuggingFaceErrorDetail struct {
 	Ctx  map[string]interface{} `json:"ctx,omitempty"`
 }
 
+// UnmarshalJSON accepts error and message sent as a string, a list of messages or an object
+// (see errorMessageText). A detail that is not a list of FastAPI validation errors, such as
+// {"detail": "Not Found"}, becomes the message.
+func (e *HuggingFaceResponseError) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Error         json.RawMessage `json:"error"`
+		ErrorType     string          `json:"error_type"`
+		Type          string          `json:"type"`
+		Message       json.RawMessage `json:"message"`
+		Detail        json.RawMessage `json:"detail"`
+		EstimatedTime *float64        `json:"estimated_time"`
+	}
+	if err := sonic.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = HuggingFaceResponseError{
+		Error:         errorMessageText(raw.Error),
+		ErrorType:     raw.ErrorType,
+		Type:          raw.Type,
+		Message:       errorMessageText(raw.Message),
+		EstimatedTime: raw.EstimatedTime,
+	}
+	if len(raw.Detail) > 0 {
+		var details []HuggingFaceErrorDetail
+		if err := sonic.Unmarshal(raw.Detail, &details); err == nil && !slices.ContainsFunc(details, func(detail HuggingFaceErrorDetail) bool { return detail.Msg == "" }) {
+			e.Detail = details
+		} else if e.Message == "" {
+			e.Message = errorMessageText(raw.Detail)
+		}
+	}
+	return nil
+}
+
+// errorMessageText extracts a readable message from an error field, which HF sends as a string,
+// a list of messages ({"error": ["msg1", "msg2"]}, joined with "; ") or an object carrying its
+// own message, msg, error or detail. Anything else is returned as compact JSON.
+func errorMessageText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := sonic.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var items []json.RawMessage
+	if err := sonic.Unmarshal(raw, &items); err == nil {
+		messages := make([]string, 0, len(items))
+		for _, item := range items {
+			if message := strings.TrimSpace(errorMessageText(item)); message != "" {
+				messages = append(messages, message)
+			}
+		}
+		return strings.Join(messages, "; ")
+	}
+	var object map[string]json.RawMessage
+	if err := sonic.Unmarshal(raw, &object); err == nil {
+		for _, field := range []string{"message", "msg", "error", "detail"} {
+			if message := errorMessageText(object[field]); message != "" {
+				return message
+			}
+		}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err == nil {
+		return compact.String()
+	}
+	return string(raw)
+}
+
+// HuggingFaceBinaryResponse wraps a non-JSON task output (image, audio, ...) returned
+// as raw bytes, along with its content type.
+type HuggingFaceBinaryResponse struct {
+	Data        []byte
+	ContentType string
+}
+
+// HuggingFaceEffectiveConfig is returned in ExtraFields.EffectiveConfig when
+// HuggingFaceConfig.IncludeEffectiveConfig is enabled, to show how a request was processed.
+type HuggingFaceEffectiveConfig struct {
+	Model             string   `json:"model"`                      // Hub repo ID after alias resolution
+	InferenceProvider string   `json:"inference_provider"`         // Backend the router sent the request to ("" = router's choice)
+	Task              string   `json:"task"`                       // Inference task, e.g. "chat-completion" or "feature-extraction"
+	AppliedDefaults   []string `json:"applied_defaults,omitempty"` // Settings Bifrost filled in because the request left them unset
+	BatchSize         int      `json:"batch_size,omitempty"`       // Inputs sent upstream (embeddings)
+}
+
 // # EMBEDDING TYPES
 
+// HuggingFaceInferenceOptions is the `options` object of hf-inference task payloads.
+type HuggingFaceInferenceOptions struct {
+	WaitForModel *bool `json:"wait_for_model,omitempty"` // hold the request while a cold model loads instead of answering 503
+	UseCache     *bool `json:"use_cache,omitempty"`      // false skips HF's cache of identical requests, e.g. for non-deterministic models
+}
+
 // HuggingFaceEmbeddingRequest represents the request format for HuggingFace embeddings API
 // Based on the HuggingFace Router API specification
 type HuggingFaceEmbeddingRequest struct {
-	Input               *InputsCustomType      `json:"input,omitempty"`    // string or []string used by all inference providers other than hf-inference
-	Inputs              *InputsCustomType      `json:"inputs,omitempty"`   // string or []string used by hf-inference provider
-	Provider            *string                `json:"provider,omitempty"` // used by all inference providers other than hf-inference
-	Model               *string                `json:"model,omitempty"`    // used by all inference providers other than hf-inference
-	Normalize           *bool                  `json:"normalize,omitempty"`
-	PromptName          *string                `json:"prompt_name,omitempty"`
-	Truncate            *bool                  `json:"truncate,omitempty"`
-	TruncationDirection *string                `json:"truncation_direction,omitempty"` // "left" or "right"
-	EncodingFormat      *EncodingType          `json:"encoding_format,omitempty"`
-	Dimensions          *int                   `json:"dimensions,omitempty"`
-	ExtraParams         map[string]interface{} `json:"-"`
+	Input               *InputsCustomType            `json:"input,omitempty"`    // string or []string used by all inference providers other than hf-inference
+	Inputs              *InputsCustomType            `json:"inputs,omitempty"`   // string or []string used by hf-inference provider
+	Provider            *string                      `json:"provider,omitempty"` // used by all inference providers other than hf-inference
+	Model               *string                      `json:"model,omitempty"`    // used by all inference providers other than hf-inference
+	Normalize           *bool                        `json:"normalize,omitempty"`
+	PromptName          *string                      `json:"prompt_name,omitempty"` // name of a prompt template from the model's sentence-transformers config (e.g. "query", "passage"); respected by instruction-tuned embedders such as intfloat/multilingual-e5-large-instruct and Qwen/Qwen3-Embedding-*, ignored by others
+	Truncate            *bool                        `json:"truncate,omitempty"`
+	TruncationDirection *TruncationDirection         `json:"truncation_direction,omitempty"` // "Left" or "Right"
+	EncodingFormat      *EncodingType                `json:"encoding_format,omitempty"`
+	Dimensions          *int                         `json:"dimensions,omitempty"`
+	Options             *HuggingFaceInferenceOptions `json:"options,omitempty"` // hf-inference only
+	ExtraParams         map[string]interface{}       `json:"-"`
 }
 
 func (req *HuggingFaceEmbeddingRequest) GetExtraParams() map[string]interface{} {
 	return req.ExtraParams
 }
 
+// HuggingFaceSentenceSimilarityRequest is the hf-inference sentence-similarity payload, scoring
+// one source sentence against each candidate in a single call.
+type HuggingFaceSentenceSimilarityRequest struct {
+	Inputs      HuggingFaceSentenceSimilarityInputs `json:"inputs"`
+	Options     *HuggingFaceInferenceOptions        `json:"options,omitempty"`
+	ExtraParams map[string]interface{}              `json:"-"`
+}
+
+func (req *HuggingFaceSentenceSimilarityRequest) GetExtraParams() map[string]interface{} {
+	return req.ExtraParams
+}
+
+type HuggingFaceSentenceSimilarityInputs struct {
+	SourceSentence string   `json:"source_sentence"`
+	Sentences      []string `json:"sentences"`
+}
+
+// HuggingFaceTextRankingRequest is the text-ranking (TEI /rerank) payload for cross-encoder
+// rerankers, which score each text jointly with the query.
+type HuggingFaceTextRankingRequest struct {
+	Query       string                       `json:"query"`
+	Texts       []string                     `json:"texts"`
+	Options     *HuggingFaceInferenceOptions `json:"options,omitempty"`
+	ExtraParams map[string]interface{}       `json:"-"` // e.g. truncate, raw_scores
+}
+
+func (req *HuggingFaceTextRankingRequest) GetExtraParams() map[string]interface{} {
+	return req.ExtraParams
+}
+
+// HuggingFaceTextRankingResult is one scored text of a text-ranking response.
+type HuggingFaceTextRankingResult struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
 type InputsCustomType struct {
 	Texts []string `json:"texts,omitempty"`
 	Text  *string  `json:"text,omitempty"`
@@ -242,6 +459,45 @@ const (
 	EncodingTypeBase64 EncodingType = "base64"
 )
 
+// EmbeddingDType is the numeric type a TEI deployment returned embeddings in. Quantized
+// deployments declare it with a top-level "dtype" (and usually a "scale") in the response.
+type EmbeddingDType string
+
+const (
+	EmbeddingDTypeFloat32 EmbeddingDType = "float32" // plain JSON floats (assumed when the response declares nothing)
+	EmbeddingDTypeFloat16 EmbeddingDType = "float16"
+	EmbeddingDTypeInt8    EmbeddingDType = "int8"
+	EmbeddingDTypeUint8   EmbeddingDType = "uint8"
+)
+
+// NewlinePolicy controls how newlines in embedding inputs are rewritten before
+// they are sent upstream. Set via ExtraParams["newline_policy"].
+type NewlinePolicy string
+
+const (
+	NewlinePolicyNone     NewlinePolicy = "none"     // send inputs as-is (default)
+	NewlinePolicyReplace  NewlinePolicy = "replace"  // replace each newline with a single space
+	NewlinePolicyCollapse NewlinePolicy = "collapse" // collapse all whitespace runs to a single space and trim
+)
+
+// EmbeddingPooling controls how token-level (3-D) feature-extraction output, returned by
+// models served without a pooling layer, is reduced. Set via ExtraParams["pooling"].
+type EmbeddingPooling string
+
+const (
+	EmbeddingPoolingMean EmbeddingPooling = "mean" // average token vectors into one vector per input (default)
+	EmbeddingPoolingNone EmbeddingPooling = "none" // return each input's token matrix in Embedding2DArray
+)
+
+// TruncationDirection selects which end of an oversized embedding input is clipped
+// when truncate is enabled. Set via ExtraParams["truncation_direction"].
+type TruncationDirection string
+
+const (
+	TruncationDirectionLeft  TruncationDirection = "Left"  // drop tokens from the start of the input
+	TruncationDirectionRight TruncationDirection = "Right" // drop tokens from the end of the input
+)
+
 // # SPEECH TYPES
 
 // Speech request represents the inputs for Text To Speech inference.
@@ -257,6 +513,18 @@ func (req *HuggingFaceSpeechRequest) GetExtraParams() map[string]interface{} {
 	return req.ExtraParams
 }
 
+// HuggingFaceInferenceSpeechRequest is the hf-inference Text To Speech payload, which
+// takes the text under "inputs" and answers with raw audio bytes rather than a JSON envelope.
+type HuggingFaceInferenceSpeechRequest struct {
+	Inputs      string                       `json:"inputs"`
+	Parameters  *HuggingFaceSpeechParameters `json:"parameters,omitempty"`
+	ExtraParams map[string]interface{}       `json:"-"`
+}
+
+func (req *HuggingFaceInferenceSpeechRequest) GetExtraParams() map[string]interface{} {
+	return req.ExtraParams
+}
+
 // Speech parameters are additional inference parameters for Text To Speech
 type HuggingFaceSpeechParameters struct {
 	GenerationParameters *HuggingFaceTranscriptionGenerationParameters `json:"generation_parameters,omitempty"`
@@ -373,8 +641,26 @@ type HuggingFaceEarlyStoppingUnion = HuggingFaceTranscriptionEarlyStopping
 
 // HuggingFaceHFInferenceImageGenerationRequest for hf-inference image generation
 type HuggingFaceHFInferenceImageGenerationRequest struct {
-	Inputs      string         `json:"inputs"`
-	ExtraParams map[string]any `json:"-"`
+	Inputs      string                                           `json:"inputs"`
+	Parameters  *HuggingFaceHFInferenceImageGenerationParameters `json:"parameters,omitempty"`
+	Options     *HuggingFaceInferenceOptions                     `json:"options,omitempty"`
+	ExtraParams map[string]any                                   `json:"-"`
+}
+
+// HuggingFaceHFInferenceImageGenerationParameters are the text-to-image task parameters,
+// see https://huggingface.co/docs/inference-providers/tasks/text-to-image
+type HuggingFaceHFInferenceImageGenerationParameters struct {
+	GuidanceScale     *float64                    `json:"guidance_scale,omitempty"`
+	NegativePrompt    *string                     `json:"negative_prompt,omitempty"`
+	NumInferenceSteps *int                        `json:"num_inference_steps,omitempty"`
+	TargetSize        *HuggingFaceImageTargetSize `json:"target_size,omitempty"`
+	Seed              *int                        `json:"seed,omitempty"`
+}
+
+// HuggingFaceImageTargetSize is the requested output size of a generated image, in pixels.
+type HuggingFaceImageTargetSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
 }
 
 func (req *HuggingFaceHFInferenceImageGenerationRequest) GetExtraParams() map[string]any {
@@ -527,3 +813,62 @@ type HuggingFaceFalAIImageEditRequest struct {
 func (req *HuggingFaceFalAIImageEditRequest) GetExtraParams() map[string]any {
 	return req.ExtraParams
 }
+
+// # IMAGE-TO-TEXT TYPES
+
+// HuggingFaceImageToTextRequest is the hf-inference image-to-text (captioning) payload.
+type HuggingFaceImageToTextRequest struct {
+	Inputs      string                       `json:"inputs"` // base64-encoded image bytes
+	Options     *HuggingFaceInferenceOptions `json:"options,omitempty"`
+	ExtraParams map[string]interface{}       `json:"-"` // e.g. parameters.max_new_tokens
+}
+
+func (req *HuggingFaceImageToTextRequest) GetExtraParams() map[string]interface{} {
+	return req.ExtraParams
+}
+
+// HuggingFaceImageToTextResult is one generated caption of an image-to-text response.
+type HuggingFaceImageToTextResult struct {
+	GeneratedText string `json:"generated_text"`
+}
+
+// # TEXT GENERATION TYPES
+
+// HuggingFaceTextGenerationRequest is the raw-prompt text-generation payload of the hf-inference
+// pipeline, used for models that do not serve the chat completions route.
+type HuggingFaceTextGenerationRequest struct {
+	Inputs      string                               `json:"inputs"`
+	Parameters  *HuggingFaceTextGenerationParameters `json:"parameters,omitempty"`
+	Options     *HuggingFaceInferenceOptions         `json:"options,omitempty"`
+	ExtraParams map[string]interface{}               `json:"-"`
+}
+
+func (req *HuggingFaceTextGenerationRequest) GetExtraParams() map[string]interface{} {
+	return req.ExtraParams
+}
+
+// HuggingFaceTextGenerationParameters are the generation settings of a text-generation request.
+type HuggingFaceTextGenerationParameters struct {
+	MaxNewTokens      *int     `json:"max_new_tokens,omitempty"`
+	Temperature       *float64 `json:"temperature,omitempty"`
+	TopP              *float64 `json:"top_p,omitempty"`
+	Seed              *int     `json:"seed,omitempty"`
+	Stop              []string `json:"stop,omitempty"`
+	RepetitionPenalty *float64 `json:"repetition_penalty,omitempty"` // > 1 discourages repeating tokens already generated
+	TopK              *int     `json:"top_k,omitempty"`              // Sample from the k most likely tokens only
+	DoSample          *bool    `json:"do_sample,omitempty"`          // false decodes greedily
+	ReturnFullText    bool     `json:"return_full_text"`             // Prepend the prompt to the generated text (the completions echo)
+	Details           bool     `json:"details"`                      // Ask for the finish reason and generated token count
+}
+
+// HuggingFaceTextGenerationResult is one generation of a text-generation response.
+type HuggingFaceTextGenerationResult struct {
+	GeneratedText string                            `json:"generated_text"`
+	Details       *HuggingFaceTextGenerationDetails `json:"details,omitempty"`
+}
+
+// HuggingFaceTextGenerationDetails is the generation summary returned when details are requested.
+type HuggingFaceTextGenerationDetails struct {
+	FinishReason    string `json:"finish_reason"` // "length", "eos_token" or "stop_sequence"
+	GeneratedTokens int    `json:"generated_tokens"`
+}
@@ -2,13 +2,20 @@ package huggingface
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
+	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bytedance/sonic"
+	"github.com/google/uuid"
 	providerUtils "github.com/maximhq/bifrost/core/providers/utils"
 	schemas "github.com/maximhq/bifrost/core/schemas"
 	"github.com/valyala/fasthttp"
@@ -20,6 +27,9 @@ const (
 	defaultInferenceBaseURL = "https://router.huggingface.co"
 	modelHubBaseURL         = "https://huggingface.co"
 
+	// requestIDHeader carries the call's request ID to HF, which echoes its own in the response
+	requestIDHeader = "x-request-id"
+
 	//For custom deployments, HF offers inference endpoints under
 	// inferenceBaseEndpointsEndpointBaseURL = "https://api.endpoints.huggingface.cloud/v2"
 )
@@ -80,25 +90,44 @@ var PROVIDERS_OR_POLICIES = func() []inferenceProvider {
 	return out
 }()
 
-func (provider *HuggingFaceProvider) buildModelHubURL(request *schemas.BifrostListModelsRequest, inferenceProvider inferenceProvider) string {
+// buildModelHubURL builds the Hub listing URL for an inference provider, forwarding ExtraParams
+// as query parameters and resuming from cursor when set. It errors when the result would exceed
+// maxModelHubURLLength.
+func (provider *HuggingFaceProvider) buildModelHubURL(request *schemas.BifrostListModelsRequest, inferenceProvider inferenceProvider, cursor string) (string, error) {
 	values := url.Values{}
 
 	// Add inference_provider parameter to filter models served by Hugging Face's inference provider
 	// According to https://huggingface.co/docs/inference-providers/hub-api
 	limit := request.PageSize
 	if limit <= 0 {
-		limit = defaultModelFetchLimit
-	}
-	if limit > maxModelFetchLimit {
-		limit = maxModelFetchLimit
+		limit = provider.defaultModelFetchLimit()
 	}
+	limit = min(limit, provider.maxModelFetchLimit())
 	values.Set("limit", strconv.Itoa(limit))
 	values.Set("full", "1")
-	values.Set("sort", "likes")
-	values.Set("direction", "-1")
+	values.Set("cardData", "1")
+	sortKey, direction, sortErr := parseModelHubSort(request.ExtraParams)
+	if sortErr != nil {
+		return "", sortErr
+	}
+	values.Set("sort", sortKey)
+	values.Set("direction", direction)
 	values.Set("inference_provider", string(inferenceProvider))
+	pipelineTag, tagErr := parseModelHubPipelineTag(request.ExtraParams)
+	if tagErr != nil {
+		return "", tagErr
+	}
+	if pipelineTag != "" {
+		values.Set("pipeline_tag", pipelineTag)
+	}
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
 
 	for key, value := range request.ExtraParams {
+		if _, ok := listModelsControlParams[key]; ok {
+			continue
+		}
 		switch typed := value.(type) {
 		case string:
 			if typed != "" {
@@ -117,7 +146,62 @@ func (provider *HuggingFaceProvider) buildModelHubURL(request *schemas.BifrostLi
 		}
 	}
 
-	return fmt.Sprintf("%s/api/models?%s", modelHubBaseURL, values.Encode())
+	modelHubURL := fmt.Sprintf("%s/api/models?%s", modelHubBaseURL, values.Encode())
+	if len(modelHubURL) > maxModelHubURLLength {
+		return "", fmt.Errorf("model hub request URL is %d characters, exceeding the %d character limit; reduce the size of list models extra params", len(modelHubURL), maxModelHubURLLength)
+	}
+	return modelHubURL, nil
+}
+
+// extractCursor returns the Hub pagination cursor for the next page. The Hub advertises it in an
+// RFC 5988 Link header (`<https://huggingface.co/api/models?...&cursor=...>; rel="next"`); the
+// nonstandard X-Next-Page header is only consulted when no Link header is present.
+func extractCursor(header *fasthttp.ResponseHeader) string {
+	link := strings.TrimSpace(string(header.Peek("Link")))
+	if link == "" {
+		return strings.TrimSpace(string(header.Peek("X-Next-Page")))
+	}
+
+	for _, entry := range strings.Split(link, ",") {
+		parts := strings.Split(entry, ";")
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		isNext := false
+		for _, param := range parts[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+				continue
+			}
+			// rel may hold several space-separated relation types
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				if strings.EqualFold(rel, "next") {
+					isNext = true
+				}
+			}
+		}
+		if !isNext {
+			continue
+		}
+		nextURL, err := url.Parse(strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">"))
+		if err != nil {
+			return ""
+		}
+		return nextURL.Query().Get("cursor")
+	}
+	return ""
+}
+
+// buildModelInfoURL returns the Hub's single-model endpoint, which includes the full card data.
+func (provider *HuggingFaceProvider) buildModelInfoURL(modelName string) string {
+	return fmt.Sprintf("%s/api/models/%s", modelHubBaseURL, modelName)
+}
+
+// buildModelAuthCheckURL returns the Hub endpoint that answers 200 when the key may download
+// (and so run) the model, and 401/403 when it is gated or private for the key's account.
+func (provider *HuggingFaceProvider) buildModelAuthCheckURL(modelName string) string {
+	return fmt.Sprintf("%s/api/models/%s/auth-check", modelHubBaseURL, modelName)
 }
 
 func (provider *HuggingFaceProvider) buildModelInferenceProviderURL(modelName string) string {
@@ -127,7 +211,452 @@ func (provider *HuggingFaceProvider) buildModelInferenceProviderURL(modelName st
 	return fmt.Sprintf("%s/api/models/%s?%s", modelHubBaseURL, modelName, values.Encode())
 }
 
+// resolveModelAlias applies the alias rules core's exact-match resolution lacks. Core resolves
+// the key's aliases before the provider runs, so a model that is already an alias target is
+// returned as is; resolving it again would chain aliases or send it on to a catch-all. Otherwise,
+// alias keys namespaced under one of the configured prefixes (e.g. "prod/llama-3" with prefix
+// "prod") match the bare request ("llama-3"), and failing that, pattern keys ending in "*" (e.g.
+// "llama-*", or "*" as a catch-all) match by prefix. Surrounding whitespace, as left by IDs pasted
+// from a UI, is trimmed from the model and the resolved target; an exact alias only matches here
+// when trimming is what made it match, since core has already tried the untrimmed model.
+func resolveModelAlias(aliases schemas.KeyAliases, model string, namespacePrefixes []string) string {
+	trimmed := strings.TrimSpace(model)
+	if len(aliases) == 0 || isAliasTarget(aliases, trimmed) {
+		return trimmed
+	}
+	if trimmed != model {
+		if resolved := aliases.Resolve(trimmed); resolved != trimmed {
+			return strings.TrimSpace(resolved)
+		}
+	}
+	for _, prefix := range namespacePrefixes {
+		prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+		if prefix == "" {
+			continue
+		}
+		namespaced := prefix + "/" + trimmed
+		if resolved := aliases.Resolve(namespaced); resolved != namespaced {
+			return strings.TrimSpace(resolved)
+		}
+	}
+	if resolved, ok := resolveModelAliasPattern(aliases, trimmed); ok {
+		return strings.TrimSpace(resolved)
+	}
+	return trimmed
+}
+
+// isAliasTarget reports whether model is one of the aliases' targets, case-insensitively.
+func isAliasTarget(aliases schemas.KeyAliases, model string) bool {
+	for _, target := range aliases {
+		if strings.EqualFold(strings.TrimSpace(target), model) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveModelAliasPattern matches model against the "*"-suffixed alias keys, case-insensitively.
+// The pattern with the longest literal prefix wins, so "*" only applies when nothing else does.
+func resolveModelAliasPattern(aliases schemas.KeyAliases, model string) (string, bool) {
+	lowerModel := strings.ToLower(model)
+	var bestLiteral, bestTarget string
+	found := false
+	for pattern, target := range aliases {
+		literal, isPattern := strings.CutSuffix(pattern, "*")
+		if !isPattern || !strings.HasPrefix(lowerModel, strings.ToLower(literal)) {
+			continue
+		}
+		// Break length ties on the literal itself so the result doesn't depend on map order
+		if !found || len(literal) > len(bestLiteral) || (len(literal) == len(bestLiteral) && literal < bestLiteral) {
+			bestLiteral, bestTarget, found = literal, target, true
+		}
+	}
+	return bestTarget, found
+}
+
+// minWaitForModelTimeout is the request timeout below which wait_for_model is likely to time out
+// before a cold model has loaded; larger models commonly take over a minute.
+const minWaitForModelTimeout = 120 * time.Second
+
+// extractInferenceOptions consumes wait_for_model and use_cache from extraParams into an
+// hf-inference options object, or returns nil when neither is set.
+func extractInferenceOptions(extraParams map[string]interface{}) *HuggingFaceInferenceOptions {
+	options := &HuggingFaceInferenceOptions{}
+	if waitForModel, ok := schemas.SafeExtractBool(extraParams["wait_for_model"]); ok {
+		delete(extraParams, "wait_for_model")
+		options.WaitForModel = &waitForModel
+	}
+	if useCache, ok := schemas.SafeExtractBool(extraParams["use_cache"]); ok {
+		delete(extraParams, "use_cache")
+		options.UseCache = &useCache
+	}
+	if options.WaitForModel == nil && options.UseCache == nil {
+		return nil
+	}
+	return options
+}
+
+// warnShortWaitForModelTimeout warns when extraParams ask HF to hold the request through a cold
+// start while the request timeout (or ctx's deadline, if sooner) is too short for one.
+func (provider *HuggingFaceProvider) warnShortWaitForModelTimeout(ctx context.Context, extraParams map[string]interface{}, model string) {
+	if waitForModel, ok := schemas.SafeExtractBool(extraParams["wait_for_model"]); !ok || !waitForModel {
+		return
+	}
+	timeout := time.Duration(provider.networkConfig.DefaultRequestTimeoutInSeconds) * time.Second
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	if timeout < minWaitForModelTimeout {
+		provider.logger.Warn(withRequestID(ctx, fmt.Sprintf("huggingface: wait_for_model is set for %s but the request times out after %s; a cold start can take longer, consider a timeout of at least %s", model, timeout.Round(time.Second), minWaitForModelTimeout), nil))
+	}
+}
+
+// maxEmbeddingRequestBytes returns the largest embedding request body sent, or 0 when the check
+// is disabled by a negative configured value.
+func (provider *HuggingFaceProvider) maxEmbeddingRequestBytes() int {
+	configured := provider.huggingFaceConfig.MaxEmbeddingRequestBytes
+	if configured < 0 {
+		return 0
+	}
+	if configured == 0 {
+		return defaultMaxEmbeddingRequestBytes
+	}
+	return configured
+}
+
+// defaultModelFetchLimit returns how many models a listing fetches per inference provider when
+// the request sets no page size, capped at the per-page maximum.
+func (provider *HuggingFaceProvider) defaultModelFetchLimit() int {
+	limit := defaultModelFetchLimit
+	if configured := provider.huggingFaceConfig.DefaultModelFetchLimit; configured > 0 {
+		limit = configured
+	}
+	return min(limit, provider.maxModelFetchLimit())
+}
+
+// maxModelFetchLimit returns the most models requested from the Hub per page. A configured
+// value above the Hub's own maximum is lowered to it.
+func (provider *HuggingFaceProvider) maxModelFetchLimit() int {
+	if configured := provider.huggingFaceConfig.MaxModelFetchLimit; configured > 0 {
+		return min(configured, maxModelFetchLimit)
+	}
+	return maxModelFetchLimit
+}
+
+// modelHubURL returns the Hub page of the resolved model when IncludeModelHubURL is enabled.
+// modelName is the Hub repo ID ({org}/{model}), without the inference provider prefix.
+func (provider *HuggingFaceProvider) modelHubURL(modelName string) string {
+	if !provider.huggingFaceConfig.IncludeModelHubURL || modelName == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", modelHubBaseURL, modelName)
+}
+
+// effectiveConfig returns config for ExtraFields.EffectiveConfig when IncludeEffectiveConfig is
+// enabled, and an untyped nil otherwise so the field is omitted.
+func (provider *HuggingFaceProvider) effectiveConfig(config HuggingFaceEffectiveConfig) interface{} {
+	if !provider.huggingFaceConfig.IncludeEffectiveConfig {
+		return nil
+	}
+	return &config
+}
+
+// resolveModelAlias applies the provider's alias namespace rules on top of the key's aliases.
+// Resolutions are logged from the model the caller asked for, which core records in the context
+// before resolving the key's exact aliases itself.
+func (provider *HuggingFaceProvider) resolveModelAlias(ctx *schemas.BifrostContext, key schemas.Key, model string) string {
+	resolved := resolveModelAlias(key.Aliases, model, provider.huggingFaceConfig.AliasNamespacePrefixes)
+	if !provider.huggingFaceConfig.LogAliasResolution {
+		return resolved
+	}
+	requested := model
+	// Only trust the recorded model when it is what led here, not e.g. an embedding fallback model
+	if original, ok := ctx.Value(schemas.BifrostContextKeyOriginalModelRequested).(string); ok && original != "" && key.Aliases.Resolve(original) == model {
+		requested = original
+	}
+	if resolved != strings.TrimSpace(requested) {
+		provider.logger.Debug(withRequestID(ctx, fmt.Sprintf("huggingface: model alias resolved requested=%q resolved=%q key_id=%q", requested, resolved, key.ID), nil))
+	}
+	return resolved
+}
+
+// keyInferenceProvider returns the backend the key prefers on the HF router, or "" when it has none.
+func keyInferenceProvider(key schemas.Key) inferenceProvider {
+	if key.HuggingFaceKeyConfig == nil {
+		return ""
+	}
+	return inferenceProvider(strings.ToLower(strings.TrimSpace(key.HuggingFaceKeyConfig.InferenceProvider)))
+}
+
+// dedicatedEndpointURL returns the Dedicated Inference Endpoint the key routes model to, without
+// a trailing slash, or "" when the key has none for it. model may carry an inference provider
+// prefix, which is ignored for the lookup. Values that are not full http(s) URLs are rejected.
+func dedicatedEndpointURL(key schemas.Key, model string) (string, error) {
+	if key.HuggingFaceKeyConfig == nil || len(key.HuggingFaceKeyConfig.Endpoints) == 0 {
+		return "", nil
+	}
+	model = strings.TrimSpace(model)
+	endpoint, ok := key.HuggingFaceKeyConfig.Endpoints[model]
+	if !ok && strings.Count(model, "/") > 1 {
+		_, modelName, _ := strings.Cut(model, "/")
+		endpoint, ok = key.HuggingFaceKeyConfig.Endpoints[modelName]
+	}
+	if !ok {
+		return "", nil
+	}
+
+	endpoint = normalizeBaseURL(endpoint)
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return "", fmt.Errorf("endpoint %q configured for model %s is not a full http(s) URL", endpoint, model)
+	}
+	return endpoint, nil
+}
+
+// estimatedCost prices usage with the key's price for modelName served by inferenceProvider,
+// preferring a "{inference_provider}/{org}/{model}" entry over a bare "{org}/{model}" one.
+// Returns nil when the key has no price for the model or there is no usage to price.
+func estimatedCost(key schemas.Key, inferenceProvider inferenceProvider, modelName string, usage *schemas.BifrostLLMUsage) *schemas.BifrostCost {
+	if usage == nil || key.HuggingFaceKeyConfig == nil || len(key.HuggingFaceKeyConfig.Pricing) == 0 {
+		return nil
+	}
+	price, ok := key.HuggingFaceKeyConfig.Pricing[string(inferenceProvider)+"/"+modelName]
+	if !ok {
+		price, ok = key.HuggingFaceKeyConfig.Pricing[modelName]
+	}
+	if !ok {
+		return nil
+	}
+
+	cost := &schemas.BifrostCost{
+		InputTokensCost:  float64(usage.PromptTokens) * price.InputPerMillionTokens / 1e6,
+		OutputTokensCost: float64(usage.CompletionTokens) * price.OutputPerMillionTokens / 1e6,
+	}
+	cost.TotalCost = cost.InputTokensCost + cost.OutputTokensCost
+	return cost
+}
+
+// coreModulePath is the module whose version identifies Bifrost in the default User-Agent.
+const coreModulePath = "github.com/maximhq/bifrost/core"
+
+// defaultUserAgent is "bifrost/<version>", sent unless HuggingFaceConfig.UserAgent overrides it.
+var defaultUserAgent = "bifrost/" + coreModuleVersion()
+
+// rankModelSuggestions returns up to limit of candidates other than modelName, closest first by
+// the edit distance between the lowercased model names (without author). Ties keep the Hub's order.
+func rankModelSuggestions(modelName string, candidates []string, limit int) []string {
+	nameOf := func(id string) string {
+		if idx := strings.LastIndex(id, "/"); idx >= 0 {
+			id = id[idx+1:]
+		}
+		return strings.ToLower(id)
+	}
+	target := nameOf(modelName)
+
+	type rankedModel struct {
+		id       string
+		distance int
+	}
+	ranked := make([]rankedModel, 0, len(candidates))
+	seen := make(map[string]struct{}, len(candidates))
+	for _, id := range candidates {
+		if strings.EqualFold(id, modelName) {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ranked = append(ranked, rankedModel{id: id, distance: editDistance(target, nameOf(id))})
+	}
+	slices.SortStableFunc(ranked, func(a, b rankedModel) int { return a.distance - b.distance })
+
+	suggestions := make([]string, 0, min(limit, len(ranked)))
+	for _, model := range ranked[:min(limit, len(ranked))] {
+		suggestions = append(suggestions, model.id)
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between a and b, counted in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// coreModuleVersion reads the core module's version from the build info of the binary embedding
+// it, or returns "dev" when it is unknown (e.g. when built from a local checkout).
+func coreModuleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	var module *debug.Module
+	if info.Main.Path == coreModulePath {
+		module = &info.Main
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == coreModulePath {
+			module = dep
+		}
+	}
+	if module != nil && module.Replace != nil {
+		module = module.Replace
+	}
+	if module == nil || module.Version == "" || module.Version == "(devel)" {
+		return "dev"
+	}
+	return strings.TrimPrefix(module.Version, "v")
+}
+
+// userAgent returns the User-Agent for requests made under config.
+func userAgent(config schemas.HuggingFaceConfig) string {
+	if value := strings.TrimSpace(config.UserAgent); value != "" {
+		return value
+	}
+	return defaultUserAgent
+}
+
+// baseURLRouteSuffixes are paths Bifrost appends itself, longest first, so a configured base or
+// endpoint URL ending in one is cut back to its root instead of having the path doubled.
+var baseURLRouteSuffixes = []string{"/v1/chat/completions", "/v1/embeddings", "/rerank", "/v1"}
+
+// normalizeBaseURL trims whitespace, trailing slashes and a trailing route such as "/v1" from a
+// router or dedicated-endpoint base URL, leaving any other path (e.g. a proxy prefix) intact.
+func normalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	for _, suffix := range baseURLRouteSuffixes {
+		if strings.HasSuffix(baseURL, suffix) {
+			return strings.TrimRight(strings.TrimSuffix(baseURL, suffix), "/")
+		}
+	}
+	return baseURL
+}
+
+// dedicatedEndpointHostSuffix is the domain of HF-managed dedicated Inference Endpoints.
+const dedicatedEndpointHostSuffix = ".endpoints.huggingface.cloud"
+
+// isDedicatedEndpointURL reports whether baseURL points at a dedicated Inference Endpoint
+// rather than the router (or a proxy in front of it).
+func isDedicatedEndpointURL(baseURL string) bool {
+	parsed, err := url.Parse(baseURL)
+	return err == nil && strings.HasSuffix(strings.ToLower(parsed.Hostname()), dedicatedEndpointHostSuffix)
+}
+
+// dedicatedEndpointRequestURL returns the URL serving requestType on a dedicated Inference
+// Endpoint rooted at endpoint: chat goes to the OpenAI-compatible /v1 route, reranking to TEI's
+// /rerank, and feature-extraction to the endpoint root.
+func dedicatedEndpointRequestURL(endpoint string, requestType schemas.RequestType) string {
+	switch requestType {
+	case schemas.ChatCompletionRequest, schemas.ChatCompletionStreamRequest:
+		return endpoint + "/v1/chat/completions"
+	case schemas.RerankRequest:
+		return endpoint + "/rerank"
+	default:
+		return endpoint
+	}
+}
+
+// bearerAuthHeader builds the Authorization header value for an HF token, or "" when there is
+// no token. A token already stored with a "Bearer " prefix is not prefixed a second time.
+func bearerAuthHeader(token string) string {
+	const scheme = "Bearer "
+	token = strings.TrimLeft(token, " \t")
+	if len(token) >= len(scheme) && strings.EqualFold(token[:len(scheme)], scheme) {
+		token = token[len(scheme):]
+	}
+	if token = strings.TrimSpace(token); token == "" {
+		return ""
+	}
+	return scheme + token
+}
+
+// requestID returns the call's request ID from ctx. Bifrost sets one on every request; when the
+// provider is driven directly without one, a new ID is generated and stored on ctx so the rest of
+// the call reuses it.
+func requestID(ctx context.Context) string {
+	if id, _ := ctx.Value(schemas.BifrostContextKeyRequestID).(string); id != "" {
+		return id
+	}
+	id := uuid.New().String()
+	if bifrostCtx, ok := ctx.(*schemas.BifrostContext); ok {
+		bifrostCtx.SetValue(schemas.BifrostContextKeyRequestID, id)
+	}
+	return id
+}
+
+// setRequestIDHeader sends the call's request ID as x-request-id, unless the extra headers
+// already carry one.
+func setRequestIDHeader(ctx context.Context, req *fasthttp.Request) {
+	if len(req.Header.Peek(requestIDHeader)) == 0 {
+		req.Header.Set(requestIDHeader, requestID(ctx))
+	}
+}
+
+// withRequestID appends the call's request ID to a log message, followed by the ID HF echoed in
+// resp's x-request-id header when resp is given and carries one.
+func withRequestID(ctx context.Context, message string, resp *fasthttp.Response) string {
+	message += fmt.Sprintf(" request_id=%q", requestID(ctx))
+	if resp != nil {
+		if upstreamID := string(resp.Header.Peek(requestIDHeader)); upstreamID != "" {
+			message += fmt.Sprintf(" upstream_request_id=%q", upstreamID)
+		}
+	}
+	return message
+}
+
+// proxyConfigFingerprint identifies a proxy configuration by its resolved values, so keys whose
+// settings resolve the same share clients. Credentials are hashed rather than kept as map keys.
+func proxyConfigFingerprint(proxyConfig *schemas.ProxyConfig) string {
+	hash := sha256.New()
+	for _, part := range []string{
+		string(proxyConfig.Type),
+		proxyConfig.URL.GetValue(),
+		proxyConfig.Username.GetValue(),
+		proxyConfig.Password.GetValue(),
+		proxyConfig.CACertPEM.GetValue(),
+	} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// applyKeyInferenceProvider prefixes a bare "{org}/{model}" ID with the key's preferred inference
+// provider, or with fallback when the key has none (an empty fallback leaves the ID untouched).
+// IDs that already name a provider are returned as is.
+func applyKeyInferenceProvider(key schemas.Key, model string, fallback inferenceProvider) string {
+	if strings.Count(model, "/") != 1 {
+		return model
+	}
+	preferred := keyInferenceProvider(key)
+	if preferred == "" {
+		preferred = fallback
+	}
+	if preferred == "" {
+		return model
+	}
+	return string(preferred) + "/" + model
+}
+
 func splitIntoModelProvider(bifrostModelName string) (inferenceProvider, string, error) {
+	bifrostModelName = strings.TrimSpace(bifrostModelName)
 	// Extract provider and model name
 	t := strings.Count(bifrostModelName, "/")
 	if t == 0 {
@@ -147,7 +676,8 @@ func splitIntoModelProvider(bifrostModelName string) (inferenceProvider, string,
 }
 
 // Defined for tasks given by https://huggingface.co/docs/inference-providers/en/index and makeURL logic at https://github.com/huggingface/huggingface.js/blob/c02dd89eff24593b304d72715247f7eef79b3b73/packages/inference/src/providers/providerHelper.ts#L111
-func (provider *HuggingFaceProvider) getInferenceProviderRouteURL(ctx *schemas.BifrostContext, inferenceProvider inferenceProvider, modelName string, requestType schemas.RequestType) (string, error) {
+// task picks the hf-inference pipeline for request types served by more than one (rerank).
+func (provider *HuggingFaceProvider) getInferenceProviderRouteURL(ctx *schemas.BifrostContext, inferenceProvider inferenceProvider, modelName string, requestType schemas.RequestType, task string) (string, error) {
 	defaultPath := ""
 	switch inferenceProvider {
 	case falAI:
@@ -157,12 +687,18 @@ func (provider *HuggingFaceProvider) getInferenceProviderRouteURL(ctx *schemas.B
 		switch requestType {
 		case schemas.EmbeddingRequest:
 			pipeline = "feature-extraction"
+		case schemas.RerankRequest:
+			pipeline = task
+		case schemas.TextCompletionRequest:
+			pipeline = "text-generation"
 		case schemas.SpeechRequest:
-			pipeline = "text-to-speech"
+			return provider.buildRequestURL(ctx, fmt.Sprintf("/hf-inference/models/%s", modelName), requestType), nil
 		case schemas.ImageGenerationRequest:
 			return provider.buildRequestURL(ctx, fmt.Sprintf("/hf-inference/models/%s", modelName), requestType), nil
 		case schemas.TranscriptionRequest:
 			return provider.buildRequestURL(ctx, fmt.Sprintf("/hf-inference/models/%s", modelName), requestType), nil
+		case schemas.OCRRequest:
+			return provider.buildRequestURL(ctx, fmt.Sprintf("/hf-inference/models/%s", modelName), requestType), nil
 		default:
 			pipeline = "chat-completion"
 		}
@@ -234,6 +770,9 @@ func (provider *HuggingFaceProvider) getModelInferenceProviderMapping(ctx contex
 	defer fasthttp.ReleaseRequest(req)
 	defer fasthttp.ReleaseResponse(resp)
 
+	providerUtils.SetExtraHeaders(ctx, req, provider.networkConfig.ExtraHeaders, nil)
+	setRequestIDHeader(ctx, req)
+
 	req.SetRequestURI(provider.buildModelInferenceProviderURL(huggingfaceModelName))
 	req.Header.SetMethod(http.MethodGet)
 	req.Header.SetContentType("application/json")
@@ -300,35 +839,241 @@ func (provider *HuggingFaceProvider) getValidatedProviderModelID(ctx context.Con
 	return mapping.ProviderModelID, nil
 }
 
-// downloadAudioFromURL downloads audio data from a URL
-func (provider *HuggingFaceProvider) downloadAudioFromURL(ctx context.Context, audioURL string) ([]byte, error) {
+// rerankTask returns the pipeline the model's hf-inference mapping reranks with: text-ranking
+// for cross-encoders, sentence-similarity for bi-encoders.
+func (provider *HuggingFaceProvider) rerankTask(ctx context.Context, huggingfaceModelName string) (string, *schemas.BifrostError) {
+	providerMapping, bifrostErr := provider.getModelInferenceProviderMapping(ctx, huggingfaceModelName)
+	if bifrostErr != nil {
+		return "", bifrostErr
+	}
+	if mapping, ok := providerMapping[hfInference]; ok {
+		switch mapping.ProviderTask {
+		case "text-ranking", "sentence-similarity":
+			return mapping.ProviderTask, nil
+		}
+	}
+	return "", providerUtils.NewUnsupportedOperationError(schemas.RerankRequest, provider.GetProviderKey())
+}
+
+// downloadFromURL downloads the kind (e.g. "audio", "image") of data at fileURL. fileURL is
+// the caller's, not Hugging Face's, so no extra headers are sent to it.
+func (provider *HuggingFaceProvider) downloadFromURL(ctx context.Context, fileURL string, kind string) ([]byte, error) {
 	req := fasthttp.AcquireRequest()
 	resp := fasthttp.AcquireResponse()
 	defer fasthttp.ReleaseRequest(req)
 	defer fasthttp.ReleaseResponse(resp)
 
-	req.SetRequestURI(audioURL)
+	req.SetRequestURI(fileURL)
 	req.Header.SetMethod(http.MethodGet)
 
 	_, bifrostErr, wait := providerUtils.MakeRequestWithContext(ctx, provider.client, req, resp)
 	defer wait()
 	if bifrostErr != nil {
-		return nil, fmt.Errorf("failed to download audio: %v", bifrostErr)
+		return nil, fmt.Errorf("failed to download %s: %v", kind, bifrostErr)
 	}
 
 	if resp.StatusCode() != fasthttp.StatusOK {
-		return nil, fmt.Errorf("failed to download audio: status=%d", resp.StatusCode())
+		return nil, fmt.Errorf("failed to download %s: status=%d", kind, resp.StatusCode())
 	}
 
 	body, err := providerUtils.CheckAndDecodeBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read audio data: %w", err)
+		return nil, fmt.Errorf("failed to read %s data: %w", kind, err)
 	}
 
 	// Copy the body to avoid use-after-free
-	audioCopy := append([]byte(nil), body...)
+	return append([]byte(nil), body...), nil
+}
+
+// imageBytes returns the bytes of an image given as an http(s) URL, a base64 data URL or raw
+// base64, downloading it in the first case.
+func (provider *HuggingFaceProvider) imageBytes(ctx context.Context, imageURL string) ([]byte, error) {
+	sanitizedURL, err := schemas.SanitizeImageURL(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+	info := schemas.ExtractURLTypeInfo(sanitizedURL)
+	if info.Type == schemas.ImageContentTypeBase64 && info.DataURLWithoutPrefix != nil {
+		data, err := base64.StdEncoding.DecodeString(*info.DataURLWithoutPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 image data: %w", err)
+		}
+		return data, nil
+	}
+	return provider.downloadFromURL(ctx, sanitizedURL, "image")
+}
 
-	return audioCopy, nil
+// newHuggingFaceBinaryResponse wraps raw task output bytes. The upstream content type is
+// used when it describes a binary payload; otherwise the type is sniffed from the bytes.
+func newHuggingFaceBinaryResponse(data []byte, contentType string) *HuggingFaceBinaryResponse {
+	mediaType := strings.ToLower(strings.TrimSpace(contentType))
+	if idx := strings.IndexByte(mediaType, ';'); idx >= 0 {
+		mediaType = strings.TrimSpace(mediaType[:idx])
+	}
+	if mediaType == "" || mediaType == "application/octet-stream" || strings.HasSuffix(mediaType, "json") {
+		mediaType = http.DetectContentType(data)
+		if idx := strings.IndexByte(mediaType, ';'); idx >= 0 {
+			mediaType = mediaType[:idx]
+		}
+	}
+	return &HuggingFaceBinaryResponse{
+		Data:        data,
+		ContentType: mediaType,
+	}
+}
+
+// Format returns the subtype of the content type (e.g. "png" for "image/png").
+func (r *HuggingFaceBinaryResponse) Format() string {
+	if r == nil {
+		return ""
+	}
+	_, subtype, found := strings.Cut(r.ContentType, "/")
+	if !found {
+		return ""
+	}
+	return subtype
+}
+
+// getHeaderValue looks up a provider response header case-insensitively.
+func getHeaderValue(headers map[string]string, name string) (string, bool) {
+	if value, ok := headers[name]; ok {
+		return value, true
+	}
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// parseRateLimitHeaders extracts rate-limit state from provider response headers. Both the
+// x-ratelimit-{limit,remaining,reset} headers and the IETF draft "RateLimit" header
+// (e.g. `"api";r=999;t=60`) are understood. Returns nil when no rate-limit header is present.
+func parseRateLimitHeaders(headers map[string]string) *schemas.ProviderRateLimit {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	parseInt := func(value string) *int64 {
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil
+		}
+		return &parsed
+	}
+
+	rateLimit := &schemas.ProviderRateLimit{}
+	if value, ok := getHeaderValue(headers, "x-ratelimit-limit"); ok {
+		rateLimit.Limit = parseInt(value)
+	}
+	if value, ok := getHeaderValue(headers, "x-ratelimit-remaining"); ok {
+		rateLimit.Remaining = parseInt(value)
+	}
+	if value, ok := getHeaderValue(headers, "x-ratelimit-reset"); ok {
+		rateLimit.Reset = parseInt(value)
+	}
+	if value, ok := getHeaderValue(headers, "ratelimit"); ok {
+		for _, param := range strings.Split(value, ";") {
+			name, paramValue, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found {
+				continue
+			}
+			switch name {
+			case "r":
+				if rateLimit.Remaining == nil {
+					rateLimit.Remaining = parseInt(paramValue)
+				}
+			case "t":
+				if rateLimit.Reset == nil {
+					rateLimit.Reset = parseInt(paramValue)
+				}
+			}
+		}
+	}
+	if value, ok := getHeaderValue(headers, "ratelimit-policy"); ok && rateLimit.Limit == nil {
+		for _, param := range strings.Split(value, ";") {
+			if name, paramValue, found := strings.Cut(strings.TrimSpace(param), "="); found && name == "q" {
+				rateLimit.Limit = parseInt(paramValue)
+			}
+		}
+	}
+	if value, ok := getHeaderValue(headers, "retry-after"); ok {
+		rateLimit.RetryAfter = parseRetryAfter(value)
+	}
+
+	if rateLimit.Limit == nil && rateLimit.Remaining == nil && rateLimit.Reset == nil && rateLimit.RetryAfter == nil {
+		return nil
+	}
+	return rateLimit
+}
+
+// parseGenerationTimingHeaders reads the x-{queue,validation,inference,total}-time and
+// x-time-per-token headers TGI adds to non-streaming generations. Tokens per second comes from
+// generatedTokens (falling back to TGI's x-generated-tokens header) over the inference time.
+// Returns nil when no timing header is present, as for backends other than TGI.
+func parseGenerationTimingHeaders(headers map[string]string, generatedTokens int) *schemas.GenerationTiming {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	parseMs := func(name string) *int64 {
+		value, ok := getHeaderValue(headers, name)
+		if !ok {
+			return nil
+		}
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || parsed < 0 {
+			return nil
+		}
+		return &parsed
+	}
+
+	timing := &schemas.GenerationTiming{
+		QueueTimeMs:      parseMs("x-queue-time"),
+		ValidationTimeMs: parseMs("x-validation-time"),
+		InferenceTimeMs:  parseMs("x-inference-time"),
+		TotalTimeMs:      parseMs("x-total-time"),
+		TimePerTokenMs:   parseMs("x-time-per-token"),
+	}
+	if timing.QueueTimeMs == nil && timing.ValidationTimeMs == nil && timing.InferenceTimeMs == nil && timing.TotalTimeMs == nil && timing.TimePerTokenMs == nil {
+		return nil
+	}
+
+	if generatedTokens <= 0 {
+		if value, ok := getHeaderValue(headers, "x-generated-tokens"); ok {
+			generatedTokens, _ = strconv.Atoi(strings.TrimSpace(value))
+		}
+	}
+	switch {
+	case generatedTokens > 0 && timing.InferenceTimeMs != nil && *timing.InferenceTimeMs > 0:
+		timing.TokensPerSecond = schemas.Ptr(float64(generatedTokens) * 1000 / float64(*timing.InferenceTimeMs))
+	case timing.TimePerTokenMs != nil && *timing.TimePerTokenMs > 0:
+		timing.TokensPerSecond = schemas.Ptr(1000 / float64(*timing.TimePerTokenMs))
+	}
+	return timing
+}
+
+// parseRetryAfter reads a Retry-After value given either in seconds or as an HTTP date, returning
+// the wait in whole seconds (never negative), or nil when the value is malformed.
+func parseRetryAfter(value string) *int64 {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return schemas.Ptr(max(seconds, 0))
+	}
+	if retryAt, err := http.ParseTime(value); err == nil {
+		return schemas.Ptr(max(int64(time.Until(retryAt).Seconds()), 0))
+	}
+	return nil
+}
+
+// withErrorRateLimit attaches the rate-limit headers of an error response to bifrostErr, so
+// callers can honor Retry-After on a 429 without re-reading the response.
+func withErrorRateLimit(bifrostErr *schemas.BifrostError, resp *fasthttp.Response) *schemas.BifrostError {
+	if bifrostErr != nil {
+		bifrostErr.ExtraFields.RateLimit = parseRateLimitHeaders(providerUtils.ExtractProviderResponseHeaders(resp))
+	}
+	return bifrostErr
 }
 
 func getMimeTypeForAudioType(audioType string) string {
@@ -0,0 +1,665 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	schemas "github.com/maximhq/bifrost/core/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestNewHuggingFaceBinaryResponse(t *testing.T) {
+	pngBytes := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		want        string
+		wantFormat  string
+	}{
+		{name: "header_wins", data: []byte{0x01, 0x02}, contentType: "audio/flac", want: "audio/flac", wantFormat: "flac"},
+		{name: "header_params_stripped", data: pngBytes, contentType: "image/jpeg; q=0.9", want: "image/jpeg", wantFormat: "jpeg"},
+		{name: "sniffed_without_header", data: pngBytes, want: "image/png", wantFormat: "png"},
+		{name: "sniffed_over_octet_stream", data: pngBytes, contentType: "application/octet-stream", want: "image/png", wantFormat: "png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newHuggingFaceBinaryResponse(tt.data, tt.contentType)
+			assert.Equal(t, tt.data, got.Data)
+			assert.Equal(t, tt.want, got.ContentType)
+			assert.Equal(t, tt.wantFormat, got.Format())
+		})
+	}
+}
+
+func TestUnmarshalHuggingFaceImageGenerationResponse_HFInferenceBinary(t *testing.T) {
+	pngBytes := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	resp, err := UnmarshalHuggingFaceImageGenerationResponse(pngBytes, "hf-inference/stabilityai/stable-diffusion-xl-base-1.0")
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(pngBytes), resp.Data[0].B64JSON)
+	require.NotNil(t, resp.ImageGenerationResponseParameters)
+	assert.Equal(t, "png", resp.OutputFormat)
+}
+
+func TestToHuggingFaceImageGenerationRequest_HFInferenceParameters(t *testing.T) {
+	t.Run("parameters_nested", func(t *testing.T) {
+		body, err := ToHuggingFaceImageGenerationRequest(&schemas.BifrostImageGenerationRequest{
+			Model: "hf-inference/stabilityai/stable-diffusion-xl-base-1.0",
+			Input: &schemas.ImageGenerationInput{Prompt: "a lighthouse at dusk"},
+			Params: &schemas.ImageGenerationParameters{
+				NegativePrompt:    schemas.Ptr("blurry"),
+				NumInferenceSteps: schemas.Ptr(30),
+				Size:              schemas.Ptr("1024x768"),
+				ExtraParams:       map[string]any{"guidance_scale": 7.5, "scheduler": "DDIM"},
+			},
+		})
+		require.NoError(t, err)
+		req, ok := body.(*HuggingFaceHFInferenceImageGenerationRequest)
+		require.True(t, ok)
+		assert.Equal(t, "a lighthouse at dusk", req.Inputs)
+		require.NotNil(t, req.Parameters)
+		assert.Equal(t, "blurry", *req.Parameters.NegativePrompt)
+		assert.Equal(t, 30, *req.Parameters.NumInferenceSteps)
+		assert.Equal(t, 7.5, *req.Parameters.GuidanceScale)
+		assert.Equal(t, &HuggingFaceImageTargetSize{Width: 1024, Height: 768}, req.Parameters.TargetSize)
+		assert.Equal(t, map[string]any{"scheduler": "DDIM"}, req.ExtraParams)
+	})
+
+	t.Run("no_params", func(t *testing.T) {
+		body, err := ToHuggingFaceImageGenerationRequest(&schemas.BifrostImageGenerationRequest{
+			Model:  "hf-inference/stabilityai/stable-diffusion-xl-base-1.0",
+			Input:  &schemas.ImageGenerationInput{Prompt: "a lighthouse"},
+			Params: &schemas.ImageGenerationParameters{},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, body.(*HuggingFaceHFInferenceImageGenerationRequest).Parameters)
+	})
+
+	t.Run("invalid_size", func(t *testing.T) {
+		_, err := ToHuggingFaceImageGenerationRequest(&schemas.BifrostImageGenerationRequest{
+			Model:  "hf-inference/stabilityai/stable-diffusion-xl-base-1.0",
+			Input:  &schemas.ImageGenerationInput{Prompt: "a lighthouse"},
+			Params: &schemas.ImageGenerationParameters{Size: schemas.Ptr("large")},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	t.Run("absent", func(t *testing.T) {
+		assert.Nil(t, parseRateLimitHeaders(map[string]string{"X-Request-Id": "abc"}))
+	})
+
+	t.Run("ietf_draft", func(t *testing.T) {
+		got := parseRateLimitHeaders(map[string]string{
+			"Ratelimit":        `"api";r=999;t=60`,
+			"Ratelimit-Policy": `"fixed window";"api";q=1000;w=300`,
+		})
+		require.NotNil(t, got)
+		assert.Equal(t, int64(1000), *got.Limit)
+		assert.Equal(t, int64(999), *got.Remaining)
+		assert.Equal(t, int64(60), *got.Reset)
+	})
+
+	t.Run("unparseable_values_ignored", func(t *testing.T) {
+		got := parseRateLimitHeaders(map[string]string{
+			"X-Ratelimit-Remaining": "many",
+			"X-Ratelimit-Reset":     "5",
+		})
+		require.NotNil(t, got)
+		assert.Nil(t, got.Remaining)
+		assert.Equal(t, int64(5), *got.Reset)
+	})
+
+	t.Run("retry_after", func(t *testing.T) {
+		got := parseRateLimitHeaders(map[string]string{"Retry-After": "7"})
+		require.NotNil(t, got)
+		require.NotNil(t, got.RetryAfter)
+		assert.Equal(t, int64(7), *got.RetryAfter)
+		assert.Nil(t, got.Limit)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, int64(30), *parseRetryAfter(" 30 "))
+	assert.Equal(t, int64(0), *parseRetryAfter("-5"))
+	assert.Equal(t, int64(0), *parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"), "dates in the past mean no wait")
+
+	future := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	require.NotNil(t, future)
+	assert.InDelta(t, 60, *future, 2)
+
+	assert.Nil(t, parseRetryAfter(""))
+	assert.Nil(t, parseRetryAfter("soon"))
+}
+
+func TestResolveModelAlias_NamespacePrefixes(t *testing.T) {
+	aliases := schemas.KeyAliases{
+		"prod/llama-3":       "groq/meta-llama/Llama-3.3-70B-Instruct",
+		"staging/llama-3":    "together/meta-llama/Llama-3.3-70B-Instruct",
+		"llama-3-exact":      "cerebras/meta-llama/Llama-3.3-70B-Instruct",
+		"prod/llama-3-exact": "novita/meta-llama/Llama-3.3-70B-Instruct",
+	}
+
+	tests := []struct {
+		name     string
+		model    string
+		prefixes []string
+		want     string
+	}{
+		{name: "bare_without_prefixes_unresolved", model: "llama-3", want: "llama-3"},
+		{name: "bare_resolves_via_prefix", model: "llama-3", prefixes: []string{"prod"}, want: "groq/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "trailing_slash_prefix", model: "llama-3", prefixes: []string{"staging/"}, want: "together/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "first_matching_prefix_wins", model: "llama-3", prefixes: []string{"dev", "staging", "prod"}, want: "together/meta-llama/Llama-3.3-70B-Instruct"},
+		// Core resolved the exact alias already; its target is not resolved again
+		{name: "core_resolved_target_kept", model: "cerebras/meta-llama/Llama-3.3-70B-Instruct", prefixes: []string{"prod"}, want: "cerebras/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "trimmed_exact_match", model: " prod/llama-3 ", want: "groq/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "no_match", model: "mistral", prefixes: []string{"prod"}, want: "mistral"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveModelAlias(aliases, tt.model, tt.prefixes))
+		})
+	}
+}
+
+func TestResolveModelAlias_Patterns(t *testing.T) {
+	aliases := schemas.KeyAliases{
+		"*":             "hf-inference/meta-llama/Llama-3.1-8B-Instruct",
+		"llama-*":       "groq/meta-llama/Llama-3.3-70B-Instruct",
+		"llama-3.1-*":   "cerebras/meta-llama/Llama-3.1-8B-Instruct",
+		"llama-3.1-70b": "together/meta-llama/Llama-3.1-70B-Instruct",
+		"prod/qwen":     "novita/Qwen/Qwen2.5-72B-Instruct",
+	}
+
+	tests := []struct {
+		name     string
+		aliases  schemas.KeyAliases
+		model    string
+		prefixes []string
+		want     string
+	}{
+		// A target core resolved to is neither chained nor sent on to a pattern or the catch-all
+		{name: "core_resolved_target_kept", aliases: aliases, model: "together/meta-llama/Llama-3.1-70B-Instruct", want: "together/meta-llama/Llama-3.1-70B-Instruct"},
+		{name: "chained_alias_not_followed", aliases: schemas.KeyAliases{"a": "b", "b": "c"}, model: "b", want: "b"},
+		{name: "longest_prefix_wins", aliases: aliases, model: "llama-3.1-8b", want: "cerebras/meta-llama/Llama-3.1-8B-Instruct"},
+		{name: "shorter_prefix", aliases: aliases, model: "llama-3.3", want: "groq/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "case_insensitive", aliases: aliases, model: "LLAMA-2", want: "groq/meta-llama/Llama-3.3-70B-Instruct"},
+		{name: "namespace_beats_patterns", aliases: aliases, model: "qwen", prefixes: []string{"prod"}, want: "novita/Qwen/Qwen2.5-72B-Instruct"},
+		{name: "catch_all", aliases: aliases, model: "mistral", want: "hf-inference/meta-llama/Llama-3.1-8B-Instruct"},
+		{name: "unchanged_without_catch_all", aliases: schemas.KeyAliases{"llama-*": "groq/meta-llama/Llama-3.3-70B-Instruct"}, model: "mistral", want: "mistral"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveModelAlias(tt.aliases, tt.model, tt.prefixes))
+		})
+	}
+}
+
+func TestNewHuggingFaceProvider_KeepAliveConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{}, noopLogger{})
+		assert.Equal(t, 30*time.Second, provider.client.MaxIdleConnDuration)
+		assert.Equal(t, time.Duration(schemas.DefaultMaxConnDurationInSeconds)*time.Second, provider.client.MaxConnDuration)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		provider := NewHuggingFaceProvider(&schemas.ProviderConfig{
+			HuggingFaceConfig: &schemas.HuggingFaceConfig{
+				MaxIdleConnDurationInSeconds: 90,
+				MaxConnDurationInSeconds:     600,
+			},
+		}, noopLogger{})
+		assert.Equal(t, 90*time.Second, provider.client.MaxIdleConnDuration)
+		assert.Equal(t, 600*time.Second, provider.client.MaxConnDuration)
+		assert.Equal(t, 90*time.Second, provider.streamingClient.MaxIdleConnDuration)
+		// Streaming connections are never recycled mid-stream, so only idle tuning carries over
+		assert.Zero(t, provider.streamingClient.MaxConnDuration)
+	})
+}
+
+func TestApplyKeyInferenceProvider(t *testing.T) {
+	withPreference := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{InferenceProvider: " Together "}}
+
+	tests := []struct {
+		name     string
+		key      schemas.Key
+		model    string
+		fallback inferenceProvider
+		want     string
+	}{
+		{"bare_model_uses_key_preference", withPreference, "meta-llama/Llama-3.1-8B-Instruct", hfInference, "together/meta-llama/Llama-3.1-8B-Instruct"},
+		{"bare_model_uses_fallback", schemas.Key{}, "intfloat/e5-large", hfInference, "hf-inference/intfloat/e5-large"},
+		{"bare_model_without_fallback_unchanged", schemas.Key{}, "meta-llama/Llama-3.1-8B-Instruct", "", "meta-llama/Llama-3.1-8B-Instruct"},
+		{"explicit_provider_wins", withPreference, "groq/meta-llama/Llama-3.1-8B-Instruct", hfInference, "groq/meta-llama/Llama-3.1-8B-Instruct"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, applyKeyInferenceProvider(tt.key, tt.model, tt.fallback))
+		})
+	}
+}
+
+func TestExtractCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		nextPage string
+		want     string
+	}{
+		{
+			name: "link_next",
+			link: `<https://huggingface.co/api/models?limit=1000&inference_provider=groq&cursor=eyIkb3IiOlt7Il9pZCI6eyIkZ3QiOiI2NWQ0In19XX0%3D>; rel="next"`,
+			want: "eyIkb3IiOlt7Il9pZCI6eyIkZ3QiOiI2NWQ0In19XX0=",
+		},
+		{
+			name: "link_next_among_other_relations",
+			link: `<https://huggingface.co/api/models?cursor=prev123>; rel="prev", <https://huggingface.co/api/models?cursor=next456>; rel="next"`,
+			want: "next456",
+		},
+		{
+			name:     "link_without_next_ignores_fallback",
+			link:     `<https://huggingface.co/api/models?cursor=prev123>; rel="prev"`,
+			nextPage: "stale",
+			want:     "",
+		},
+		{
+			name:     "fallback_to_x_next_page",
+			nextPage: "page2",
+			want:     "page2",
+		},
+		{
+			name: "no_headers",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header fasthttp.ResponseHeader
+			if tt.link != "" {
+				header.Set("Link", tt.link)
+			}
+			if tt.nextPage != "" {
+				header.Set("X-Next-Page", tt.nextPage)
+			}
+			assert.Equal(t, tt.want, extractCursor(&header))
+		})
+	}
+}
+
+func TestBearerAuthHeader(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"hf_abc":           "Bearer hf_abc",
+		"Bearer hf_abc":    "Bearer hf_abc",
+		"BEARER hf_abc":    "Bearer hf_abc",
+		"  Bearer  hf_abc": "Bearer hf_abc",
+		"Bearer ":          "",
+	}
+	for token, want := range tests {
+		assert.Equal(t, want, bearerAuthHeader(token), "token %q", token)
+	}
+}
+
+func TestResolveModelAlias_TrimsWhitespace(t *testing.T) {
+	aliases := schemas.KeyAliases{"gpt2": "hf-inference/openai-community/gpt2 "}
+
+	tests := map[string]string{
+		" gpt2 ":                      "hf-inference/openai-community/gpt2",
+		"gpt2\n":                      "hf-inference/openai-community/gpt2",
+		"\tgroq/meta-llama/Llama-3 ":  "groq/meta-llama/Llama-3",
+		" hf-inference/org/model\r\n": "hf-inference/org/model",
+	}
+	for model, want := range tests {
+		assert.Equal(t, want, resolveModelAlias(aliases, model, nil), "model %q", model)
+	}
+	assert.Equal(t, "groq/org/model", resolveModelAlias(nil, " groq/org/model ", nil), "keys without aliases are trimmed too")
+}
+
+func TestDedicatedEndpointURL(t *testing.T) {
+	key := schemas.Key{
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			Endpoints: map[string]string{
+				"BAAI/bge-m3":     " https://abc123.us-east-1.aws.endpoints.huggingface.cloud/ ",
+				"acme/not-an-url": "abc123.endpoints.huggingface.cloud",
+			},
+		},
+	}
+
+	endpoint, err := dedicatedEndpointURL(key, "BAAI/bge-m3")
+	require.NoError(t, err)
+	assert.Equal(t, "https://abc123.us-east-1.aws.endpoints.huggingface.cloud", endpoint)
+
+	endpoint, err = dedicatedEndpointURL(key, "hf-inference/BAAI/bge-m3")
+	require.NoError(t, err)
+	assert.Equal(t, "https://abc123.us-east-1.aws.endpoints.huggingface.cloud", endpoint, "provider prefix is ignored")
+
+	endpoint, err = dedicatedEndpointURL(key, "BAAI/bge-small-en-v1.5")
+	require.NoError(t, err)
+	assert.Empty(t, endpoint)
+
+	_, err = dedicatedEndpointURL(key, "acme/not-an-url")
+	assert.Error(t, err)
+
+	endpoint, err = dedicatedEndpointURL(schemas.Key{}, "BAAI/bge-m3")
+	require.NoError(t, err)
+	assert.Empty(t, endpoint)
+}
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{"router", "https://router.huggingface.co", "https://router.huggingface.co"},
+		{"router_with_v1", "https://router.huggingface.co/v1", "https://router.huggingface.co"},
+		{"router_with_v1_and_trailing_slash", " https://router.huggingface.co/v1/ ", "https://router.huggingface.co"},
+		{"trailing_slashes", "https://router.huggingface.co//", "https://router.huggingface.co"},
+		{"custom_domain_keeps_prefix", "https://gateway.example.com/hf", "https://gateway.example.com/hf"},
+		{"custom_domain_with_v1", "https://gateway.example.com/hf/v1", "https://gateway.example.com/hf"},
+		{"endpoint_with_chat_route", "https://abc123.endpoints.huggingface.cloud/v1/chat/completions/", "https://abc123.endpoints.huggingface.cloud"},
+		{"endpoint_with_rerank_route", "https://abc123.endpoints.huggingface.cloud/rerank", "https://abc123.endpoints.huggingface.cloud"},
+		{"v1_inside_a_segment_is_kept", "https://gateway.example.com/apiv1", "https://gateway.example.com/apiv1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeBaseURL(tt.baseURL))
+		})
+	}
+}
+
+func TestBuildRequestURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseURL     string
+		path        string
+		requestType schemas.RequestType
+		want        string
+	}{
+		{"router_chat", "https://router.huggingface.co/v1", "/v1/chat/completions", schemas.ChatCompletionRequest, "https://router.huggingface.co/v1/chat/completions"},
+		{"router_feature_extraction", "https://router.huggingface.co/v1/", "/hf-inference/models/BAAI/bge-m3/pipeline/feature-extraction", schemas.EmbeddingRequest, "https://router.huggingface.co/hf-inference/models/BAAI/bge-m3/pipeline/feature-extraction"},
+		{"custom_domain", "https://gateway.example.com/hf/", "/hf-inference/models/BAAI/bge-m3/pipeline/feature-extraction", schemas.EmbeddingRequest, "https://gateway.example.com/hf/hf-inference/models/BAAI/bge-m3/pipeline/feature-extraction"},
+		{"endpoint_chat", "https://abc123.us-east-1.aws.endpoints.huggingface.cloud/v1", "/v1/chat/completions", schemas.ChatCompletionRequest, "https://abc123.us-east-1.aws.endpoints.huggingface.cloud/v1/chat/completions"},
+		{"endpoint_feature_extraction", "https://abc123.us-east-1.aws.endpoints.huggingface.cloud/", "/hf-inference/models/BAAI/bge-m3/pipeline/feature-extraction", schemas.EmbeddingRequest, "https://abc123.us-east-1.aws.endpoints.huggingface.cloud"},
+		{"endpoint_rerank", "https://abc123.us-east-1.aws.endpoints.huggingface.cloud/v1/", "/hf-inference/models/BAAI/bge-reranker-base/pipeline/text-ranking", schemas.RerankRequest, "https://abc123.us-east-1.aws.endpoints.huggingface.cloud/rerank"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestHuggingFaceProvider(t, tt.baseURL)
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+			assert.Equal(t, tt.want, provider.buildRequestURL(ctx, tt.path, tt.requestType))
+		})
+	}
+}
+
+func TestDedicatedEndpointURL_NormalizesRoutes(t *testing.T) {
+	key := schemas.Key{
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			Endpoints: map[string]string{"BAAI/bge-m3": "https://abc123.endpoints.huggingface.cloud/v1/"},
+		},
+	}
+
+	endpoint, err := dedicatedEndpointURL(key, "BAAI/bge-m3")
+	require.NoError(t, err)
+	assert.Equal(t, "https://abc123.endpoints.huggingface.cloud", endpoint)
+	assert.Equal(t, "https://abc123.endpoints.huggingface.cloud/v1/chat/completions", dedicatedEndpointRequestURL(endpoint, schemas.ChatCompletionStreamRequest))
+	assert.Equal(t, "https://abc123.endpoints.huggingface.cloud/rerank", dedicatedEndpointRequestURL(endpoint, schemas.RerankRequest))
+	assert.Equal(t, "https://abc123.endpoints.huggingface.cloud", dedicatedEndpointRequestURL(endpoint, schemas.EmbeddingRequest))
+}
+
+func TestParseGenerationTimingHeaders(t *testing.T) {
+	assert.Nil(t, parseGenerationTimingHeaders(nil, 10))
+	assert.Nil(t, parseGenerationTimingHeaders(map[string]string{"x-ratelimit-limit": "10"}, 10))
+
+	t.Run("tokens per second from x-generated-tokens", func(t *testing.T) {
+		timing := parseGenerationTimingHeaders(map[string]string{"x-inference-time": "250", "x-generated-tokens": "10"}, 0)
+		require.NotNil(t, timing)
+		require.NotNil(t, timing.TokensPerSecond)
+		assert.InDelta(t, 40.0, *timing.TokensPerSecond, 1e-9)
+	})
+
+	t.Run("tokens per second from time per token", func(t *testing.T) {
+		timing := parseGenerationTimingHeaders(map[string]string{"x-time-per-token": "25"}, 0)
+		require.NotNil(t, timing)
+		require.NotNil(t, timing.TokensPerSecond)
+		assert.InDelta(t, 40.0, *timing.TokensPerSecond, 1e-9)
+	})
+
+	t.Run("malformed values are skipped", func(t *testing.T) {
+		timing := parseGenerationTimingHeaders(map[string]string{"x-queue-time": "soon", "x-total-time": "-3", "x-inference-time": "8"}, 0)
+		require.NotNil(t, timing)
+		assert.Nil(t, timing.QueueTimeMs)
+		assert.Nil(t, timing.TotalTimeMs)
+		assert.Equal(t, int64(8), *timing.InferenceTimeMs)
+		assert.Nil(t, timing.TokensPerSecond)
+	})
+}
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{"default", "", defaultUserAgent},
+		{"override", "acme-search/2.0 " + defaultUserAgent, "acme-search/2.0 " + defaultUserAgent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userAgents []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userAgents = append(userAgents, r.UserAgent())
+				w.Header().Set("Content-Type", "application/json")
+				if strings.Contains(r.URL.Path, "feature-extraction") {
+					_, _ = w.Write([]byte(`[[0.1, 0.2]]`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`))
+			}))
+			defer server.Close()
+
+			const embeddingModel = "sentence-transformers/all-MiniLM-L6-v2"
+			config := &schemas.ProviderConfig{
+				NetworkConfig:     schemas.NetworkConfig{BaseURL: server.URL, DefaultRequestTimeoutInSeconds: 5},
+				HuggingFaceConfig: &schemas.HuggingFaceConfig{DisableTokenizerFetch: true, UserAgent: tt.userAgent},
+			}
+			provider := NewHuggingFaceProvider(config, noopLogger{})
+			provider.modelProviderMappingCache.Store(embeddingModel, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+				hfInference: {ProviderTask: "feature-extraction", ProviderModelID: embeddingModel},
+			})
+			ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+
+			_, bifrostErr := provider.ChatCompletion(ctx, schemas.Key{}, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+			require.Nil(t, bifrostErr)
+			_, bifrostErr = provider.Embedding(ctx, schemas.Key{}, &schemas.BifrostEmbeddingRequest{
+				Provider: schemas.HuggingFace,
+				Model:    "hf-inference/" + embeddingModel,
+				Input:    &schemas.EmbeddingInput{Text: schemas.Ptr("hello")},
+			})
+			require.Nil(t, bifrostErr)
+
+			assert.Equal(t, []string{tt.want, tt.want}, userAgents)
+		})
+	}
+}
+
+func TestExtraHeadersFromContext(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var captured http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		captured = r.Header.Clone()
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/chat/completions"):
+			_, _ = io.WriteString(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+		case r.URL.Path == "/api/models":
+			_, _ = io.WriteString(w, `[]`)
+		default:
+			_, _ = io.WriteString(w, `[[0.1,0.2]]`)
+		}
+	}))
+	defer server.Close()
+
+	const embeddingModel = "intfloat/multilingual-e5-large-instruct"
+	provider := newTestHuggingFaceProvider(t, server.URL)
+	provider.networkConfig.ExtraHeaders = map[string]string{"X-Trace-Id": "provider-trace", "X-Team": "search"}
+	provider.modelProviderMappingCache.Store(embeddingModel, map[inferenceProvider]HuggingFaceInferenceProviderMapping{
+		hfInference: {ProviderTask: "feature-extraction", ProviderModelID: embeddingModel},
+	})
+	key := schemas.Key{Value: *schemas.NewEnvVar("hf_test")}
+	newCtx := func() *schemas.BifrostContext {
+		ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+		ctx.SetValue(schemas.BifrostContextKeyExtraHeaders, map[string][]string{
+			"x-trace-id":    {"request-trace"},
+			"x-use-cache":   {"false"},
+			"authorization": {"Bearer hf_other"},
+		})
+		return ctx
+	}
+	assertHeaders := func(t *testing.T) {
+		t.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "request-trace", captured.Get("X-Trace-Id"), "per-request headers override provider ones")
+		assert.Equal(t, "search", captured.Get("X-Team"), "provider headers are the base")
+		assert.Equal(t, "false", captured.Get("X-Use-Cache"))
+		assert.Equal(t, "Bearer hf_test", captured.Get("Authorization"), "the key's token is never replaced")
+	}
+
+	t.Run("chat", func(t *testing.T) {
+		_, bifrostErr := provider.ChatCompletion(newCtx(), key, testHuggingFaceChatRequest("groq/meta-llama/Llama-3.3-70B-Instruct"))
+		require.Nil(t, bifrostErr)
+		assertHeaders(t)
+	})
+
+	t.Run("embedding", func(t *testing.T) {
+		_, bifrostErr := provider.Embedding(newCtx(), key, &schemas.BifrostEmbeddingRequest{
+			Provider: schemas.HuggingFace,
+			Model:    "hf-inference/" + embeddingModel,
+			Input:    &schemas.EmbeddingInput{Texts: []string{"hello"}},
+		})
+		require.Nil(t, bifrostErr)
+		assertHeaders(t)
+	})
+
+	t.Run("list_models", func(t *testing.T) {
+		_, bifrostErr := provider.fetchModelHubPage(newCtx(), key, server.URL+"/api/models", false)
+		require.Nil(t, bifrostErr)
+		assertHeaders(t)
+	})
+}
+
+func TestEstimatedCost(t *testing.T) {
+	key := schemas.Key{HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+		Pricing: map[string]schemas.HuggingFaceModelPrice{
+			"meta-llama/Llama-3.3-70B-Instruct":      {InputPerMillionTokens: 1, OutputPerMillionTokens: 2},
+			"groq/meta-llama/Llama-3.3-70B-Instruct": {InputPerMillionTokens: 0.5, OutputPerMillionTokens: 0.8},
+			"BAAI/bge-m3":                            {InputPerMillionTokens: 0.02},
+		},
+	}}
+	usage := &schemas.BifrostLLMUsage{PromptTokens: 2_000_000, CompletionTokens: 500_000, TotalTokens: 2_500_000}
+
+	tests := []struct {
+		name     string
+		key      schemas.Key
+		provider inferenceProvider
+		model    string
+		usage    *schemas.BifrostLLMUsage
+		want     *schemas.BifrostCost
+	}{
+		{
+			name: "provider_specific_price", key: key, provider: groq, model: "meta-llama/Llama-3.3-70B-Instruct", usage: usage,
+			want: &schemas.BifrostCost{InputTokensCost: 1, OutputTokensCost: 0.4, TotalCost: 1.4},
+		},
+		{
+			name: "model_price", key: key, provider: "together", model: "meta-llama/Llama-3.3-70B-Instruct", usage: usage,
+			want: &schemas.BifrostCost{InputTokensCost: 2, OutputTokensCost: 1, TotalCost: 3},
+		},
+		{
+			name: "input_only_price", key: key, provider: hfInference, model: "BAAI/bge-m3", usage: &schemas.BifrostLLMUsage{PromptTokens: 1_000_000, TotalTokens: 1_000_000},
+			want: &schemas.BifrostCost{InputTokensCost: 0.02, TotalCost: 0.02},
+		},
+		{name: "unpriced_model", key: key, provider: groq, model: "openai/gpt-oss-120b", usage: usage, want: nil},
+		{name: "no_usage", key: key, provider: groq, model: "meta-llama/Llama-3.3-70B-Instruct", usage: nil, want: nil},
+		{name: "no_price_table", key: schemas.Key{}, provider: groq, model: "meta-llama/Llama-3.3-70B-Instruct", usage: usage, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimatedCost(tt.key, tt.provider, tt.model, tt.usage)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.InDelta(t, tt.want.InputTokensCost, got.InputTokensCost, 1e-9)
+			assert.InDelta(t, tt.want.OutputTokensCost, got.OutputTokensCost, 1e-9)
+			assert.InDelta(t, tt.want.TotalCost, got.TotalCost, 1e-9)
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, "req-123")
+	assert.Equal(t, "req-123", requestID(ctx))
+
+	// Without one, an ID is generated once and reused for the rest of the call
+	ctx = schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	generated := requestID(ctx)
+	require.NotEmpty(t, generated)
+	assert.Equal(t, generated, requestID(ctx))
+}
+
+func TestSetRequestIDHeader(t *testing.T) {
+	t.Parallel()
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, "req-123")
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	setRequestIDHeader(ctx, req)
+	assert.Equal(t, "req-123", string(req.Header.Peek("X-Request-Id")))
+
+	// An ID the caller sent through extra headers is kept
+	req.Header.Set("X-Request-Id", "caller-id")
+	setRequestIDHeader(ctx, req)
+	assert.Equal(t, "caller-id", string(req.Header.Peek("X-Request-Id")))
+}
+
+func TestWithRequestID(t *testing.T) {
+	t.Parallel()
+
+	ctx := schemas.NewBifrostContext(context.Background(), schemas.NoDeadline)
+	ctx.SetValue(schemas.BifrostContextKeyRequestID, "req-123")
+	assert.Equal(t, `huggingface: retrying request_id="req-123"`, withRequestID(ctx, "huggingface: retrying", nil))
+
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	assert.Equal(t, `huggingface: retrying request_id="req-123"`, withRequestID(ctx, "huggingface: retrying", resp))
+	resp.Header.Set("X-Request-Id", "Root=1-abc")
+	assert.Equal(t, `huggingface: retrying request_id="req-123" upstream_request_id="Root=1-abc"`, withRequestID(ctx, "huggingface: retrying", resp))
+}
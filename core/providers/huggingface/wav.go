@@ -0,0 +1,87 @@
+package huggingface
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// wavFormatPCM is the WAVE_FORMAT_PCM tag of an uncompressed "fmt " chunk.
+const wavFormatPCM = 1
+
+// pcmWAV is an uncompressed WAV file split into its format description and sample data, which
+// is all that is needed to cut it into shorter, independently playable WAV files.
+type pcmWAV struct {
+	format     []byte // body of the "fmt " chunk, copied verbatim into every window
+	byteRate   int
+	blockAlign int
+	data       []byte
+}
+
+// parsePCMWAV reads a RIFF/WAVE file with PCM samples. Returns false for anything else
+// (compressed WAV, other containers, truncated headers), which cannot be cut without decoding.
+func parsePCMWAV(audio []byte) (*pcmWAV, bool) {
+	if len(audio) < 12 || !bytes.Equal(audio[0:4], []byte("RIFF")) || !bytes.Equal(audio[8:12], []byte("WAVE")) {
+		return nil, false
+	}
+
+	var wav pcmWAV
+	for offset := 12; offset+8 <= len(audio); {
+		chunkID := string(audio[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(audio[offset+4 : offset+8]))
+		body := audio[offset+8:]
+		if chunkSize > len(body) {
+			// Streamed WAVs often leave the data size unset; take whatever follows
+			if chunkID != "data" {
+				return nil, false
+			}
+			chunkSize = len(body)
+		}
+		body = body[:chunkSize]
+
+		switch chunkID {
+		case "fmt ":
+			if len(body) < 16 || binary.LittleEndian.Uint16(body[0:2]) != wavFormatPCM {
+				return nil, false
+			}
+			wav.format = body
+			wav.byteRate = int(binary.LittleEndian.Uint32(body[8:12]))
+			wav.blockAlign = int(binary.LittleEndian.Uint16(body[12:14]))
+		case "data":
+			wav.data = body
+		}
+		// Chunks are padded to an even size
+		offset += 8 + chunkSize + chunkSize%2
+	}
+
+	if wav.format == nil || wav.data == nil || wav.byteRate <= 0 || wav.blockAlign <= 0 {
+		return nil, false
+	}
+	return &wav, true
+}
+
+// duration returns the length of the audio in seconds.
+func (wav *pcmWAV) duration() float64 {
+	return float64(len(wav.data)) / float64(wav.byteRate)
+}
+
+// window returns the audio between start and end (in seconds) as a standalone WAV file.
+func (wav *pcmWAV) window(start, end float64) []byte {
+	offset := func(seconds float64) int {
+		position := int(seconds*float64(wav.byteRate)) / wav.blockAlign * wav.blockAlign
+		return min(max(position, 0), len(wav.data))
+	}
+	samples := wav.data[offset(start):offset(end)]
+
+	var out bytes.Buffer
+	out.Grow(20 + len(wav.format) + 8 + len(samples))
+	out.WriteString("RIFF")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(4+8+len(wav.format)+8+len(samples)))
+	out.WriteString("WAVE")
+	out.WriteString("fmt ")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(len(wav.format)))
+	out.Write(wav.format)
+	out.WriteString("data")
+	_ = binary.Write(&out, binary.LittleEndian, uint32(len(samples)))
+	out.Write(samples)
+	return out.Bytes()
+}
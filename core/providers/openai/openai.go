@@ -1113,6 +1113,10 @@ func HandleOpenAIChatCompletionStreaming(
 		var modelName string
 		var created int
 		forwardedTerminalFinishReason := false
+		// Some providers stream usage in a chunk of its own after the finish_reason one
+		awaitingUsage := providerUtils.ProviderSendsUsageAfterFinishReason(providerName) &&
+			request.Params != nil && request.Params.StreamOptions != nil &&
+			request.Params.StreamOptions.IncludeUsage != nil && *request.Params.StreamOptions.IncludeUsage
 
 		for {
 			// If context was cancelled/timed out, let defer handle it
@@ -1309,8 +1313,9 @@ func HandleOpenAIChatCompletionStreaming(
 					providerUtils.ProcessAndSendResponse(ctx, postHookRunner, providerUtils.GetBifrostResponseForStreamResponse(nil, &response, nil, nil, nil, nil), responseChan, postHookSpanFinalizer)
 				}
 
-				// For providers that don't send [DONE] marker break on finish_reason
-				if !providerUtils.ProviderSendsDoneMarker(providerName) && finishReason != nil {
+				// For providers that don't send [DONE] marker break on finish_reason, unless the
+				// provider sends usage after it and the requested usage has yet to arrive
+				if !providerUtils.ProviderSendsDoneMarker(providerName) && finishReason != nil && (!awaitingUsage || usage.TotalTokens > 0) {
 					break
 				}
 			}
@@ -1793,6 +1793,7 @@ func BuildClientStreamChunk(ctx context.Context, processedResponse *schemas.Bifr
 		streamResponse.BifrostSpeechStreamResponse = processedResponse.SpeechStreamResponse
 		streamResponse.BifrostTranscriptionStreamResponse = processedResponse.TranscriptionStreamResponse
 		streamResponse.BifrostImageGenerationStreamResponse = processedResponse.ImageGenerationStreamResponse
+		streamResponse.BifrostEmbeddingResponse = processedResponse.EmbeddingResponse
 		// Strip raw fields from client-facing copies without mutating the shared objects
 		// that PostLLMHook goroutines may still be reading.
 		if drop {
@@ -1856,6 +1857,16 @@ func BuildClientStreamChunk(ctx context.Context, processedResponse *schemas.Bifr
 				}
 				streamResponse.BifrostImageGenerationStreamResponse = &cp
 			}
+			if streamResponse.BifrostEmbeddingResponse != nil {
+				cp := *streamResponse.BifrostEmbeddingResponse
+				if dropReq {
+					cp.ExtraFields.RawRequest = nil
+				}
+				if dropResp {
+					cp.ExtraFields.RawResponse = nil
+				}
+				streamResponse.BifrostEmbeddingResponse = &cp
+			}
 		}
 	}
 	if processedError != nil {
@@ -2309,6 +2320,19 @@ func ProviderSendsDoneMarker(providerName schemas.ModelProvider) bool {
 	}
 }
 
+// ProviderSendsUsageAfterFinishReason returns true if the provider streams usage in a chunk of its
+// own after the finish_reason chunk when the request asks for it. Handlers of providers that
+// don't send [DONE] keep reading past finish_reason for such providers until that usage arrives.
+func ProviderSendsUsageAfterFinishReason(providerName schemas.ModelProvider) bool {
+	switch providerName {
+	case schemas.HuggingFace:
+		// HuggingFace's router backends send the usage-only chunk after the finish_reason one
+		return true
+	default:
+		return false
+	}
+}
+
 func ProviderIsResponsesAPINative(providerName schemas.ModelProvider) bool {
 	switch providerName {
 	case schemas.OpenAI, schemas.OpenRouter, schemas.Azure:
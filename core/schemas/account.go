@@ -120,25 +120,26 @@ func (bl BlackList) Validate() error {
 // Key represents an API key and its associated configuration for a provider.
 // It contains the key value, supported models, and a weight for load balancing.
 type Key struct {
-	ID                 string              `json:"id"`                             // The unique identifier for the key (used by bifrost to identify the key)
-	Name               string              `json:"name"`                           // The name of the key (used by users to identify the key, not used by bifrost)
-	Value              EnvVar              `json:"value"`                          // The actual API key value
-	Models             WhiteList           `json:"models"`                         // List of models this key can access
-	BlacklistedModels  BlackList           `json:"blacklisted_models"`             // List of models this key cannot access
-	Weight             float64             `json:"weight"`                         // Weight for load balancing between multiple keys
-	Aliases            KeyAliases          `json:"aliases,omitempty"`              // Mapping of model identifiers to inference profiles
-	AzureKeyConfig     *AzureKeyConfig     `json:"azure_key_config,omitempty"`     // Azure-specific key configuration
-	VertexKeyConfig    *VertexKeyConfig    `json:"vertex_key_config,omitempty"`    // Vertex-specific key configuration
-	BedrockKeyConfig   *BedrockKeyConfig   `json:"bedrock_key_config,omitempty"`   // AWS Bedrock-specific key configuration
-	VLLMKeyConfig      *VLLMKeyConfig      `json:"vllm_key_config,omitempty"`      // vLLM-specific key configuration
-	ReplicateKeyConfig *ReplicateKeyConfig `json:"replicate_key_config,omitempty"` // Replicate-specific key configuration
-	OllamaKeyConfig    *OllamaKeyConfig    `json:"ollama_key_config,omitempty"`    // Ollama-specific key configuration
-	SGLKeyConfig       *SGLKeyConfig       `json:"sgl_key_config,omitempty"`       // SGLang-specific key configuration
-	Enabled            *bool               `json:"enabled,omitempty"`              // Whether the key is active (default:true)
-	UseForBatchAPI     *bool               `json:"use_for_batch_api,omitempty"`    // Whether this key can be used for batch API operations (default:false for new keys, migrated keys default to true)
-	ConfigHash         string              `json:"config_hash,omitempty"`          // Hash of config.json version, used for change detection
-	Status             KeyStatusType       `json:"status,omitempty"`               // Status of key
-	Description        string              `json:"description,omitempty"`          // Description of key
+	ID                   string                `json:"id"`                               // The unique identifier for the key (used by bifrost to identify the key)
+	Name                 string                `json:"name"`                             // The name of the key (used by users to identify the key, not used by bifrost)
+	Value                EnvVar                `json:"value"`                            // The actual API key value
+	Models               WhiteList             `json:"models"`                           // List of models this key can access
+	BlacklistedModels    BlackList             `json:"blacklisted_models"`               // List of models this key cannot access
+	Weight               float64               `json:"weight"`                           // Weight for load balancing between multiple keys
+	Aliases              KeyAliases            `json:"aliases,omitempty"`                // Mapping of model identifiers to inference profiles
+	AzureKeyConfig       *AzureKeyConfig       `json:"azure_key_config,omitempty"`       // Azure-specific key configuration
+	VertexKeyConfig      *VertexKeyConfig      `json:"vertex_key_config,omitempty"`      // Vertex-specific key configuration
+	BedrockKeyConfig     *BedrockKeyConfig     `json:"bedrock_key_config,omitempty"`     // AWS Bedrock-specific key configuration
+	VLLMKeyConfig        *VLLMKeyConfig        `json:"vllm_key_config,omitempty"`        // vLLM-specific key configuration
+	ReplicateKeyConfig   *ReplicateKeyConfig   `json:"replicate_key_config,omitempty"`   // Replicate-specific key configuration
+	OllamaKeyConfig      *OllamaKeyConfig      `json:"ollama_key_config,omitempty"`      // Ollama-specific key configuration
+	SGLKeyConfig         *SGLKeyConfig         `json:"sgl_key_config,omitempty"`         // SGLang-specific key configuration
+	HuggingFaceKeyConfig *HuggingFaceKeyConfig `json:"huggingface_key_config,omitempty"` // HuggingFace-specific key configuration
+	Enabled              *bool                 `json:"enabled,omitempty"`                // Whether the key is active (default:true)
+	UseForBatchAPI       *bool                 `json:"use_for_batch_api,omitempty"`      // Whether this key can be used for batch API operations (default:false for new keys, migrated keys default to true)
+	ConfigHash           string                `json:"config_hash,omitempty"`            // Hash of config.json version, used for change detection
+	Status               KeyStatusType         `json:"status,omitempty"`                 // Status of key
+	Description          string                `json:"description,omitempty"`            // Description of key
 }
 
 type KeyAliases map[string]string
@@ -266,6 +267,27 @@ type OllamaKeyConfig struct {
 	URL EnvVar `json:"url"` // Ollama server base URL (required, supports env. prefix)
 }
 
+// HuggingFaceKeyConfig represents the HuggingFace-specific key configuration.
+// It lets each key prefer a backend inference provider on the HF router for model IDs
+// that don't name one (e.g. "meta-llama/Llama-3.1-8B-Instruct" rather than "together/meta-llama/..."),
+// send chosen models to Dedicated Inference Endpoints instead of the router, cap how many
+// requests a small deployment sees at once, and reach HF through its own proxy when keys
+// live in different network zones.
+type HuggingFaceKeyConfig struct {
+	InferenceProvider string            `json:"inference_provider,omitempty"` // Preferred backend, e.g. "together", "fireworks-ai", "sambanova" (default: "hf-inference")
+	Endpoints         map[string]string `json:"endpoints,omitempty"`          // Dedicated Inference Endpoint URL per "{org}/{model}" ID, e.g. "https://abc123.us-east-1.aws.endpoints.huggingface.cloud" (chat, embedding and rerank only)
+	ProxyConfig       *ProxyConfig      `json:"proxy_config,omitempty"`       // Proxy for this key's requests, overriding the provider's (type "none" bypasses it)
+	MaxConcurrency    map[string]int    `json:"max_concurrency,omitempty"`    // Most in-flight chat and embedding requests per "{org}/{model}" ID (or its dedicated endpoint); excess requests queue until a slot frees or their context ends
+
+	Pricing map[string]HuggingFaceModelPrice `json:"pricing,omitempty"` // Token prices per "{inference_provider}/{org}/{model}" or "{org}/{model}" ID, used to estimate the cost of chat and embedding responses; unpriced models get no estimate
+}
+
+// HuggingFaceModelPrice is what a model costs through this key, in USD per million tokens.
+type HuggingFaceModelPrice struct {
+	InputPerMillionTokens  float64 `json:"input_per_million_tokens"`
+	OutputPerMillionTokens float64 `json:"output_per_million_tokens,omitempty"`
+}
+
 // SGLKeyConfig represents the SGLang-specific key configuration.
 // It allows each key to target a different SGLang server URL,
 // enabling per-key routing and round-robin load balancing across multiple SGLang instances.
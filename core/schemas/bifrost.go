@@ -193,6 +193,7 @@ const (
 	BifrostContextKeyGovernanceIncludeOnlyKeys           BifrostContextKey = "bf-governance-include-only-keys"       // []string (to store the include-only key IDs for provider config routing (set by bifrost governance plugin - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyNumberOfRetries                     BifrostContextKey = "bifrost-number-of-retries"             // int (to store the number of retries (set by bifrost - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyFallbackIndex                       BifrostContextKey = "bifrost-fallback-index"                // int (to store the fallback index (set by bifrost - DO NOT SET THIS MANUALLY)) 0 for primary, 1 for first fallback, etc.
+	BifrostContextKeyOriginalModelRequested              BifrostContextKey = "bifrost-original-model-requested"      // string (the model as requested, before the key's aliases are resolved (set by bifrost - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyStreamEndIndicator                  BifrostContextKey = "bifrost-stream-end-indicator"          // bool (set by bifrost - DO NOT SET THIS MANUALLY))
 	BifrostContextKeyStreamIdleTimeout                   BifrostContextKey = "bifrost-stream-idle-timeout"           // time.Duration (per-chunk idle timeout for streaming)
 	BifrostContextKeySkipKeySelection                    BifrostContextKey = "bifrost-skip-key-selection"            // bool (will pass an empty key to the provider)
@@ -1097,6 +1098,42 @@ type BifrostResponseExtraFields struct {
 	ConvertedRequestType      RequestType        `json:"converted_request_type,omitempty"`
 	DroppedCompatPluginParams []string           `json:"dropped_compat_plugin_params,omitempty"` // params dropped by the compat plugin based on model catalog
 	ProviderResponseHeaders   map[string]string  `json:"provider_response_headers,omitempty"`    // HTTP response headers from the provider (filtered to exclude transport-level headers)
+	RateLimit                 *ProviderRateLimit `json:"rate_limit,omitempty"`                   // rate-limit state parsed from provider response headers, when advertised
+	GenerationTiming          *GenerationTiming  `json:"generation_timing,omitempty"`            // server-side timing breakdown of the generation, when the provider reports one
+	UsageAccuracy             UsageAccuracy      `json:"usage_accuracy,omitempty"`               // whether Usage was reported by the provider or estimated by Bifrost
+	ModelHubURL               string             `json:"model_hub_url,omitempty"`                // public model page of the resolved model, for providers backed by a model hub
+	EffectiveConfig           interface{}        `json:"effective_config,omitempty"`             // provider-specific view of how the request was processed (opt-in, for debugging)
+	EmbeddingDType            string             `json:"embedding_dtype,omitempty"`              // numeric type the provider returned embeddings in (e.g. "float32", "int8"), before any dequantization
+	FallbackModelUsed         string             `json:"fallback_model_used,omitempty"`          // configured fallback model that served the request after the requested model failed
+	EstimatedCost             *BifrostCost       `json:"estimated_cost,omitempty"`               // cost computed by the provider from token usage and a configured price table, when the model has a price
+}
+
+// UsageAccuracy labels where the token counts in a response's Usage came from.
+type UsageAccuracy string
+
+const (
+	UsageAccuracyExact     UsageAccuracy = "exact"     // counts reported by the provider
+	UsageAccuracyEstimated UsageAccuracy = "estimated" // counts approximated by Bifrost because the provider reported none
+)
+
+// ProviderRateLimit captures the rate-limit state a provider advertises in its response headers,
+// so callers can throttle client-side before hitting 429s.
+type ProviderRateLimit struct {
+	Limit      *int64 `json:"limit,omitempty"`       // requests allowed in the current window
+	Remaining  *int64 `json:"remaining,omitempty"`   // requests left in the current window
+	Reset      *int64 `json:"reset,omitempty"`       // seconds until the current window resets
+	RetryAfter *int64 `json:"retry_after,omitempty"` // seconds to wait before retrying, from a Retry-After header (sent with 429s)
+}
+
+// GenerationTiming is the server-side time breakdown a provider reports for one generation, for
+// per-request performance analysis. Times are in milliseconds.
+type GenerationTiming struct {
+	QueueTimeMs      *int64   `json:"queue_time_ms,omitempty"`      // waiting for a free slot on the server
+	ValidationTimeMs *int64   `json:"validation_time_ms,omitempty"` // validating and tokenizing the input
+	InferenceTimeMs  *int64   `json:"inference_time_ms,omitempty"`  // running the model
+	TotalTimeMs      *int64   `json:"total_time_ms,omitempty"`      // end to end on the server
+	TimePerTokenMs   *int64   `json:"time_per_token_ms,omitempty"`  // average inference time per generated token
+	TokensPerSecond  *float64 `json:"tokens_per_second,omitempty"`  // generated tokens over inference time
 }
 
 type BifrostMCPResponseExtraFields struct {
@@ -1139,6 +1176,7 @@ type BifrostStreamChunk struct {
 	*BifrostSpeechStreamResponse
 	*BifrostTranscriptionStreamResponse
 	*BifrostImageGenerationStreamResponse
+	*BifrostEmbeddingResponse // one chunk of a streamed embedding batch
 	*BifrostPassthroughResponse
 	*BifrostError
 }
@@ -1158,6 +1196,8 @@ func (bs BifrostStreamChunk) MarshalJSON() ([]byte, error) {
 		return MarshalSorted(bs.BifrostTranscriptionStreamResponse)
 	} else if bs.BifrostImageGenerationStreamResponse != nil {
 		return MarshalSorted(bs.BifrostImageGenerationStreamResponse)
+	} else if bs.BifrostEmbeddingResponse != nil {
+		return MarshalSorted(bs.BifrostEmbeddingResponse)
 	} else if bs.BifrostPassthroughResponse != nil {
 		return MarshalSorted(bs.BifrostPassthroughResponse)
 	} else if bs.BifrostError != nil {
@@ -1284,4 +1324,5 @@ type BifrostErrorExtraFields struct {
 	DroppedCompatPluginParams []string                   `json:"dropped_compat_plugin_params,omitempty"`
 	KeyStatuses               []KeyStatus                `json:"key_statuses,omitempty"`
 	MCPAuthRequired           *MCPUserOAuthRequiredError `json:"mcp_auth_required,omitempty"` // Set when a per-user OAuth MCP tool requires authentication
+	RateLimit                 *ProviderRateLimit         `json:"rate_limit,omitempty"`        // rate-limit state from the error response headers, including Retry-After on 429s
 }
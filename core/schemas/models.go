@@ -150,7 +150,14 @@ type Model struct {
 	SupportedParameters []string           `json:"supported_parameters,omitempty"`
 	DefaultParameters   *DefaultParameters `json:"default_parameters,omitempty"`
 	HuggingFaceID       *string            `json:"hugging_face_id,omitempty"`
+	Likes               *int               `json:"likes,omitempty"`               // Popularity on the provider's model hub, when it reports one
+	Downloads           *int               `json:"downloads,omitempty"`           // Recent download count on the provider's model hub, when it reports one
+	InferenceProviders  []string           `json:"inference_providers,omitempty"` // Backends serving this model when a router provider collapses duplicates
 	Description         *string            `json:"description,omitempty"`
+	License             *string            `json:"license,omitempty"`      // License identifier(s) declared by the model author, e.g. "apache-2.0"
+	LibraryName         *string            `json:"library_name,omitempty"` // Library the weights are packaged for on the provider's model hub, e.g. "transformers"
+	Gated               *bool              `json:"gated,omitempty"`        // Whether the model hub requires accepting the model's terms before it can be used
+	Accessible          *bool              `json:"accessible,omitempty"`   // Whether the listing key can run a gated model, when the provider was asked to check
 
 	OwnedBy          *string  `json:"owned_by,omitempty"`
 	SupportedMethods []string `json:"supported_methods,omitempty"`
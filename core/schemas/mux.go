@@ -1025,6 +1025,7 @@ func (brr *BifrostResponsesRequest) ToChatRequest() *BifrostChatRequest {
 			Temperature:       brr.Params.Temperature,
 			TopLogProbs:       brr.Params.TopLogProbs,
 			TopP:              brr.Params.TopP,
+			User:              brr.Params.User,
 			ExtraParams:       brr.Params.ExtraParams,
 
 			// Map specific fields
@@ -2255,6 +2256,11 @@ func (cr *BifrostChatResponse) ToBifrostTextCompletionResponse() *BifrostTextCom
 		return nil
 	}
 
+	// Keep every extra field (rate limits, usage accuracy, hub URL, ...) on streamed and
+	// non-streamed responses alike
+	extraFields := cr.ExtraFields
+	extraFields.RequestType = TextCompletionRequest
+
 	if len(cr.Choices) == 0 {
 		return &BifrostTextCompletionResponse{
 			ID:                cr.ID,
@@ -2262,16 +2268,7 @@ func (cr *BifrostChatResponse) ToBifrostTextCompletionResponse() *BifrostTextCom
 			Object:            "text_completion",
 			SystemFingerprint: cr.SystemFingerprint,
 			Usage:             cr.Usage,
-			ExtraFields: BifrostResponseExtraFields{
-				RequestType:             TextCompletionRequest,
-				ChunkIndex:              cr.ExtraFields.ChunkIndex,
-				Provider:                cr.ExtraFields.Provider,
-				OriginalModelRequested:  cr.ExtraFields.OriginalModelRequested,
-				Latency:                 cr.ExtraFields.Latency,
-				RawResponse:             cr.ExtraFields.RawResponse,
-				CacheDebug:              cr.ExtraFields.CacheDebug,
-				ProviderResponseHeaders: cr.ExtraFields.ProviderResponseHeaders,
-			},
+			ExtraFields:       extraFields,
 		}
 	}
 
@@ -2286,25 +2283,16 @@ func (cr *BifrostChatResponse) ToBifrostTextCompletionResponse() *BifrostTextCom
 			SystemFingerprint: cr.SystemFingerprint,
 			Choices: []BifrostResponseChoice{
 				{
-					Index: 0,
+					Index: choice.Index,
 					TextCompletionResponseChoice: &TextCompletionResponseChoice{
 						Text: choice.ChatStreamResponseChoice.Delta.Content,
 					},
 					FinishReason: choice.FinishReason,
-					LogProbs:     choice.LogProbs,
+					LogProbs:     toTextCompletionLogProbs(choice.LogProbs),
 				},
 			},
-			Usage: cr.Usage,
-			ExtraFields: BifrostResponseExtraFields{
-				RequestType:             TextCompletionRequest,
-				ChunkIndex:              cr.ExtraFields.ChunkIndex,
-				Provider:                cr.ExtraFields.Provider,
-				OriginalModelRequested:  cr.ExtraFields.OriginalModelRequested,
-				Latency:                 cr.ExtraFields.Latency,
-				RawResponse:             cr.ExtraFields.RawResponse,
-				CacheDebug:              cr.ExtraFields.CacheDebug,
-				ProviderResponseHeaders: cr.ExtraFields.ProviderResponseHeaders,
-			},
+			Usage:       cr.Usage,
+			ExtraFields: extraFields,
 		}
 	}
 
@@ -2335,25 +2323,16 @@ func (cr *BifrostChatResponse) ToBifrostTextCompletionResponse() *BifrostTextCom
 			SystemFingerprint: cr.SystemFingerprint,
 			Choices: []BifrostResponseChoice{
 				{
-					Index: 0,
+					Index: choice.Index,
 					TextCompletionResponseChoice: &TextCompletionResponseChoice{
 						Text: textContent,
 					},
 					FinishReason: choice.FinishReason,
-					LogProbs:     choice.LogProbs,
+					LogProbs:     toTextCompletionLogProbs(choice.LogProbs),
 				},
 			},
-			Usage: cr.Usage,
-			ExtraFields: BifrostResponseExtraFields{
-				RequestType:             TextCompletionRequest,
-				ChunkIndex:              cr.ExtraFields.ChunkIndex,
-				Provider:                cr.ExtraFields.Provider,
-				OriginalModelRequested:  cr.ExtraFields.OriginalModelRequested,
-				Latency:                 cr.ExtraFields.Latency,
-				RawResponse:             cr.ExtraFields.RawResponse,
-				CacheDebug:              cr.ExtraFields.CacheDebug,
-				ProviderResponseHeaders: cr.ExtraFields.ProviderResponseHeaders,
-			},
+			Usage:       cr.Usage,
+			ExtraFields: extraFields,
 		}
 	}
 
@@ -2364,15 +2343,36 @@ func (cr *BifrostChatResponse) ToBifrostTextCompletionResponse() *BifrostTextCom
 		Object:            "text_completion",
 		SystemFingerprint: cr.SystemFingerprint,
 		Usage:             cr.Usage,
-		ExtraFields: BifrostResponseExtraFields{
-			RequestType:             TextCompletionRequest,
-			ChunkIndex:              cr.ExtraFields.ChunkIndex,
-			Provider:                cr.ExtraFields.Provider,
-			OriginalModelRequested:  cr.ExtraFields.OriginalModelRequested,
-			Latency:                 cr.ExtraFields.Latency,
-			RawResponse:             cr.ExtraFields.RawResponse,
-			CacheDebug:              cr.ExtraFields.CacheDebug,
-			ProviderResponseHeaders: cr.ExtraFields.ProviderResponseHeaders,
-		},
+		ExtraFields:       extraFields,
+	}
+}
+
+// toTextCompletionLogProbs reshapes chat-style per-token logprobs into the legacy
+// text completion layout (tokens, token_logprobs, top_logprobs, text_offset).
+func toTextCompletionLogProbs(logProbs *BifrostLogProbs) *BifrostLogProbs {
+	if logProbs == nil || logProbs.TextCompletionLogProb != nil || len(logProbs.Content) == 0 {
+		return logProbs
+	}
+
+	textLogProbs := &TextCompletionLogProb{
+		TextOffset:    make([]int, 0, len(logProbs.Content)),
+		TokenLogProbs: make([]float64, 0, len(logProbs.Content)),
+		Tokens:        make([]string, 0, len(logProbs.Content)),
+		TopLogProbs:   make([]map[string]float64, 0, len(logProbs.Content)),
 	}
+	offset := 0
+	for _, content := range logProbs.Content {
+		textLogProbs.TextOffset = append(textLogProbs.TextOffset, offset)
+		textLogProbs.TokenLogProbs = append(textLogProbs.TokenLogProbs, content.LogProb)
+		textLogProbs.Tokens = append(textLogProbs.Tokens, content.Token)
+		offset += len(content.Token)
+
+		topLogProbs := make(map[string]float64, len(content.TopLogProbs))
+		for _, top := range content.TopLogProbs {
+			topLogProbs[top.Token] = top.LogProb
+		}
+		textLogProbs.TopLogProbs = append(textLogProbs.TopLogProbs, topLogProbs)
+	}
+
+	return &BifrostLogProbs{TextCompletionLogProb: textLogProbs}
 }
@@ -700,3 +700,93 @@ func TestToBifrostResponsesStreamResponse_MapsLengthToIncompleteEvent(t *testing
 		t.Fatal("expected incomplete_details.reason to be max_output_tokens")
 	}
 }
+
+func TestToBifrostChatRequest_MapsTextCompletionLogProbs(t *testing.T) {
+	req := &BifrostTextCompletionRequest{
+		Model:  "m",
+		Input:  &TextCompletionInput{PromptStr: Ptr("hi")},
+		Params: &TextCompletionParameters{LogProbs: Ptr(0)},
+	}
+	chatReq := req.ToBifrostChatRequest()
+	if chatReq.Params.LogProbs == nil || !*chatReq.Params.LogProbs {
+		t.Fatalf("expected logprobs to be requested")
+	}
+	if chatReq.Params.TopLogProbs != nil {
+		t.Fatalf("expected no top_logprobs for logprobs=0, got %d", *chatReq.Params.TopLogProbs)
+	}
+
+	req.Params.LogProbs = Ptr(3)
+	chatReq = req.ToBifrostChatRequest()
+	if chatReq.Params.TopLogProbs == nil || *chatReq.Params.TopLogProbs != 3 {
+		t.Fatalf("expected top_logprobs 3, got %v", chatReq.Params.TopLogProbs)
+	}
+}
+
+func TestToBifrostTextCompletionResponse_KeepsTextLogProbs(t *testing.T) {
+	textLogProbs := &BifrostLogProbs{TextCompletionLogProb: &TextCompletionLogProb{Tokens: []string{"a"}}}
+	if got := toTextCompletionLogProbs(textLogProbs); got != textLogProbs {
+		t.Fatalf("expected text completion logprobs to pass through unchanged")
+	}
+	if got := toTextCompletionLogProbs(nil); got != nil {
+		t.Fatalf("expected nil logprobs to stay nil")
+	}
+}
+
+func TestToBifrostTextCompletionResponse_StreamMatchesNonStream(t *testing.T) {
+	extraFields := BifrostResponseExtraFields{
+		RequestType:            ChatCompletionRequest,
+		Provider:               HuggingFace,
+		OriginalModelRequested: "fast",
+		ResolvedModelUsed:      "groq/meta-llama/Llama-3.3-70B-Instruct",
+		ChunkIndex:             3,
+		RateLimit:              &ProviderRateLimit{Remaining: Ptr(int64(9))},
+		UsageAccuracy:          UsageAccuracyEstimated,
+		ModelHubURL:            "https://huggingface.co/meta-llama/Llama-3.3-70B-Instruct",
+		FallbackModelUsed:      "fallback",
+	}
+	usage := &BifrostLLMUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}
+
+	nonStream := (&BifrostChatResponse{
+		ID:                "1",
+		Model:             "m",
+		SystemFingerprint: "fp_1",
+		Usage:             usage,
+		ExtraFields:       extraFields,
+		Choices: []BifrostResponseChoice{{
+			Index:        1,
+			FinishReason: Ptr("stop"),
+			ChatNonStreamResponseChoice: &ChatNonStreamResponseChoice{
+				Message: &ChatMessage{Role: ChatMessageRoleAssistant, Content: &ChatMessageContent{ContentStr: Ptr("hi")}},
+			},
+		}},
+	}).ToBifrostTextCompletionResponse()
+	stream := (&BifrostChatResponse{
+		ID:                "1",
+		Model:             "m",
+		SystemFingerprint: "fp_1",
+		Usage:             usage,
+		ExtraFields:       extraFields,
+		Choices: []BifrostResponseChoice{{
+			Index:                    1,
+			FinishReason:             Ptr("stop"),
+			ChatStreamResponseChoice: &ChatStreamResponseChoice{Delta: &ChatStreamResponseChoiceDelta{Content: Ptr("hi")}},
+		}},
+	}).ToBifrostTextCompletionResponse()
+
+	for name, got := range map[string]*BifrostTextCompletionResponse{"non-stream": nonStream, "stream": stream} {
+		if got.SystemFingerprint != "fp_1" || got.ID != "1" || got.Model != "m" || got.Object != "text_completion" || got.Usage != usage {
+			t.Fatalf("%s: top-level fields not preserved: %+v", name, got)
+		}
+		if len(got.Choices) != 1 || got.Choices[0].Index != 1 {
+			t.Fatalf("%s: expected the choice index to be preserved, got %+v", name, got.Choices)
+		}
+		fields := got.ExtraFields
+		if fields.RequestType != TextCompletionRequest {
+			t.Fatalf("%s: expected request type %q, got %q", name, TextCompletionRequest, fields.RequestType)
+		}
+		if fields.ResolvedModelUsed != extraFields.ResolvedModelUsed || fields.ChunkIndex != 3 || fields.RateLimit != extraFields.RateLimit ||
+			fields.UsageAccuracy != UsageAccuracyEstimated || fields.ModelHubURL != extraFields.ModelHubURL || fields.FallbackModelUsed != "fallback" {
+			t.Fatalf("%s: extra fields not preserved: %+v", name, fields)
+		}
+	}
+}
@@ -443,6 +443,7 @@ type ProviderConfig struct {
 	StoreRawRequestResponse bool                  `json:"store_raw_request_response"` // Capture raw request/response for internal logging only; strip from API responses returned to clients (default: false)
 	CustomProviderConfig    *CustomProviderConfig `json:"custom_provider_config,omitempty"`
 	OpenAIConfig            *OpenAIConfig         `json:"openai_config,omitempty"`
+	HuggingFaceConfig       *HuggingFaceConfig    `json:"huggingface_config,omitempty"`
 }
 
 // OpenAIConfig holds OpenAI-specific provider configuration.
@@ -450,6 +451,69 @@ type OpenAIConfig struct {
 	DisableStore bool `json:"disable_store"` // When true, forces store=false on all outgoing OpenAI requests (default: false)
 }
 
+// HuggingFaceConfig holds HuggingFace-specific provider configuration.
+type HuggingFaceConfig struct {
+	AliasNamespacePrefixes   []string `json:"alias_namespace_prefixes,omitempty"`     // Namespaces (e.g. "prod") under which alias keys also match bare model requests
+	LogAliasResolution       bool     `json:"log_alias_resolution,omitempty"`         // Debug-log each aliased request as requested=… resolved=… key_id=… request_id=…, for auditing which deployment served a model
+	ForwardUserIDFromContext bool     `json:"forward_user_id_from_context,omitempty"` // Populate the chat `user` field from the authenticated user ID when the caller leaves it unset
+
+	PredictionInferenceProviders []string `json:"prediction_inference_providers,omitempty"` // Inference providers (e.g. "groq") whose chat endpoints accept `prediction` (predicted outputs); it is dropped with a warning for all others
+
+	// Keep-alive tuning for connections to the HuggingFace router (0 = use the client defaults)
+	MaxIdleConnDurationInSeconds int `json:"max_idle_conn_duration_in_seconds,omitempty"` // How long an idle keep-alive connection stays in the pool before being closed
+	MaxConnDurationInSeconds     int `json:"max_conn_duration_in_seconds,omitempty"`      // Maximum lifetime of a keep-alive connection before it is recycled
+
+	MaxModelDescriptionLength int  `json:"max_model_description_length,omitempty"` // Truncate listed model descriptions longer than this many characters, ending in "…" (0 = no truncation)
+	ListModelsFromRouter      bool `json:"list_models_from_router,omitempty"`      // List models from the router's OpenAI-compatible /v1/models (chat models live right now, per inference provider) instead of the Hub API's richer metadata
+
+	// Model listing page sizes, per inference provider
+	DefaultModelFetchLimit int `json:"default_model_fetch_limit,omitempty"` // Models listed when the request sets no page size (default 200)
+	MaxModelFetchLimit     int `json:"max_model_fetch_limit,omitempty"`     // Most models requested from the Hub per page (default 1000, which is also the most the Hub serves)
+
+	IncludeModelHubURL     bool `json:"include_model_hub_url,omitempty"`    // Add the resolved model's Hub page (https://huggingface.co/{org}/{model}) to chat and embedding response extra fields
+	IncludeEffectiveConfig bool `json:"include_effective_config,omitempty"` // Debug: add the final model, task, applied defaults and batch size to chat and embedding response extra fields
+
+	MergeEmbeddingTextInputs        bool   `json:"merge_embedding_text_inputs,omitempty"`        // When an embedding input sets both text and texts, embed text first followed by texts instead of rejecting the request
+	EmbeddingBatchErrorPolicy       string `json:"embedding_batch_error_policy,omitempty"`       // When an embedding batch split into several calls (by prompt name or size) has a failing call: "fail_fast" cancels the others in flight (default), "best_effort" lets them finish
+	GzipEmbeddingRequestMinBytes    int    `json:"gzip_embedding_request_min_bytes,omitempty"`   // Gzip embedding request bodies of at least this many bytes and send them with Content-Encoding: gzip (0 = never; only for endpoints that accept compressed bodies)
+	EmbeddingStreamChunkSize        int    `json:"embedding_stream_chunk_size,omitempty"`        // Inputs per sub-request when EmbeddingStream splits a batch (default 32)
+	MaxEmbeddingRequestBytes        int    `json:"max_embedding_request_bytes,omitempty"`        // Reject an embedding request whose estimated body exceeds this many bytes before sending it (default 2 MiB, HF's limit; negative = no check)
+	ChunkOversizedEmbeddingRequests bool   `json:"chunk_oversized_embedding_requests,omitempty"` // Split such a batch into concurrent requests that each fit instead of rejecting it
+
+	// Embedding fallback: when the requested model fails with one of the listed statuses (gated,
+	// missing or unavailable), the request is resent once with EmbeddingFallbackModel. Responses
+	// served this way carry the fallback in extra fields as fallback_model_used.
+	EmbeddingFallbackModel       string `json:"embedding_fallback_model,omitempty"`        // Model (in the same "provider/org/model" form as requests) to embed with instead (empty = no fallback)
+	EmbeddingFallbackStatusCodes []int  `json:"embedding_fallback_status_codes,omitempty"` // Statuses that trigger the fallback (default 403, 404, 410 and any 5xx)
+
+	JSONContentType string `json:"json_content_type,omitempty"` // Content-Type sent with JSON request bodies (default "application/json; charset=utf-8"; set "application/json" for the bare type)
+	UserAgent       string `json:"user_agent,omitempty"`        // User-Agent sent with every request (default "bifrost/<version>"); e.g. "acme-search/2.0 bifrost/1.5" to identify your app in HF's dashboard
+
+	// Cold-start handling for serverless hf-inference, which answers 503 while a model is loading
+	ModelLoadingRetries            int     `json:"model_loading_retries,omitempty"`              // Times to wait for a loading model and resend before returning the 503 (0 = leave it to Bifrost's generic retries)
+	DefaultModelLoadingWaitSeconds float64 `json:"default_model_loading_wait_seconds,omitempty"` // Wait used when the loading 503 carries no estimated_time (default 10)
+
+	// Resends on transient 429/503 answers (other than cold starts), with exponential backoff that
+	// honors Retry-After. Retries never sleep past the request's context deadline.
+	TransientErrorRetries       int `json:"transient_error_retries,omitempty"`          // Times to resend after a 429 or 503 before returning it (0 = leave it to Bifrost's generic retries)
+	TransientRetryBaseDelayInMs int `json:"transient_retry_base_delay_in_ms,omitempty"` // Wait before the first resend, doubled on each further one (default 500)
+
+	// Long-audio transcription: PCM WAV input longer than TranscriptionSegmentSeconds is cut into
+	// overlapping windows that are transcribed one by one and stitched back into a single transcript
+	TranscriptionSegmentSeconds        float64 `json:"transcription_segment_seconds,omitempty"`         // Window length (0 = always send the audio whole)
+	TranscriptionSegmentOverlapSeconds float64 `json:"transcription_segment_overlap_seconds,omitempty"` // Audio shared by neighboring windows so words at a cut are heard whole (default 2, capped at a quarter of the window)
+
+	EstimateStreamUsage     bool `json:"estimate_stream_usage,omitempty"`       // End chat streams with estimated usage (labeled "estimated") when HF reports none
+	DisableTokenizerFetch   bool `json:"disable_tokenizer_fetch,omitempty"`     // Usage estimates download each model's tokenizer.json from the Hub in the background on first use (on by default); set to never fetch it, e.g. when air-gapped, and use the length heuristic instead
+	StreamFallback          bool `json:"stream_fallback,omitempty"`             // When a model rejects a chat stream as unsupported, make a non-streaming call and send its response as a single stream chunk
+	SuggestModelsOnNotFound bool `json:"suggest_models_on_not_found,omitempty"` // When a chat request's model is not found (404), search the Hub and name up to three similar model IDs in the error (costs one extra Hub call per such error)
+
+	// Default stop sequences for raw-prompt text completion, keyed by a case-insensitive model family
+	// substring (e.g. "llama-3": ["<|eot_id|>"]). Entries override the built-in family defaults and are
+	// only applied when the caller sends no stop sequences of their own.
+	FamilyStopSequences map[string][]string `json:"family_stop_sequences,omitempty"`
+}
+
 func (config *ProviderConfig) CheckAndSetDefaults() {
 	if config.ConcurrencyAndBufferSize.Concurrency == 0 {
 		config.ConcurrencyAndBufferSize.Concurrency = DefaultConcurrency
@@ -54,6 +54,14 @@ func (r *BifrostTextCompletionRequest) ToBifrostChatRequest() *BifrostChatReques
 		params.LogitBias = r.Params.LogitBias
 		params.PresencePenalty = r.Params.PresencePenalty
 		params.Seed = r.Params.Seed
+		// Legacy logprobs is the number of alternatives per token; chat splits it into a
+		// flag plus top_logprobs. echo, best_of and suffix have no chat equivalent.
+		if r.Params.LogProbs != nil {
+			params.LogProbs = Ptr(true)
+			if *r.Params.LogProbs > 0 {
+				params.TopLogProbs = r.Params.LogProbs
+			}
+		}
 	}
 	return &BifrostChatRequest{
 		Provider:  r.Provider,
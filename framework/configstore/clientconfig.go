@@ -317,6 +317,7 @@ type ProviderConfig struct {
 	StoreRawRequestResponse  bool                              `json:"store_raw_request_response"`            // Capture raw request/response for internal logging only; strip from API responses returned to clients
 	CustomProviderConfig     *schemas.CustomProviderConfig     `json:"custom_provider_config,omitempty"`      // Custom provider configuration
 	OpenAIConfig             *schemas.OpenAIConfig             `json:"openai_config,omitempty"`               // OpenAI-specific configuration
+	HuggingFaceConfig        *schemas.HuggingFaceConfig        `json:"huggingface_config,omitempty"`          // HuggingFace-specific configuration
 	ConfigHash               string                            `json:"config_hash,omitempty"`                 // Hash of config.json version, used for change detection
 	Status                   string                            `json:"status,omitempty"`                      // Model discovery status for keyless providers
 	Description              string                            `json:"description,omitempty"`                 // Model discovery error message for keyless providers
@@ -337,6 +338,7 @@ func (p *ProviderConfig) Redacted() *ProviderConfig {
 		StoreRawRequestResponse:  p.StoreRawRequestResponse,
 		CustomProviderConfig:     p.CustomProviderConfig,
 		OpenAIConfig:             p.OpenAIConfig,
+		HuggingFaceConfig:        p.HuggingFaceConfig,
 		ConfigHash:               p.ConfigHash,
 		Status:                   p.Status,
 		Description:              p.Description,
@@ -470,6 +472,14 @@ func (p *ProviderConfig) Redacted() *ProviderConfig {
 			sglConfig.URL = *key.SGLKeyConfig.URL.Redacted()
 			redactedConfig.Keys[i].SGLKeyConfig = sglConfig
 		}
+
+		if key.HuggingFaceKeyConfig != nil {
+			huggingFaceConfig := *key.HuggingFaceKeyConfig
+			if key.HuggingFaceKeyConfig.ProxyConfig != nil {
+				huggingFaceConfig.ProxyConfig = key.HuggingFaceKeyConfig.ProxyConfig.Redacted()
+			}
+			redactedConfig.Keys[i].HuggingFaceKeyConfig = &huggingFaceConfig
+		}
 	}
 	return &redactedConfig
 }
@@ -528,6 +538,15 @@ func (p *ProviderConfig) GenerateConfigHash(providerName string) (string, error)
 		hash.Write(data)
 	}
 
+	// Hash HuggingFaceConfig
+	if p.HuggingFaceConfig != nil {
+		data, err := sonic.Marshal(p.HuggingFaceConfig)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(data)
+	}
+
 	// Hash SendBackRawRequest
 	if p.SendBackRawRequest {
 		hash.Write([]byte("sendBackRawRequest"))
@@ -652,6 +671,14 @@ func GenerateKeyHash(key schemas.Key) (string, error) {
 		}
 		hash.Write(data)
 	}
+	// Hash HuggingFaceKeyConfig
+	if key.HuggingFaceKeyConfig != nil {
+		data, err := sonic.Marshal(key.HuggingFaceKeyConfig)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(data)
+	}
 	// Hash Enabled (nil = false, only true produces different hash)
 	if key.Enabled != nil && *key.Enabled {
 		hash.Write([]byte("enabled:true"))
@@ -617,6 +617,12 @@ func triggerMigrations(ctx context.Context, db *gorm.DB) error {
 	if err := migrationConvertMCPClientToolSyncIntervalMinutesToSeconds(ctx, db); err != nil {
 		return err
 	}
+	if err := migrationAddHuggingFaceConfigJSONColumn(ctx, db); err != nil {
+		return err
+	}
+	if err := migrationAddHuggingFaceKeyConfigJSONColumn(ctx, db); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -7038,3 +7044,65 @@ func migrationAddOCRPricingColumns(ctx context.Context, db *gorm.DB) error {
 	}
 	return nil
 }
+
+// migrationAddHuggingFaceConfigJSONColumn adds the hugging_face_config_json column to the provider table
+func migrationAddHuggingFaceConfigJSONColumn(ctx context.Context, db *gorm.DB) error {
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: "add_hugging_face_config_json_column",
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			migrator := tx.Migrator()
+			if !migrator.HasColumn(&tables.TableProvider{}, "hugging_face_config_json") {
+				if err := migrator.AddColumn(&tables.TableProvider{}, "HuggingFaceConfigJSON"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			migrator := tx.Migrator()
+			if migrator.HasColumn(&tables.TableProvider{}, "hugging_face_config_json") {
+				if err := migrator.DropColumn(&tables.TableProvider{}, "hugging_face_config_json"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error while running add_hugging_face_config_json_column migration: %s", err.Error())
+	}
+	return nil
+}
+
+// migrationAddHuggingFaceKeyConfigJSONColumn adds the hugging_face_key_config_json column to the key table
+func migrationAddHuggingFaceKeyConfigJSONColumn(ctx context.Context, db *gorm.DB) error {
+	m := migrator.New(db, migrator.DefaultOptions, []*migrator.Migration{{
+		ID: "add_hugging_face_key_config_json_column",
+		Migrate: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			migrator := tx.Migrator()
+			if !migrator.HasColumn(&tables.TableKey{}, "hugging_face_key_config_json") {
+				if err := migrator.AddColumn(&tables.TableKey{}, "HuggingFaceKeyConfigJSON"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			tx = tx.WithContext(ctx)
+			migrator := tx.Migrator()
+			if migrator.HasColumn(&tables.TableKey{}, "hugging_face_key_config_json") {
+				if err := migrator.DropColumn(&tables.TableKey{}, "hugging_face_key_config_json"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}})
+	if err := m.Migrate(); err != nil {
+		return fmt.Errorf("error while running add_hugging_face_key_config_json_column migration: %s", err.Error())
+	}
+	return nil
+}
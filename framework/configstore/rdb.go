@@ -54,52 +54,54 @@ func toolSyncIntervalDurationToStoredSeconds(interval time.Duration) (int, error
 // schemaKeyFromTableKey converts a database key to a schema key.
 func schemaKeyFromTableKey(dbKey tables.TableKey) schemas.Key {
 	return schemas.Key{
-		ID:                 dbKey.KeyID,
-		Name:               dbKey.Name,
-		Value:              dbKey.Value,
-		Models:             dbKey.Models,
-		BlacklistedModels:  dbKey.BlacklistedModels,
-		Weight:             getWeight(dbKey.Weight),
-		Enabled:            dbKey.Enabled,
-		UseForBatchAPI:     dbKey.UseForBatchAPI,
-		AzureKeyConfig:     dbKey.AzureKeyConfig,
-		VertexKeyConfig:    dbKey.VertexKeyConfig,
-		BedrockKeyConfig:   dbKey.BedrockKeyConfig,
-		Aliases:            dbKey.Aliases,
-		VLLMKeyConfig:      dbKey.VLLMKeyConfig,
-		ReplicateKeyConfig: dbKey.ReplicateKeyConfig,
-		OllamaKeyConfig:    dbKey.OllamaKeyConfig,
-		SGLKeyConfig:       dbKey.SGLKeyConfig,
-		ConfigHash:         dbKey.ConfigHash,
-		Status:             schemas.KeyStatusType(dbKey.Status),
-		Description:        dbKey.Description,
+		ID:                   dbKey.KeyID,
+		Name:                 dbKey.Name,
+		Value:                dbKey.Value,
+		Models:               dbKey.Models,
+		BlacklistedModels:    dbKey.BlacklistedModels,
+		Weight:               getWeight(dbKey.Weight),
+		Enabled:              dbKey.Enabled,
+		UseForBatchAPI:       dbKey.UseForBatchAPI,
+		AzureKeyConfig:       dbKey.AzureKeyConfig,
+		VertexKeyConfig:      dbKey.VertexKeyConfig,
+		BedrockKeyConfig:     dbKey.BedrockKeyConfig,
+		Aliases:              dbKey.Aliases,
+		VLLMKeyConfig:        dbKey.VLLMKeyConfig,
+		ReplicateKeyConfig:   dbKey.ReplicateKeyConfig,
+		OllamaKeyConfig:      dbKey.OllamaKeyConfig,
+		SGLKeyConfig:         dbKey.SGLKeyConfig,
+		HuggingFaceKeyConfig: dbKey.HuggingFaceKeyConfig,
+		ConfigHash:           dbKey.ConfigHash,
+		Status:               schemas.KeyStatusType(dbKey.Status),
+		Description:          dbKey.Description,
 	}
 }
 
 // tableKeyFromSchemaKey converts a schema key to a database key.
 func tableKeyFromSchemaKey(provider tables.TableProvider, key schemas.Key) (tables.TableKey, error) {
 	dbKey := tables.TableKey{
-		Provider:           provider.Name,
-		ProviderID:         provider.ID,
-		KeyID:              key.ID,
-		Name:               key.Name,
-		Value:              key.Value,
-		Models:             key.Models,
-		BlacklistedModels:  key.BlacklistedModels,
-		Weight:             &key.Weight,
-		Enabled:            key.Enabled,
-		UseForBatchAPI:     key.UseForBatchAPI,
-		AzureKeyConfig:     key.AzureKeyConfig,
-		VertexKeyConfig:    key.VertexKeyConfig,
-		BedrockKeyConfig:   key.BedrockKeyConfig,
-		Aliases:            key.Aliases,
-		VLLMKeyConfig:      key.VLLMKeyConfig,
-		ReplicateKeyConfig: key.ReplicateKeyConfig,
-		OllamaKeyConfig:    key.OllamaKeyConfig,
-		SGLKeyConfig:       key.SGLKeyConfig,
-		ConfigHash:         key.ConfigHash,
-		Status:             string(key.Status),
-		Description:        key.Description,
+		Provider:             provider.Name,
+		ProviderID:           provider.ID,
+		KeyID:                key.ID,
+		Name:                 key.Name,
+		Value:                key.Value,
+		Models:               key.Models,
+		BlacklistedModels:    key.BlacklistedModels,
+		Weight:               &key.Weight,
+		Enabled:              key.Enabled,
+		UseForBatchAPI:       key.UseForBatchAPI,
+		AzureKeyConfig:       key.AzureKeyConfig,
+		VertexKeyConfig:      key.VertexKeyConfig,
+		BedrockKeyConfig:     key.BedrockKeyConfig,
+		Aliases:              key.Aliases,
+		VLLMKeyConfig:        key.VLLMKeyConfig,
+		ReplicateKeyConfig:   key.ReplicateKeyConfig,
+		OllamaKeyConfig:      key.OllamaKeyConfig,
+		SGLKeyConfig:         key.SGLKeyConfig,
+		HuggingFaceKeyConfig: key.HuggingFaceKeyConfig,
+		ConfigHash:           key.ConfigHash,
+		Status:               string(key.Status),
+		Description:          key.Description,
 	}
 
 	if key.AzureKeyConfig != nil {
@@ -402,6 +404,7 @@ func (s *RDBConfigStore) UpdateProvidersConfig(ctx context.Context, providers ma
 			StoreRawRequestResponse:  providerConfig.StoreRawRequestResponse,
 			CustomProviderConfig:     providerConfig.CustomProviderConfig,
 			OpenAIConfig:             providerConfig.OpenAIConfig,
+			HuggingFaceConfig:        providerConfig.HuggingFaceConfig,
 			ConfigHash:               providerConfig.ConfigHash,
 			Status:                   providerConfig.Status,
 			Description:              providerConfig.Description,
@@ -432,27 +435,28 @@ func (s *RDBConfigStore) UpdateProvidersConfig(ctx context.Context, providers ma
 				}
 			}
 			dbKey := tables.TableKey{
-				Provider:           dbProvider.Name,
-				ProviderID:         dbProvider.ID,
-				KeyID:              key.ID,
-				Name:               key.Name,
-				Value:              key.Value,
-				Models:             key.Models,
-				BlacklistedModels:  key.BlacklistedModels,
-				Weight:             &key.Weight,
-				Enabled:            key.Enabled,
-				UseForBatchAPI:     key.UseForBatchAPI,
-				AzureKeyConfig:     key.AzureKeyConfig,
-				VertexKeyConfig:    key.VertexKeyConfig,
-				BedrockKeyConfig:   key.BedrockKeyConfig,
-				Aliases:            key.Aliases,
-				VLLMKeyConfig:      key.VLLMKeyConfig,
-				ReplicateKeyConfig: key.ReplicateKeyConfig,
-				OllamaKeyConfig:    key.OllamaKeyConfig,
-				SGLKeyConfig:       key.SGLKeyConfig,
-				ConfigHash:         keyHash,
-				Status:             string(key.Status),
-				Description:        key.Description,
+				Provider:             dbProvider.Name,
+				ProviderID:           dbProvider.ID,
+				KeyID:                key.ID,
+				Name:                 key.Name,
+				Value:                key.Value,
+				Models:               key.Models,
+				BlacklistedModels:    key.BlacklistedModels,
+				Weight:               &key.Weight,
+				Enabled:              key.Enabled,
+				UseForBatchAPI:       key.UseForBatchAPI,
+				AzureKeyConfig:       key.AzureKeyConfig,
+				VertexKeyConfig:      key.VertexKeyConfig,
+				BedrockKeyConfig:     key.BedrockKeyConfig,
+				Aliases:              key.Aliases,
+				VLLMKeyConfig:        key.VLLMKeyConfig,
+				ReplicateKeyConfig:   key.ReplicateKeyConfig,
+				OllamaKeyConfig:      key.OllamaKeyConfig,
+				SGLKeyConfig:         key.SGLKeyConfig,
+				HuggingFaceKeyConfig: key.HuggingFaceKeyConfig,
+				ConfigHash:           keyHash,
+				Status:               string(key.Status),
+				Description:          key.Description,
 			}
 
 			// Handle Azure config
@@ -623,6 +627,7 @@ func (s *RDBConfigStore) UpdateProvider(ctx context.Context, provider schemas.Mo
 	dbProvider.StoreRawRequestResponse = configCopy.StoreRawRequestResponse
 	dbProvider.CustomProviderConfig = configCopy.CustomProviderConfig
 	dbProvider.OpenAIConfig = configCopy.OpenAIConfig
+	dbProvider.HuggingFaceConfig = configCopy.HuggingFaceConfig
 	dbProvider.ConfigHash = configCopy.ConfigHash
 
 	// Save the updated provider
@@ -650,27 +655,28 @@ func (s *RDBConfigStore) UpdateProvider(ctx context.Context, provider schemas.Mo
 			return fmt.Errorf("failed to generate key hash: %w", err)
 		}
 		dbKey := tables.TableKey{
-			Provider:           dbProvider.Name,
-			ProviderID:         dbProvider.ID,
-			KeyID:              key.ID,
-			Name:               key.Name,
-			Value:              key.Value,
-			Models:             key.Models,
-			BlacklistedModels:  key.BlacklistedModels,
-			Weight:             &key.Weight,
-			Enabled:            key.Enabled,
-			UseForBatchAPI:     key.UseForBatchAPI,
-			AzureKeyConfig:     key.AzureKeyConfig,
-			VertexKeyConfig:    key.VertexKeyConfig,
-			BedrockKeyConfig:   key.BedrockKeyConfig,
-			Aliases:            key.Aliases,
-			VLLMKeyConfig:      key.VLLMKeyConfig,
-			ReplicateKeyConfig: key.ReplicateKeyConfig,
-			OllamaKeyConfig:    key.OllamaKeyConfig,
-			SGLKeyConfig:       key.SGLKeyConfig,
-			ConfigHash:         keyHash,
-			Status:             string(key.Status),
-			Description:        key.Description,
+			Provider:             dbProvider.Name,
+			ProviderID:           dbProvider.ID,
+			KeyID:                key.ID,
+			Name:                 key.Name,
+			Value:                key.Value,
+			Models:               key.Models,
+			BlacklistedModels:    key.BlacklistedModels,
+			Weight:               &key.Weight,
+			Enabled:              key.Enabled,
+			UseForBatchAPI:       key.UseForBatchAPI,
+			AzureKeyConfig:       key.AzureKeyConfig,
+			VertexKeyConfig:      key.VertexKeyConfig,
+			BedrockKeyConfig:     key.BedrockKeyConfig,
+			Aliases:              key.Aliases,
+			VLLMKeyConfig:        key.VLLMKeyConfig,
+			ReplicateKeyConfig:   key.ReplicateKeyConfig,
+			OllamaKeyConfig:      key.OllamaKeyConfig,
+			SGLKeyConfig:         key.SGLKeyConfig,
+			HuggingFaceKeyConfig: key.HuggingFaceKeyConfig,
+			ConfigHash:           keyHash,
+			Status:               string(key.Status),
+			Description:          key.Description,
 		}
 
 		// Handle Azure config
@@ -775,6 +781,7 @@ func (s *RDBConfigStore) AddProvider(ctx context.Context, provider schemas.Model
 		StoreRawRequestResponse:  configCopy.StoreRawRequestResponse,
 		CustomProviderConfig:     configCopy.CustomProviderConfig,
 		OpenAIConfig:             configCopy.OpenAIConfig,
+		HuggingFaceConfig:        configCopy.HuggingFaceConfig,
 		ConfigHash:               configCopy.ConfigHash,
 	}
 	// Create the provider
@@ -784,27 +791,28 @@ func (s *RDBConfigStore) AddProvider(ctx context.Context, provider schemas.Model
 	// Create keys for this provider
 	for _, key := range configCopy.Keys {
 		dbKey := tables.TableKey{
-			Provider:           dbProvider.Name,
-			ProviderID:         dbProvider.ID,
-			KeyID:              key.ID,
-			Name:               key.Name,
-			Value:              key.Value,
-			Models:             key.Models,
-			BlacklistedModels:  key.BlacklistedModels,
-			Weight:             &key.Weight,
-			Enabled:            key.Enabled,
-			UseForBatchAPI:     key.UseForBatchAPI,
-			AzureKeyConfig:     key.AzureKeyConfig,
-			VertexKeyConfig:    key.VertexKeyConfig,
-			BedrockKeyConfig:   key.BedrockKeyConfig,
-			Aliases:            key.Aliases,
-			VLLMKeyConfig:      key.VLLMKeyConfig,
-			ReplicateKeyConfig: key.ReplicateKeyConfig,
-			OllamaKeyConfig:    key.OllamaKeyConfig,
-			SGLKeyConfig:       key.SGLKeyConfig,
-			ConfigHash:         key.ConfigHash,
-			Status:             string(key.Status),
-			Description:        key.Description,
+			Provider:             dbProvider.Name,
+			ProviderID:           dbProvider.ID,
+			KeyID:                key.ID,
+			Name:                 key.Name,
+			Value:                key.Value,
+			Models:               key.Models,
+			BlacklistedModels:    key.BlacklistedModels,
+			Weight:               &key.Weight,
+			Enabled:              key.Enabled,
+			UseForBatchAPI:       key.UseForBatchAPI,
+			AzureKeyConfig:       key.AzureKeyConfig,
+			VertexKeyConfig:      key.VertexKeyConfig,
+			BedrockKeyConfig:     key.BedrockKeyConfig,
+			Aliases:              key.Aliases,
+			VLLMKeyConfig:        key.VLLMKeyConfig,
+			ReplicateKeyConfig:   key.ReplicateKeyConfig,
+			OllamaKeyConfig:      key.OllamaKeyConfig,
+			SGLKeyConfig:         key.SGLKeyConfig,
+			HuggingFaceKeyConfig: key.HuggingFaceKeyConfig,
+			ConfigHash:           key.ConfigHash,
+			Status:               string(key.Status),
+			Description:          key.Description,
 		}
 		// Handle Azure config
 		if key.AzureKeyConfig != nil {
@@ -928,6 +936,7 @@ func (s *RDBConfigStore) GetProvidersConfig(ctx context.Context) (map[schemas.Mo
 			StoreRawRequestResponse:  dbProvider.StoreRawRequestResponse,
 			CustomProviderConfig:     dbProvider.CustomProviderConfig,
 			OpenAIConfig:             dbProvider.OpenAIConfig,
+			HuggingFaceConfig:        dbProvider.HuggingFaceConfig,
 			ConfigHash:               dbProvider.ConfigHash,
 			Status:                   dbProvider.Status,
 			Description:              dbProvider.Description,
@@ -961,6 +970,7 @@ func (s *RDBConfigStore) GetProviderConfig(ctx context.Context, provider schemas
 		StoreRawRequestResponse:  dbProvider.StoreRawRequestResponse,
 		CustomProviderConfig:     dbProvider.CustomProviderConfig,
 		OpenAIConfig:             dbProvider.OpenAIConfig,
+		HuggingFaceConfig:        dbProvider.HuggingFaceConfig,
 		ConfigHash:               dbProvider.ConfigHash,
 		Status:                   dbProvider.Status,
 		Description:              dbProvider.Description,
@@ -95,6 +95,37 @@ func TestUpdateProvidersConfig_CreateNew(t *testing.T) {
 	assert.Equal(t, "openai-primary", result["openai"].Keys[0].Name)
 }
 
+func TestUpdateProvidersConfig_HuggingFaceConfigRoundTrip(t *testing.T) {
+	store := setupRDBTestStore(t)
+	ctx := context.Background()
+
+	providers := map[schemas.ModelProvider]ProviderConfig{
+		schemas.HuggingFace: {
+			Keys: []schemas.Key{
+				{
+					ID:     "key-uuid-1",
+					Name:   "hf-primary",
+					Value:  *schemas.NewEnvVar("hf_test"),
+					Weight: 1.0,
+				},
+			},
+			HuggingFaceConfig: &schemas.HuggingFaceConfig{
+				StreamFallback:           true,
+				MaxEmbeddingRequestBytes: 1 << 20,
+				FamilyStopSequences:      map[string][]string{"llama-3": {"<|eot_id|>"}},
+			},
+		},
+	}
+
+	err := store.UpdateProvidersConfig(ctx, providers)
+	require.NoError(t, err)
+
+	result, err := store.GetProviderConfig(ctx, schemas.HuggingFace)
+	require.NoError(t, err)
+	require.NotNil(t, result.HuggingFaceConfig)
+	assert.Equal(t, providers[schemas.HuggingFace].HuggingFaceConfig, result.HuggingFaceConfig)
+}
+
 func TestUpdateProvidersConfig_UpdateExistingByKeyID(t *testing.T) {
 	store := setupRDBTestStore(t)
 	ctx := context.Background()
@@ -237,6 +237,52 @@ func TestTableKey_BedrockFieldsEncryptDecrypt(t *testing.T) {
 	assert.True(t, found.BedrockKeyConfig.BatchS3Config.Buckets[0].IsDefault)
 }
 
+func TestTableKey_HuggingFaceKeyConfigEncryptDecrypt(t *testing.T) {
+	db := setupTestDB(t)
+
+	key := &TableKey{
+		Name:       "hf-key",
+		ProviderID: 1,
+		Provider:   "huggingface",
+		KeyID:      "hf-uuid-1",
+		Value:      *schemas.NewEnvVar("hf_val"),
+		HuggingFaceKeyConfig: &schemas.HuggingFaceKeyConfig{
+			InferenceProvider: "together",
+			Endpoints:         map[string]string{"meta-llama/Llama-3.1-8B-Instruct": "https://abc123.endpoints.huggingface.cloud"},
+			ProxyConfig: &schemas.ProxyConfig{
+				Type:     schemas.HTTPProxy,
+				URL:      schemas.NewEnvVar("http://proxy.internal:8080"),
+				Password: schemas.NewEnvVar("proxy-secret"),
+			},
+			MaxConcurrency: map[string]int{"meta-llama/Llama-3.1-8B-Instruct": 2},
+		},
+	}
+
+	require.NoError(t, db.Create(key).Error)
+
+	raw := rawRow(t, db, "config_keys", key.ID)
+	assert.Equal(t, "encrypted", raw["encryption_status"])
+	var rawConfig string
+	switch v := raw["hugging_face_key_config_json"].(type) {
+	case string:
+		rawConfig = v
+	case []byte:
+		rawConfig = string(v)
+	}
+	require.NotEmpty(t, rawConfig, "hugging_face_key_config_json should not be empty")
+	assert.NotContains(t, rawConfig, "proxy-secret")
+
+	var found TableKey
+	require.NoError(t, db.First(&found, key.ID).Error)
+	require.NotNil(t, found.HuggingFaceKeyConfig)
+	assert.Equal(t, "together", found.HuggingFaceKeyConfig.InferenceProvider)
+	assert.Equal(t, key.HuggingFaceKeyConfig.Endpoints, found.HuggingFaceKeyConfig.Endpoints)
+	assert.Equal(t, key.HuggingFaceKeyConfig.MaxConcurrency, found.HuggingFaceKeyConfig.MaxConcurrency)
+	require.NotNil(t, found.HuggingFaceKeyConfig.ProxyConfig)
+	require.NotNil(t, found.HuggingFaceKeyConfig.ProxyConfig.Password)
+	assert.Equal(t, "proxy-secret", found.HuggingFaceKeyConfig.ProxyConfig.Password.GetValue())
+}
+
 func TestTableKey_EnvVarNotEncrypted(t *testing.T) {
 	db := setupTestDB(t)
 
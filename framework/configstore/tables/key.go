@@ -70,6 +70,9 @@ type TableKey struct {
 	// SGL config fields (embedded)
 	SGLUrl *schemas.EnvVar `gorm:"type:text" json:"sgl_url,omitempty"`
 
+	// HuggingFace config
+	HuggingFaceKeyConfigJSON *string `gorm:"type:text" json:"-"` // JSON serialized schemas.HuggingFaceKeyConfig
+
 	// Batch API configuration
 	UseForBatchAPI *bool `gorm:"default:false" json:"use_for_batch_api,omitempty"` // Whether this key can be used for batch API operations
 
@@ -89,6 +92,8 @@ type TableKey struct {
 	ReplicateKeyConfig *schemas.ReplicateKeyConfig `gorm:"-" json:"replicate_key_config,omitempty"`
 	OllamaKeyConfig    *schemas.OllamaKeyConfig    `gorm:"-" json:"ollama_key_config,omitempty"`
 	SGLKeyConfig       *schemas.SGLKeyConfig       `gorm:"-" json:"sgl_key_config,omitempty"`
+
+	HuggingFaceKeyConfig *schemas.HuggingFaceKeyConfig `gorm:"-" json:"huggingface_key_config,omitempty"`
 }
 
 // TableName sets the table name for each model
@@ -97,7 +102,7 @@ func (TableKey) TableName() string { return "config_keys" }
 // BeforeSave is a GORM hook that serializes runtime config structs into JSON columns and
 // encrypts sensitive fields (API key value, Azure endpoint/client ID/secret/tenant ID/API version,
 // Vertex project ID/project number/region/credentials, Bedrock keys/region/ARN/deployments/
-// batch S3 config) before writing to the database. Encryption runs last to ensure it
+// batch S3 config, HuggingFace key config) before writing to the database. Encryption runs last to ensure it
 // operates on the final serialized values.
 func (k *TableKey) BeforeSave(tx *gorm.DB) error {
 	if err := k.Models.Validate(); err != nil {
@@ -336,6 +341,17 @@ func (k *TableKey) BeforeSave(tx *gorm.DB) error {
 		k.SGLUrl = nil
 	}
 
+	if k.HuggingFaceKeyConfig != nil {
+		data, err := sonic.Marshal(k.HuggingFaceKeyConfig)
+		if err != nil {
+			return err
+		}
+		s := string(data)
+		k.HuggingFaceKeyConfigJSON = &s
+	} else {
+		k.HuggingFaceKeyConfigJSON = nil
+	}
+
 	// Encrypt sensitive fields after serialization
 	if encrypt.IsEnabled() {
 		if err := encryptEnvVar(&k.Value); err != nil {
@@ -414,6 +430,10 @@ func (k *TableKey) BeforeSave(tx *gorm.DB) error {
 		if err := encryptEnvVarPtr(&k.SGLUrl); err != nil {
 			return fmt.Errorf("failed to encrypt sgl url: %w", err)
 		}
+		// HuggingFace (may carry proxy credentials)
+		if err := encryptString(k.HuggingFaceKeyConfigJSON); err != nil {
+			return fmt.Errorf("failed to encrypt huggingface key config: %w", err)
+		}
 		k.EncryptionStatus = EncryptionStatusEncrypted
 	}
 	return nil
@@ -501,6 +521,10 @@ func (k *TableKey) AfterFind(tx *gorm.DB) error {
 		if err := decryptEnvVarPtr(&k.SGLUrl); err != nil {
 			return fmt.Errorf("failed to decrypt sgl url: %w", err)
 		}
+		// HuggingFace
+		if err := decryptString(k.HuggingFaceKeyConfigJSON); err != nil {
+			return fmt.Errorf("failed to decrypt huggingface key config: %w", err)
+		}
 	}
 
 	if k.ModelsJSON != "" {
@@ -640,5 +664,15 @@ func (k *TableKey) AfterFind(tx *gorm.DB) error {
 	} else {
 		k.SGLKeyConfig = nil
 	}
+	// Reconstruct HuggingFace config
+	if k.HuggingFaceKeyConfigJSON != nil && *k.HuggingFaceKeyConfigJSON != "" {
+		var huggingFaceConfig schemas.HuggingFaceKeyConfig
+		if err := sonic.Unmarshal([]byte(*k.HuggingFaceKeyConfigJSON), &huggingFaceConfig); err != nil {
+			return err
+		}
+		k.HuggingFaceKeyConfig = &huggingFaceConfig
+	} else {
+		k.HuggingFaceKeyConfig = nil
+	}
 	return nil
 }
@@ -22,6 +22,7 @@ type TableProvider struct {
 	ProxyConfigJSON          string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.ProxyConfig
 	CustomProviderConfigJSON string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.CustomProviderConfig
 	OpenAIConfigJSON         string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.OpenAIConfig
+	HuggingFaceConfigJSON    string    `gorm:"type:text" json:"-"`                                // JSON serialized schemas.HuggingFaceConfig
 	SendBackRawRequest       bool      `json:"send_back_raw_request"`
 	SendBackRawResponse      bool      `json:"send_back_raw_response"`
 	StoreRawRequestResponse  bool      `json:"store_raw_request_response"`
@@ -39,6 +40,7 @@ type TableProvider struct {
 	// Custom provider fields
 	CustomProviderConfig *schemas.CustomProviderConfig `gorm:"-" json:"custom_provider_config,omitempty"`
 	OpenAIConfig         *schemas.OpenAIConfig         `gorm:"-" json:"openai_config,omitempty"`
+	HuggingFaceConfig    *schemas.HuggingFaceConfig    `gorm:"-" json:"huggingface_config,omitempty"`
 
 	// Foreign keys
 	Models []TableModel `gorm:"foreignKey:ProviderID;constraint:OnDelete:CASCADE" json:"models"`
@@ -109,6 +111,15 @@ func (p *TableProvider) BeforeSave(tx *gorm.DB) error {
 	} else {
 		p.OpenAIConfigJSON = ""
 	}
+	if p.HuggingFaceConfig != nil {
+		data, err := json.Marshal(p.HuggingFaceConfig)
+		if err != nil {
+			return err
+		}
+		p.HuggingFaceConfigJSON = string(data)
+	} else {
+		p.HuggingFaceConfigJSON = ""
+	}
 	// Validate governance fields
 	if p.BudgetID != nil && strings.TrimSpace(*p.BudgetID) == "" {
 		return fmt.Errorf("budget_id cannot be an empty string")
@@ -180,5 +191,13 @@ func (p *TableProvider) AfterFind(tx *gorm.DB) error {
 		p.OpenAIConfig = &openaiConfig
 	}
 
+	if p.HuggingFaceConfigJSON != "" {
+		var huggingFaceConfig schemas.HuggingFaceConfig
+		if err := json.Unmarshal([]byte(p.HuggingFaceConfigJSON), &huggingFaceConfig); err != nil {
+			return err
+		}
+		p.HuggingFaceConfig = &huggingFaceConfig
+	}
+
 	return nil
 }
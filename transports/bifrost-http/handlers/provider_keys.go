@@ -454,6 +454,28 @@ func (h *ProviderHandler) mergeUpdatedKey(oldRawKey, oldRedactedKey, updateKey s
 		}
 	}
 
+	if updateKey.HuggingFaceKeyConfig != nil && oldRedactedKey.HuggingFaceKeyConfig != nil && oldRawKey.HuggingFaceKeyConfig != nil {
+		updateProxy := updateKey.HuggingFaceKeyConfig.ProxyConfig
+		oldRedactedProxy := oldRedactedKey.HuggingFaceKeyConfig.ProxyConfig
+		oldRawProxy := oldRawKey.HuggingFaceKeyConfig.ProxyConfig
+		if updateProxy != nil && oldRedactedProxy != nil && oldRawProxy != nil {
+			mergedProxy := *updateProxy // safe copy
+			if updateProxy.Password != nil && oldRedactedProxy.Password != nil &&
+				updateProxy.Password.IsRedacted() &&
+				updateProxy.Password.Equals(oldRedactedProxy.Password) {
+				mergedProxy.Password = oldRawProxy.Password
+			}
+			if updateProxy.CACertPEM != nil && oldRedactedProxy.CACertPEM != nil &&
+				updateProxy.CACertPEM.IsRedacted() &&
+				updateProxy.CACertPEM.Equals(oldRedactedProxy.CACertPEM) {
+				mergedProxy.CACertPEM = oldRawProxy.CACertPEM
+			}
+			mergedHuggingFaceConfig := *mergedKey.HuggingFaceKeyConfig // safe copy
+			mergedHuggingFaceConfig.ProxyConfig = &mergedProxy
+			mergedKey.HuggingFaceKeyConfig = &mergedHuggingFaceConfig
+		}
+	}
+
 	mergedKey.ConfigHash = oldRawKey.ConfigHash
 	mergedKey.Status = oldRawKey.Status
 
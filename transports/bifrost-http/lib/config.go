@@ -886,21 +886,22 @@ func mergeProviderKeys(provider schemas.ModelProvider, fileKeys, dbKeys []schema
 			} else {
 				// No stored hash (legacy) - fall back to generating fresh hash
 				dbKeyHash, err := configstore.GenerateKeyHash(schemas.Key{
-					Name:               dbKey.Name,
-					Value:              dbKey.Value,
-					Models:             dbKey.Models,
-					BlacklistedModels:  dbKey.BlacklistedModels,
-					Weight:             dbKey.Weight,
-					AzureKeyConfig:     dbKey.AzureKeyConfig,
-					VertexKeyConfig:    dbKey.VertexKeyConfig,
-					BedrockKeyConfig:   dbKey.BedrockKeyConfig,
-					ReplicateKeyConfig: dbKey.ReplicateKeyConfig,
-					Aliases:            dbKey.Aliases,
-					VLLMKeyConfig:      dbKey.VLLMKeyConfig,
-					OllamaKeyConfig:    dbKey.OllamaKeyConfig,
-					SGLKeyConfig:       dbKey.SGLKeyConfig,
-					Enabled:            dbKey.Enabled,
-					UseForBatchAPI:     dbKey.UseForBatchAPI,
+					Name:                 dbKey.Name,
+					Value:                dbKey.Value,
+					Models:               dbKey.Models,
+					BlacklistedModels:    dbKey.BlacklistedModels,
+					Weight:               dbKey.Weight,
+					AzureKeyConfig:       dbKey.AzureKeyConfig,
+					VertexKeyConfig:      dbKey.VertexKeyConfig,
+					BedrockKeyConfig:     dbKey.BedrockKeyConfig,
+					ReplicateKeyConfig:   dbKey.ReplicateKeyConfig,
+					Aliases:              dbKey.Aliases,
+					VLLMKeyConfig:        dbKey.VLLMKeyConfig,
+					OllamaKeyConfig:      dbKey.OllamaKeyConfig,
+					SGLKeyConfig:         dbKey.SGLKeyConfig,
+					HuggingFaceKeyConfig: dbKey.HuggingFaceKeyConfig,
+					Enabled:              dbKey.Enabled,
+					UseForBatchAPI:       dbKey.UseForBatchAPI,
 				})
 				if err != nil {
 					logger.Warn("failed to generate key hash for db key %s (%s): %v, falling back to name comparison", dbKey.Name, provider, err)
@@ -967,21 +968,22 @@ func reconcileProviderKeys(provider schemas.ModelProvider, fileKeys, dbKeys []sc
 			} else {
 				// No stored hash (legacy) - fall back to generating fresh hash for comparison
 				dbKeyHash, err := configstore.GenerateKeyHash(schemas.Key{
-					Name:               dbKey.Name,
-					Value:              dbKey.Value,
-					Models:             dbKey.Models,
-					BlacklistedModels:  dbKey.BlacklistedModels,
-					Weight:             dbKey.Weight,
-					AzureKeyConfig:     dbKey.AzureKeyConfig,
-					VertexKeyConfig:    dbKey.VertexKeyConfig,
-					BedrockKeyConfig:   dbKey.BedrockKeyConfig,
-					ReplicateKeyConfig: dbKey.ReplicateKeyConfig,
-					Aliases:            dbKey.Aliases,
-					VLLMKeyConfig:      dbKey.VLLMKeyConfig,
-					OllamaKeyConfig:    dbKey.OllamaKeyConfig,
-					SGLKeyConfig:       dbKey.SGLKeyConfig,
-					Enabled:            dbKey.Enabled,
-					UseForBatchAPI:     dbKey.UseForBatchAPI,
+					Name:                 dbKey.Name,
+					Value:                dbKey.Value,
+					Models:               dbKey.Models,
+					BlacklistedModels:    dbKey.BlacklistedModels,
+					Weight:               dbKey.Weight,
+					AzureKeyConfig:       dbKey.AzureKeyConfig,
+					VertexKeyConfig:      dbKey.VertexKeyConfig,
+					BedrockKeyConfig:     dbKey.BedrockKeyConfig,
+					ReplicateKeyConfig:   dbKey.ReplicateKeyConfig,
+					Aliases:              dbKey.Aliases,
+					VLLMKeyConfig:        dbKey.VLLMKeyConfig,
+					OllamaKeyConfig:      dbKey.OllamaKeyConfig,
+					SGLKeyConfig:         dbKey.SGLKeyConfig,
+					HuggingFaceKeyConfig: dbKey.HuggingFaceKeyConfig,
+					Enabled:              dbKey.Enabled,
+					UseForBatchAPI:       dbKey.UseForBatchAPI,
 				})
 				if err != nil {
 					logger.Warn("failed to generate key hash for db key %s (%s): %v", dbKey.Name, provider, err)
@@ -2927,13 +2929,13 @@ func ResolveFrameworkPricingConfig(
 	}
 
 	return &configstoreTables.TableFrameworkConfig{
-			ID:                  configID,
-			PricingURL:          resolvedPricingURL,
-			PricingSyncInterval: resolvedSyncSeconds,
-		}, &modelcatalog.Config{
-			PricingURL:          resolvedPricingURL,
-			PricingSyncInterval: resolvedSyncSeconds,
-		}, needsDBUpdate
+		ID:                  configID,
+		PricingURL:          resolvedPricingURL,
+		PricingSyncInterval: resolvedSyncSeconds,
+	}, &modelcatalog.Config{
+		PricingURL:          resolvedPricingURL,
+		PricingSyncInterval: resolvedSyncSeconds,
+	}, needsDBUpdate
 }
 
 // initFrameworkConfig initializes framework config and pricing manager from file
@@ -4373,6 +4375,13 @@ func (c *Config) GetAllKeys() ([]configstoreTables.TableKey, error) {
 				cfg.URL = *cfg.URL.Redacted()
 				configStoreKey.SGLKeyConfig = &cfg
 			}
+			if key.HuggingFaceKeyConfig != nil {
+				cfg := *key.HuggingFaceKeyConfig // safe copy
+				if cfg.ProxyConfig != nil {
+					cfg.ProxyConfig = cfg.ProxyConfig.Redacted()
+				}
+				configStoreKey.HuggingFaceKeyConfig = &cfg
+			}
 			keys = append(keys, configStoreKey)
 		}
 	}
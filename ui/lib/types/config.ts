@@ -126,6 +126,21 @@ export const DefaultSGLKeyConfig: SGLKeyConfig = {
 	url: { value: "", env_var: "", from_env: false },
 } as const satisfies Required<SGLKeyConfig>;
 
+// HuggingFaceModelPrice matching Go's schemas.HuggingFaceModelPrice (USD per million tokens)
+export interface HuggingFaceModelPrice {
+	input_per_million_tokens: number;
+	output_per_million_tokens?: number;
+}
+
+// HuggingFaceKeyConfig matching Go's schemas.HuggingFaceKeyConfig
+export interface HuggingFaceKeyConfig {
+	inference_provider?: string;
+	endpoints?: Record<string, string>;
+	proxy_config?: ProxyConfig;
+	max_concurrency?: Record<string, number>;
+	pricing?: Record<string, HuggingFaceModelPrice>;
+}
+
 // Key structure matching Go's schemas.Key
 export interface ModelProviderKey {
 	id: string;
@@ -144,6 +159,7 @@ export interface ModelProviderKey {
 	replicate_key_config?: ReplicateKeyConfig;
 	ollama_key_config?: OllamaKeyConfig;
 	sgl_key_config?: SGLKeyConfig;
+	huggingface_key_config?: HuggingFaceKeyConfig;
 	config_hash?: string; // Present when config is synced from config.json
 	status?: "unknown" | "success" | "list_models_failed";
 	description?: string;
@@ -297,6 +313,43 @@ export interface OpenAIConfig {
 	disable_store?: boolean;
 }
 
+// HuggingFaceConfig holds HuggingFace-specific provider configuration, matching Go's schemas.HuggingFaceConfig.
+export interface HuggingFaceConfig {
+	alias_namespace_prefixes?: string[];
+	log_alias_resolution?: boolean;
+	forward_user_id_from_context?: boolean;
+	prediction_inference_providers?: string[];
+	max_idle_conn_duration_in_seconds?: number;
+	max_conn_duration_in_seconds?: number;
+	max_model_description_length?: number;
+	list_models_from_router?: boolean;
+	default_model_fetch_limit?: number;
+	max_model_fetch_limit?: number;
+	include_model_hub_url?: boolean;
+	include_effective_config?: boolean;
+	merge_embedding_text_inputs?: boolean;
+	embedding_batch_error_policy?: "fail_fast" | "best_effort";
+	gzip_embedding_request_min_bytes?: number;
+	embedding_stream_chunk_size?: number;
+	max_embedding_request_bytes?: number;
+	chunk_oversized_embedding_requests?: boolean;
+	embedding_fallback_model?: string;
+	embedding_fallback_status_codes?: number[];
+	json_content_type?: string;
+	user_agent?: string;
+	model_loading_retries?: number;
+	default_model_loading_wait_seconds?: number;
+	transient_error_retries?: number;
+	transient_retry_base_delay_in_ms?: number;
+	transcription_segment_seconds?: number;
+	transcription_segment_overlap_seconds?: number;
+	estimate_stream_usage?: boolean;
+	disable_tokenizer_fetch?: boolean;
+	stream_fallback?: boolean;
+	suggest_models_on_not_found?: boolean;
+	family_stop_sequences?: Record<string, string[]>;
+}
+
 // ProviderConfig matching Go's lib.ProviderConfig
 export interface ModelProviderConfig {
 	network_config?: NetworkConfig;
@@ -307,6 +360,7 @@ export interface ModelProviderConfig {
 	store_raw_request_response?: boolean;
 	custom_provider_config?: CustomProviderConfig;
 	openai_config?: OpenAIConfig;
+	huggingface_config?: HuggingFaceConfig;
 	status?: "unknown" | "success" | "list_models_failed";
 	description?: string;
 }
@@ -335,6 +389,7 @@ export interface AddProviderRequest {
 	store_raw_request_response?: boolean;
 	custom_provider_config?: CustomProviderConfig;
 	openai_config?: OpenAIConfig;
+	huggingface_config?: HuggingFaceConfig;
 }
 
 // UpdateProviderRequest matching Go's UpdateProviderRequest
@@ -347,6 +402,7 @@ export interface UpdateProviderRequest {
 	store_raw_request_response?: boolean;
 	custom_provider_config?: CustomProviderConfig;
 	openai_config?: OpenAIConfig;
+	huggingface_config?: HuggingFaceConfig;
 }
 
 export interface CreateProviderKeyRequest extends ModelProviderKey {}
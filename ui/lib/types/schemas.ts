@@ -227,6 +227,32 @@ export const sglKeyConfigSchema = z
 		path: ["url"],
 	});
 
+// HuggingFace key config schema. Edited through config.json or the API; the form carries it
+// through unchanged so saving a key does not drop it.
+export const huggingfaceKeyConfigSchema = z.object({
+	inference_provider: z.string().optional(),
+	endpoints: z.record(z.string(), z.string()).optional(),
+	proxy_config: z
+		.object({
+			type: z.enum(["none", "http", "socks5", "environment"]),
+			url: envVarSchema.optional(),
+			username: envVarSchema.optional(),
+			password: envVarSchema.optional(),
+			ca_cert_pem: envVarSchema.optional(),
+		})
+		.optional(),
+	max_concurrency: z.record(z.string(), z.number().int().min(0)).optional(),
+	pricing: z
+		.record(
+			z.string(),
+			z.object({
+				input_per_million_tokens: z.number().min(0),
+				output_per_million_tokens: z.number().min(0).optional(),
+			}),
+		)
+		.optional(),
+});
+
 // Model provider key schema
 export const modelProviderKeySchema = z
 	.object({
@@ -261,6 +287,7 @@ export const modelProviderKeySchema = z
 		replicate_key_config: replicateKeyConfigSchema.optional(),
 		ollama_key_config: ollamaKeyConfigSchema.optional(),
 		sgl_key_config: sglKeyConfigSchema.optional(),
+		huggingface_key_config: huggingfaceKeyConfigSchema.optional(),
 		use_for_batch_api: z.boolean().optional(),
 		enabled: z.boolean().optional(),
 	})